@@ -0,0 +1,62 @@
+// eventcheck validates random event files, or a whole mods directory, before they go into the game
+//
+//	go run ./cmd/eventcheck internal/data/events.json
+//	go run ./cmd/eventcheck GameData/mods
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/dominik-merdzik/project-starbyte/internal/data"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, "usage: eventcheck <events.json | mods dir>...")
+		os.Exit(2)
+	}
+
+	// factions and enemy ships are needed to catch events pointing at ones that don't exist
+	if err := data.LoadFactions(); err != nil {
+		fmt.Fprintln(os.Stderr, "Error failed to load factions:", err)
+	}
+	if err := data.LoadEnemyShips(); err != nil {
+		fmt.Fprintln(os.Stderr, "Error failed to load enemy ships:", err)
+	}
+
+	failed := false
+	for _, path := range os.Args[1:] {
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			report := data.LoadMods(path, nil)
+			for _, problem := range report.Problems {
+				fmt.Fprintln(os.Stderr, problem)
+				failed = true
+			}
+			fmt.Printf("%s: %d mods checked\n", path, len(report.Loaded))
+			continue
+		}
+
+		file, err := os.Open(path)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			failed = true
+			continue
+		}
+		events, err := data.ParseEvents(file)
+		file.Close()
+		if err == nil {
+			err = data.CheckEventReferences(events)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s:\n%v\n", path, err)
+			failed = true
+			continue
+		}
+		fmt.Printf("%s: %d events OK\n", path, len(events))
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
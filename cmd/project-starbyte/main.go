@@ -21,6 +21,7 @@ type menuModel struct {
 	cursor     int
 	output     string
 	configPath string
+	mods       data.ModReport // what loading the mods came to, shown once the game starts
 }
 
 func main() {
@@ -44,6 +45,13 @@ func main() {
 	// initialize the background music using the loaded config
 	music.PlayBackgroundMusicFromEmbed(cfg.Music)
 
+	// load content mods before any game data is read, so they can override it
+	// the report is shown once the game starts, the alt screen would swallow anything logged now
+	var mods data.ModReport
+	if cfg.Mods.Enabled {
+		mods = data.LoadMods(data.ModsDir, cfg.Mods.Disabled)
+	}
+
 	// Setup menu choices.
 	var choices []string
 	if data.SaveExists() {
@@ -56,6 +64,7 @@ func main() {
 	model := menuModel{
 		choices:    choices,
 		configPath: absConfigPath,
+		mods:       mods,
 	}
 
 	p := tea.NewProgram(model)
@@ -86,9 +95,10 @@ func (m menuModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "enter":
 			switch m.choices[m.cursor] {
 			case "Start New Game":
-				return views.NewGameCreationModel(), tea.EnterAltScreen
+				newGame := views.NewGameCreationModel(m.mods)
+				return newGame, tea.Batch(tea.EnterAltScreen, newGame.Init())
 			case "Enter Game":
-				return views.NewGameModel(), tea.EnterAltScreen
+				return views.NewGameModel(m.mods), tea.EnterAltScreen
 			case "Edit Config":
 				m.output = "You can find and edit your config file at:\n" + m.configPath
 			case "Help":
@@ -10,6 +10,7 @@ import (
 
 type Config struct {
 	Music MusicConfig `toml:"music"`
+	Mods  ModsConfig  `toml:"mods"`
 }
 
 type MusicConfig struct {
@@ -17,12 +18,23 @@ type MusicConfig struct {
 	Volume  int  `toml:"volume"`
 }
 
+type ModsConfig struct {
+	Enabled  bool     `toml:"enabled"`
+	Disabled []string `toml:"disabled"` // folder names of mods to skip
+}
+
 const defaultConfigContent = `[music] # Background music configuration
 # Set to false to disable background music
 enabled = true
 # Set the volume level from 0 to 100
 volume = 15 
 
+[mods] # Content mods loaded from GameData/mods
+# Set to false to play without any mods
+enabled = true
+# Folder names of mods to skip, e.g. ["my_campaign"]
+disabled = []
+
 [keybindings] # Keybindings for the game
 vim_mode = false # Set to true to enable vim keybindings
 `
@@ -43,8 +55,8 @@ func InitConfig(configPath string) (Config, error) {
 		}
 	}
 
-	// load the config file
-	var cfg Config
+	// load the config file, configs written before mods existed keep them enabled
+	cfg := Config{Mods: ModsConfig{Enabled: true}}
 	if _, err := toml.DecodeFile(configPath, &cfg); err != nil {
 		return cfg, fmt.Errorf("error decoding config file: %w", err)
 	}
@@ -0,0 +1,70 @@
+package data
+
+// AddCargoItem adds up to quantity units of an item to the cargo hold, stacking with an existing entry of the same name
+// only what fits in the free space is stored, it returns how many units were
+func AddCargoItem(cargo *Cargo, name string, quantity int) int {
+	RecalculateCargo(cargo)
+	quantity = min(quantity, cargo.Capacity-cargo.UsedCapacity)
+	if quantity <= 0 {
+		return 0
+	}
+	defer RecalculateCargo(cargo)
+	for i := range cargo.Items {
+		if cargo.Items[i].Name == name {
+			cargo.Items[i].Quantity += quantity
+			return quantity
+		}
+	}
+	cargo.Items = append(cargo.Items, CargoItem{
+		ItemId:   generateRandomID("ITEM_"),
+		Name:     name,
+		Quantity: quantity,
+	})
+	return quantity
+}
+
+// StowCargo adds what fits of an item to the hold and notes anything left behind in the captain's log
+// it returns the units that didn't fit
+func StowCargo(save *FullGameSave, name string, quantity int) int {
+	lost := max(quantity, 0) - AddCargoItem(&save.Ship.Cargo, name, quantity)
+	if lost > 0 {
+		WriteLog(save, LogEvent, "No room in the hold for %d %s, it was left behind.", lost, name)
+	}
+	return lost
+}
+
+// RemoveCargoItem takes up to quantity units of an item out of the cargo hold and returns how many were removed
+func RemoveCargoItem(cargo *Cargo, name string, quantity int) int {
+	for i := range cargo.Items {
+		if cargo.Items[i].Name != name {
+			continue
+		}
+		removed := min(quantity, cargo.Items[i].Quantity)
+		cargo.Items[i].Quantity -= removed
+		if cargo.Items[i].Quantity <= 0 {
+			cargo.Items = append(cargo.Items[:i], cargo.Items[i+1:]...)
+		}
+		RecalculateCargo(cargo)
+		return removed
+	}
+	return 0
+}
+
+// CargoQuantity returns how many units of an item are in the cargo hold
+func CargoQuantity(cargo Cargo, name string) int {
+	for _, item := range cargo.Items {
+		if item.Name == name {
+			return item.Quantity
+		}
+	}
+	return 0
+}
+
+// RecalculateCargo sets UsedCapacity to the total number of units in the hold
+func RecalculateCargo(cargo *Cargo) {
+	used := 0
+	for _, item := range cargo.Items {
+		used += item.Quantity
+	}
+	cargo.UsedCapacity = used
+}
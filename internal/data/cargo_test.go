@@ -0,0 +1,45 @@
+package data
+
+import "testing"
+
+func TestAddCargoItem(t *testing.T) {
+	tests := []struct {
+		name       string
+		capacity   int
+		held       []CargoItem
+		add        int
+		wantStored int
+		wantUsed   int
+	}{
+		{name: "room to spare", capacity: 10, add: 4, wantStored: 4, wantUsed: 4},
+		{name: "stacks with what is held", capacity: 10, held: []CargoItem{{Name: "Ore", Quantity: 3}}, add: 2, wantStored: 2, wantUsed: 5},
+		{name: "only what fits", capacity: 10, held: []CargoItem{{Name: "Grain", Quantity: 8}}, add: 5, wantStored: 2, wantUsed: 10},
+		{name: "full hold", capacity: 10, held: []CargoItem{{Name: "Grain", Quantity: 10}}, add: 1, wantStored: 0, wantUsed: 10},
+		{name: "over a shrunken hold", capacity: 5, held: []CargoItem{{Name: "Grain", Quantity: 8}}, add: 1, wantStored: 0, wantUsed: 8},
+		{name: "nothing to add", capacity: 10, add: 0, wantStored: 0, wantUsed: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cargo := Cargo{Capacity: tt.capacity, Items: append([]CargoItem(nil), tt.held...)}
+			if got := AddCargoItem(&cargo, "Ore", tt.add); got != tt.wantStored {
+				t.Errorf("AddCargoItem() stored %d, want %d", got, tt.wantStored)
+			}
+			if cargo.UsedCapacity != tt.wantUsed {
+				t.Errorf("hold uses %d, want %d", cargo.UsedCapacity, tt.wantUsed)
+			}
+		})
+	}
+}
+
+func TestStowCargoLogsWhatIsLeftBehind(t *testing.T) {
+	save := &FullGameSave{Ship: Ship{Cargo: Cargo{Capacity: 3}}}
+	if lost := StowCargo(save, "Scrap", 5); lost != 2 {
+		t.Fatalf("StowCargo() lost %d, want 2", lost)
+	}
+	if len(save.Log) != 1 {
+		t.Fatalf("log has %d entries, want the loss noted once", len(save.Log))
+	}
+	if lost := StowCargo(save, "Scrap", 0); lost != 0 || len(save.Log) != 1 {
+		t.Errorf("stowing nothing lost %d and logged %d entries", lost, len(save.Log))
+	}
+}
@@ -0,0 +1,159 @@
+package data
+
+import (
+	"fmt"
+	"hash/fnv"
+	"time"
+)
+
+// the game clock counts hours since the start of a new game, stardates are derived from it
+const (
+	StardateEpoch = 2450 // stardate of the first day of a new game
+	HoursPerDay   = 24
+)
+
+// hours each activity takes on the game clock
+const (
+	DockingHours  = 2
+	ResearchHours = 6 // per research note used
+	RestHours     = 8
+)
+
+// daily upkeep and recovery rates
+const (
+	FoodPerCrewPerDay   = 1
+	WagePerDegree       = 10 // credits a crew member is paid per day for each degree
+	RecoveryPerHour     = 1  // health an injured crew member regains every hour
+	MedicRecoveryBonus  = 1  // extra health per hour with a medic aboard
+	hungerPenalty       = 5  // health lost per day without food
+	unpaidMoralePenalty = 10
+	restMoraleBonus     = 5
+)
+
+// ClockReport sums up what happened while the clock ran
+type ClockReport struct {
+	Days      int // days that ended
+	FoodEaten int
+	WagesPaid int
+	Starving  bool // the ship ran out of food
+	Unpaid    bool // wages couldn't be paid in full
+
+	Researched *ResearchNode // research node the lab finished
+}
+
+// Stardate formats a game clock hour as a stardate, the fraction is tenths of a day
+func Stardate(hours int) string {
+	return fmt.Sprintf("%d.%d", StardateEpoch+hours/HoursPerDay, hours%HoursPerDay*10/HoursPerDay)
+}
+
+// Day returns the day of the game clock an hour falls on
+func Day(hours int) int {
+	return hours / HoursPerDay
+}
+
+// TravelHours converts a travel duration into hours on the game clock, every second in transit is an hour
+func TravelHours(d time.Duration) int {
+	return max(int(d.Seconds()), 1)
+}
+
+// MarketDrift returns the percentage a station's prices sit at on a given day
+// prices wander between 85% and 115% and change every day
+func MarketDrift(loc Location, day int) int {
+	h := fnv.New32a()
+	fmt.Fprintf(h, "%s/%s/%d", loc.StarSystemName, loc.PlanetName, day)
+	return 85 + int(h.Sum32()%31)
+}
+
+// AdvanceClock moves the game clock forward by the given hours and runs everything tied to it
+// injured crew recover every hour, at the end of each day the crew eat and are paid
+func AdvanceClock(save *FullGameSave, hours int) ClockReport {
+	var report ClockReport
+	if hours <= 0 {
+		return report
+	}
+
+	start := save.GameMetadata.ClockHours
+	save.GameMetadata.ClockHours += hours
+	report.Days = Day(save.GameMetadata.ClockHours) - Day(start)
+
+	recoverCrew(save.Crew, hours)
+	ExpireAnomalies(save)
+	report.Researched = progressResearch(save, hours)
+
+	for range report.Days {
+		// food
+		needed := DailyFood(save.Crew)
+		eaten := min(needed, save.Ship.Food)
+		save.Ship.Food -= eaten
+		report.FoodEaten += eaten
+		if eaten < needed {
+			report.Starving = true
+			for i := range save.Crew {
+				alive := save.Crew[i].Health > 0
+				save.Crew[i].Health = max(save.Crew[i].Health-hungerPenalty, 0)
+				if alive && save.Crew[i].Health == 0 {
+					WriteLog(save, LogCrew, "%s (%s) died of starvation.", save.Crew[i].Name, save.Crew[i].Role)
+				}
+			}
+		}
+
+		// wages
+		wages := DailyWages(save.Crew)
+		paid := min(wages, max(save.Player.Credits, 0))
+		save.Player.Credits -= paid
+		report.WagesPaid += paid
+		if paid < wages {
+			report.Unpaid = true
+			for i := range save.Crew {
+				save.Crew[i].Morale = max(save.Crew[i].Morale-unpaidMoralePenalty, 0)
+			}
+		}
+	}
+	return report
+}
+
+// Rest lets the crew stand down for a while, time passes and morale improves
+func Rest(save *FullGameSave) ClockReport {
+	report := AdvanceClock(save, RestHours)
+	for i := range save.Crew {
+		if save.Crew[i].Health > 0 {
+			save.Crew[i].Morale = min(save.Crew[i].Morale+restMoraleBonus, 100)
+		}
+	}
+	return report
+}
+
+// DailyFood returns the food the living crew eat each day
+func DailyFood(crew []CrewMember) int {
+	food := 0
+	for _, member := range crew {
+		if member.Health > 0 {
+			food += FoodPerCrewPerDay
+		}
+	}
+	return food
+}
+
+// DailyWages returns the credits the crew are owed each day
+func DailyWages(crew []CrewMember) int {
+	wages := 0
+	for _, member := range crew {
+		if member.Health > 0 {
+			wages += WagePerDegree * max(member.Degree, 1)
+		}
+	}
+	return wages
+}
+
+// recoverCrew heals injured crew members, a medic aboard speeds things up
+func recoverCrew(crew []CrewMember, hours int) {
+	rate := RecoveryPerHour
+	if crewRoleAlive(crew, CrewRoleMedic) {
+		rate += MedicRecoveryBonus
+	}
+	for i := range crew {
+		if crew[i].Health > 0 && crew[i].Health < 100 {
+			crew[i].Health = min(crew[i].Health+rate*hours, 100)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package data
+
+import "testing"
+
+func TestAdvanceClock(t *testing.T) {
+	pilot := CrewMember{Name: "Ada", Role: CrewRolePilot, Degree: 2, Health: 100, Morale: 50}
+	wounded := CrewMember{Name: "Bo", Role: CrewRoleEngineer, Degree: 1, Health: 40, Morale: 50}
+	dead := CrewMember{Name: "Cy", Role: CrewRoleScientist, Degree: 3, Health: 0, Morale: 50}
+	medic := CrewMember{Name: "Di", Role: CrewRoleMedic, Degree: 1, Health: 100, Morale: 50}
+
+	tests := []struct {
+		name    string
+		start   int // clock hour the run starts at
+		hours   int
+		food    int
+		credits int
+		crew    []CrewMember
+
+		want        ClockReport
+		wantFood    int
+		wantCredits int
+		wantHealth  []int
+		wantMorale  []int
+	}{
+		{
+			name: "no time passes", hours: 0, food: 10, credits: 100, crew: []CrewMember{wounded},
+			wantFood: 10, wantCredits: 100, wantHealth: []int{40}, wantMorale: []int{50},
+		},
+		{
+			name: "within the day", start: 1, hours: 5, food: 10, credits: 100, crew: []CrewMember{pilot, wounded},
+			wantFood: 10, wantCredits: 100, wantHealth: []int{100, 40 + 5*RecoveryPerHour}, wantMorale: []int{50, 50},
+		},
+		{
+			name: "a medic speeds recovery", start: 1, hours: 5, food: 10, credits: 100, crew: []CrewMember{wounded, medic},
+			wantFood: 10, wantCredits: 100, wantHealth: []int{40 + 5*(RecoveryPerHour+MedicRecoveryBonus), 100}, wantMorale: []int{50, 50},
+		},
+		{
+			name: "crossing midnight", start: 20, hours: 6, food: 10, credits: 100, crew: []CrewMember{pilot},
+			want:     ClockReport{Days: 1, FoodEaten: FoodPerCrewPerDay, WagesPaid: 2 * WagePerDegree},
+			wantFood: 10 - FoodPerCrewPerDay, wantCredits: 100 - 2*WagePerDegree, wantHealth: []int{100}, wantMorale: []int{50},
+		},
+		{
+			name: "the dead neither eat nor get paid", start: 0, hours: 24, food: 10, credits: 100, crew: []CrewMember{pilot, dead},
+			want:     ClockReport{Days: 1, FoodEaten: FoodPerCrewPerDay, WagesPaid: 2 * WagePerDegree},
+			wantFood: 10 - FoodPerCrewPerDay, wantCredits: 100 - 2*WagePerDegree, wantHealth: []int{100, 0}, wantMorale: []int{50, 50},
+		},
+		{
+			name: "several days", start: 0, hours: 72, food: 10, credits: 100, crew: []CrewMember{pilot},
+			want:     ClockReport{Days: 3, FoodEaten: 3 * FoodPerCrewPerDay, WagesPaid: 6 * WagePerDegree},
+			wantFood: 10 - 3*FoodPerCrewPerDay, wantCredits: 100 - 6*WagePerDegree, wantHealth: []int{100}, wantMorale: []int{50},
+		},
+		{
+			name: "out of food", start: 0, hours: 24, food: 0, credits: 100, crew: []CrewMember{pilot},
+			want:     ClockReport{Days: 1, WagesPaid: 2 * WagePerDegree, Starving: true},
+			wantFood: 0, wantCredits: 100 - 2*WagePerDegree, wantHealth: []int{100 - hungerPenalty}, wantMorale: []int{50},
+		},
+		{
+			name: "wages short", start: 0, hours: 24, food: 10, credits: 5, crew: []CrewMember{pilot},
+			want:     ClockReport{Days: 1, FoodEaten: FoodPerCrewPerDay, WagesPaid: 5, Unpaid: true},
+			wantFood: 10 - FoodPerCrewPerDay, wantCredits: 0, wantHealth: []int{100}, wantMorale: []int{50 - unpaidMoralePenalty},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			save := &FullGameSave{
+				Player: Player{Credits: tt.credits},
+				Ship:   Ship{Food: tt.food},
+				Crew:   append([]CrewMember(nil), tt.crew...),
+			}
+			save.GameMetadata.ClockHours = tt.start
+
+			got := AdvanceClock(save, tt.hours)
+			if got != tt.want {
+				t.Errorf("AdvanceClock() report = %+v, want %+v", got, tt.want)
+			}
+			if save.GameMetadata.ClockHours != tt.start+tt.hours {
+				t.Errorf("clock = %d, want %d", save.GameMetadata.ClockHours, tt.start+tt.hours)
+			}
+			if save.Ship.Food != tt.wantFood || save.Player.Credits != tt.wantCredits {
+				t.Errorf("food %d and credits %d, want %d and %d", save.Ship.Food, save.Player.Credits, tt.wantFood, tt.wantCredits)
+			}
+			for i, member := range save.Crew {
+				if member.Health != tt.wantHealth[i] || member.Morale != tt.wantMorale[i] {
+					t.Errorf("%s has health %d and morale %d, want %d and %d", member.Name, member.Health, member.Morale, tt.wantHealth[i], tt.wantMorale[i])
+				}
+			}
+		})
+	}
+}
+
+func TestStarvationKillsAndIsLogged(t *testing.T) {
+	// an hour before the day ends, the hour's recovery leaves just enough health for the hunger to take
+	save := &FullGameSave{Crew: []CrewMember{{Name: "Ada", Role: CrewRolePilot, Health: hungerPenalty - RecoveryPerHour, Morale: 50}}}
+	save.GameMetadata.ClockHours = HoursPerDay - 1
+	AdvanceClock(save, 1)
+
+	if save.Crew[0].Health != 0 {
+		t.Fatalf("health = %d, want the crew member starved", save.Crew[0].Health)
+	}
+	if len(save.Log) != 1 || save.Log[0].Type != LogCrew {
+		t.Errorf("log = %v, want the death recorded once", save.Log)
+	}
+
+	// the dead don't starve again, so the death isn't logged twice
+	AdvanceClock(save, HoursPerDay)
+	if len(save.Log) != 1 {
+		t.Errorf("log has %d entries after another day, want 1", len(save.Log))
+	}
+}
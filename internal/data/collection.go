@@ -0,0 +1,188 @@
+package data
+
+import (
+	"fmt"
+	"slices"
+)
+
+// collection tuning
+const (
+	BaseCollectionCapacity  = 100
+	AnalyzeHours            = 4  // hours a scientist spends taking an item apart
+	collectorPricePerTier   = 40 // collectors pay this times the square of an item's tier
+	donationStandingPerTier = 2  // standing a donation is worth for every tier of the item
+	maxNoteTier             = 5
+)
+
+// CollectionUsed returns how much of the collection's capacity its notes and items take up
+func CollectionUsed(collection Collection) int {
+	used := 0
+	for _, note := range collection.ResearchNotes {
+		used += note.Quantity
+	}
+	for _, item := range collection.Items {
+		used += item.Quantity
+	}
+	return used
+}
+
+// CollectionFull reports whether the collection has no room left
+func CollectionFull(collection Collection) bool {
+	return collection.MaxCapacity > 0 && CollectionUsed(collection) >= collection.MaxCapacity
+}
+
+// ApplyCollectionCapacity sets the collection's capacity from the ship's specimen vault and research
+func ApplyCollectionCapacity(save *FullGameSave) {
+	save.Collection.MaxCapacity = save.Ship.Stats().CollectionCapacity
+	save.Collection.UsedCapacity = CollectionUsed(save.Collection)
+}
+
+// AddCollectionItem adds one of an item to the collection, stacking it with any it already holds
+// it reports false when the collection is full
+func AddCollectionItem(collection *Collection, item CollectionItem) bool {
+	if CollectionFull(*collection) {
+		return false
+	}
+	defer func() { collection.UsedCapacity = CollectionUsed(*collection) }()
+
+	for i := range collection.Items {
+		if collection.Items[i].Name == item.Name {
+			collection.Items[i].Quantity++
+			return true
+		}
+	}
+	item.ItemId = generateRandomID("ITEM_")
+	item.Quantity = 1
+	collection.Items = append(collection.Items, item)
+	return true
+}
+
+// AddResearchNote adds a research note of the given tier to the collection
+// it reports false when the collection is full
+func AddResearchNote(collection *Collection, tier int) (ResearchNoteTier, bool) {
+	i := slices.IndexFunc(collection.ResearchNotes, func(n ResearchNoteTier) bool { return n.Tier == tier })
+	if i < 0 || CollectionFull(*collection) {
+		return ResearchNoteTier{}, false
+	}
+	collection.ResearchNotes[i].Quantity++
+	collection.UsedCapacity = CollectionUsed(*collection)
+	return collection.ResearchNotes[i], true
+}
+
+// FindCollectionItem returns the item held under a name, or nil if there are none
+func FindCollectionItem(collection *Collection, name string) *CollectionItem {
+	for i := range collection.Items {
+		if collection.Items[i].Name == name && collection.Items[i].Quantity > 0 {
+			return &collection.Items[i]
+		}
+	}
+	return nil
+}
+
+// removeCollectionItem takes some of an item out of the collection, dropping the item once none are left
+func removeCollectionItem(collection *Collection, name string, quantity int) {
+	collection.Items = slices.DeleteFunc(collection.Items, func(item CollectionItem) bool {
+		if item.Name != name {
+			return false
+		}
+		return item.Quantity <= quantity
+	})
+	for i := range collection.Items {
+		if collection.Items[i].Name == name {
+			collection.Items[i].Quantity -= quantity
+		}
+	}
+	collection.UsedCapacity = CollectionUsed(*collection)
+}
+
+// CollectorPrice returns what collectors at a faction's stations pay for an item
+// collectors pay more the better the player stands with their faction
+func CollectorPrice(item CollectionItem, rep Reputation, faction string) int {
+	return AdjustPrice(collectorPricePerTier*item.Tier*item.Tier, RewardModifier(rep, faction))
+}
+
+// DonationStanding returns the standing a faction grants for a donated item
+func DonationStanding(item CollectionItem) int {
+	return donationStandingPerTier * max(item.Tier, 1)
+}
+
+// bestScientist returns the most experienced scientist still standing, or nil if there are none aboard
+func bestScientist(crew []CrewMember) *CrewMember {
+	var best *CrewMember
+	for i := range crew {
+		member := &crew[i]
+		if member.Role == CrewRoleScientist && member.Health > 0 && (best == nil || member.Degree > best.Degree) {
+			best = member
+		}
+	}
+	return best
+}
+
+// AnalyzeItem has the best scientist aboard take an item apart and write it up as a research note
+// the note's tier follows the item's, experienced scientists get more out of it
+// the caller runs the clock for AnalyzeHours
+func AnalyzeItem(save *FullGameSave, name string) (ResearchNoteTier, error) {
+	item := FindCollectionItem(&save.Collection, name)
+	if item == nil {
+		return ResearchNoteTier{}, fmt.Errorf("there is no %s in the collection", name)
+	}
+	scientist := bestScientist(save.Crew)
+	if scientist == nil {
+		return ResearchNoteTier{}, fmt.Errorf("analyzing the %s needs a scientist aboard", name)
+	}
+
+	tier := min(max(item.Tier, 1)+scientist.Degree/5+save.Ship.Research.Bonus().NoteTier, maxNoteTier)
+	// work on a copy so the item is only used up once the note has been filed
+	collection := save.Collection
+	collection.Items = slices.Clone(save.Collection.Items)
+	collection.ResearchNotes = slices.Clone(save.Collection.ResearchNotes)
+	removeCollectionItem(&collection, name, 1) // the item makes room for the note
+	note, ok := AddResearchNote(&collection, tier)
+	if !ok {
+		return ResearchNoteTier{}, fmt.Errorf("there is nowhere to file a tier %d research note", tier)
+	}
+	save.Collection = collection
+	WriteLog(save, LogCollection, "%s analyzed the %s and wrote up %s.", scientist.Name, name, note.Name)
+	return note, nil
+}
+
+// SellItem sells an item to the collectors at the station the ship is docked at and returns the price paid
+func SellItem(save *FullGameSave, name string) (int, error) {
+	item := FindCollectionItem(&save.Collection, name)
+	if item == nil {
+		return 0, fmt.Errorf("there is no %s in the collection", name)
+	}
+	station := StationAt(save.GameMap, save.Ship.Location)
+	if station == nil {
+		return 0, fmt.Errorf("collectors only trade at space stations")
+	}
+	faction := station.Faction
+	if !CanDock(save.Player.Reputation, faction) {
+		return 0, fmt.Errorf("the %s won't trade with you", FactionName(faction))
+	}
+
+	price := CollectorPrice(*item, save.Player.Reputation, faction)
+	removeCollectionItem(&save.Collection, name, 1)
+	save.Player.Credits += price
+	save.GameMetadata.Stats.Earn(price)
+	WriteLog(save, LogCollection, "Sold the %s to a collector for %d credits.", name, price)
+	return price, nil
+}
+
+// DonateItem gives an item to the faction holding the ship's location and returns the faction and the standing gained
+func DonateItem(save *FullGameSave, name string) (string, int, error) {
+	item := FindCollectionItem(&save.Collection, name)
+	if item == nil {
+		return "", 0, fmt.Errorf("there is no %s in the collection", name)
+	}
+	faction := ControllingFaction(save.GameMap, save.Ship.Location)
+	if faction == "" {
+		return "", 0, fmt.Errorf("no faction holds this system to donate to")
+	}
+
+	standing := DonationStanding(*item)
+	removeCollectionItem(&save.Collection, name, 1)
+	ChangeReputation(&save.Player.Reputation, faction, standing)
+	WriteLog(save, LogCollection, "Donated the %s to the %s.", name, FactionName(faction))
+	return faction, standing, nil
+}
@@ -0,0 +1,301 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+
+	_ "embed"
+)
+
+//go:embed enemy_ships.json
+var embeddedEnemyShips []byte
+
+// EnemyShip is the definition of a hostile ship the player can fight
+type EnemyShip struct {
+	ID          string         `json:"id"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Faction     string         `json:"faction"`
+	Hull        int            `json:"hull"`
+	Shields     int            `json:"shields"`
+	WeaponPower int            `json:"weaponPower"`
+	Accuracy    int            `json:"accuracy"`
+	Evasion     int            `json:"evasion"`
+	HailChance  int            `json:"hailChance"`
+	Loot        CombatLoot     `json:"loot"`
+	Reputation  map[string]int `json:"reputation"`
+}
+
+// CombatLoot is what the player receives for defeating an enemy ship
+type CombatLoot struct {
+	Credits int         `json:"credits"`
+	Items   []CargoItem `json:"items"`
+}
+
+var EnemyShips []EnemyShip
+
+// LoadEnemyShips loads the enemy ship definitions from the embedded enemy_ships.json
+func LoadEnemyShips() error {
+	var ships []EnemyShip
+	if err := json.NewDecoder(bytes.NewReader(embeddedEnemyShips)).Decode(&ships); err != nil {
+		return err
+	}
+	EnemyShips = ships
+	return nil
+}
+
+// FindEnemyShip returns a copy of the enemy ship with the given id, or nil if none exists
+func FindEnemyShip(id string) *EnemyShip {
+	for _, enemy := range EnemyShips {
+		if enemy.ID == id {
+			e := enemy
+			return &e
+		}
+	}
+	return nil
+}
+
+// ---------------------
+// Combat rules
+// ---------------------
+
+type CombatAction int
+
+const (
+	CombatActionFire CombatAction = iota
+	CombatActionRaiseShields
+	CombatActionEvade
+	CombatActionHail
+	CombatActionFlee
+)
+
+// AllCombatActions lists the actions in the order they are shown to the player
+var AllCombatActions = []CombatAction{
+	CombatActionFire,
+	CombatActionRaiseShields,
+	CombatActionEvade,
+	CombatActionHail,
+	CombatActionFlee,
+}
+
+func (a CombatAction) String() string {
+	return [...]string{"Fire", "Raise Shields", "Evade", "Hail", "Flee"}[a]
+}
+
+type CombatOutcome int
+
+const (
+	CombatOngoing CombatOutcome = iota
+	CombatVictory
+	CombatDefeat
+	CombatFled
+	CombatTruce
+)
+
+func (o CombatOutcome) String() string {
+	return [...]string{"Ongoing", "Victory", "Defeat", "Escaped", "Truce"}[o]
+}
+
+const (
+	fleeFuelCost         = 10 // fuel burned by a flee attempt
+	moduleDamageChance   = 25 // % chance that a hit on the hull also damages a module
+	raisedShieldsDivisor = 2  // incoming damage is divided by this while shields are raised
+)
+
+// CombatStats are the player's combat numbers, worked out from the ship and crew
+type CombatStats struct {
+	Attack     int // damage dealt by a successful shot
+	Accuracy   int // % chance to hit before the enemy's evasion
+	Evasion    int // subtracted from the enemy's chance to hit
+	FleeChance int // % chance a flee attempt succeeds
+	HailBonus  int // added to the enemy's hail chance
+}
+
+// PlayerCombatStats combines weapon upgrades, the best pilot, weapons specialist and
+// communications officer on board, and the crew's buffs and debuffs
+func PlayerCombatStats(ship Ship, crew []CrewMember) CombatStats {
+	pilot := bestDegree(crew, CrewRolePilot)
+	gunner := bestDegree(crew, CrewRoleWeaponsSpecialist)
+	comms := bestDegree(crew, CrewRoleCommunicationsOfficer)
+
+	stats := CombatStats{
+		Attack:     CalculateShipStats(ship.Upgrades).WeaponPower + 2*gunner,
+		Accuracy:   60 + 5*gunner,
+		Evasion:    10 + 5*pilot,
+		FleeChance: 30 + 5*pilot,
+		HailBonus:  10*comms + ship.Research.Bonus().HailChance,
+	}
+
+	for _, member := range crew {
+		for _, buff := range member.Buffs {
+			switch buff {
+			case "Sharp Shooter":
+				stats.Accuracy += 10
+			case "Quick Reflexes":
+				stats.Evasion += 10
+			case "Expert Navigator":
+				stats.FleeChance += 10
+			case "Iron Will":
+				stats.HailBonus += 5
+			case "Enhanced Strength":
+				stats.Attack += 2
+			}
+		}
+		for _, debuff := range member.Debuffs {
+			switch debuff {
+			case "Unfocused", "Distracted":
+				stats.Accuracy -= 5
+			case "Sluggish", "Tired":
+				stats.Evasion -= 5
+			case "Injured":
+				stats.FleeChance -= 5
+			}
+		}
+	}
+	return stats
+}
+
+// bestDegree returns the highest degree among healthy crew members with the given role
+func bestDegree(crew []CrewMember, role CrewRole) int {
+	best := 0
+	for _, member := range crew {
+		if member.Role == role && member.Health > 0 && member.Degree > best {
+			best = member.Degree
+		}
+	}
+	return best
+}
+
+// CombatEncounter is the state of a single fight between the player and an enemy ship
+type CombatEncounter struct {
+	Enemy         EnemyShip
+	EnemyHull     int
+	EnemyShields  int
+	Turn          int
+	Outcome       CombatOutcome
+	Log           []string
+	hailAttempted bool
+}
+
+// NewCombatEncounter starts a fight against the given enemy at full strength
+func NewCombatEncounter(enemy EnemyShip) *CombatEncounter {
+	return &CombatEncounter{
+		Enemy:        enemy,
+		EnemyHull:    enemy.Hull,
+		EnemyShields: enemy.Shields,
+		Turn:         1,
+		Outcome:      CombatOngoing,
+		Log:          []string{fmt.Sprintf("%s moves to engage!", enemy.Name)},
+	}
+}
+
+// ResolveTurn plays out the player's action and the enemy's response, applying damage to the ship
+func (c *CombatEncounter) ResolveTurn(action CombatAction, ship *Ship, crew []CrewMember) {
+	if c.Outcome != CombatOngoing {
+		return
+	}
+	stats := PlayerCombatStats(*ship, crew)
+	shieldsRaised := false
+	evasion := stats.Evasion
+
+	switch action {
+	case CombatActionFire:
+		if rand.Intn(100) < stats.Accuracy-c.Enemy.Evasion {
+			damage := stats.Attack + rand.Intn(stats.Attack/2+1)
+			absorbed := min(damage, c.EnemyShields)
+			c.EnemyShields -= absorbed
+			c.EnemyHull = max(c.EnemyHull-(damage-absorbed), 0)
+			c.addLog("Direct hit! %d damage (%d absorbed by shields).", damage, absorbed)
+		} else {
+			c.addLog("Your shot goes wide.")
+		}
+		if c.EnemyHull <= 0 {
+			c.Outcome = CombatVictory
+			c.addLog("The %s breaks apart!", c.Enemy.Name)
+			return
+		}
+	case CombatActionRaiseShields:
+		shieldsRaised = true
+		c.addLog("Shields raised. Incoming damage reduced this turn.")
+	case CombatActionEvade:
+		evasion *= 2
+		c.addLog("Your pilot throws the ship into evasive manoeuvres.")
+	case CombatActionHail:
+		if c.hailAttempted {
+			c.addLog("They aren't answering any more.")
+			break
+		}
+		c.hailAttempted = true
+		if c.Enemy.HailChance > 0 && rand.Intn(100) < c.Enemy.HailChance+stats.HailBonus {
+			c.Outcome = CombatTruce
+			c.addLog("The %s agrees to stand down.", c.Enemy.Name)
+			return
+		}
+		c.addLog("Your hail is met with static.")
+	case CombatActionFlee:
+		ship.Fuel = max(ship.Fuel-fleeFuelCost, 0)
+		if rand.Intn(100) < stats.FleeChance {
+			c.Outcome = CombatFled
+			c.addLog("You burn %d fuel and escape!", fleeFuelCost)
+			return
+		}
+		c.addLog("You burn %d fuel but fail to break away.", fleeFuelCost)
+	}
+
+	c.enemyTurn(ship, evasion, shieldsRaised)
+	c.Turn++
+}
+
+// enemyTurn resolves the enemy's attack against the player's ship
+func (c *CombatEncounter) enemyTurn(ship *Ship, evasion int, shieldsRaised bool) {
+	if rand.Intn(100) >= c.Enemy.Accuracy-evasion {
+		c.addLog("The %s fires and misses.", c.Enemy.Name)
+		return
+	}
+
+	damage := c.Enemy.WeaponPower + rand.Intn(c.Enemy.WeaponPower/2+1)
+	if shieldsRaised {
+		damage /= raisedShieldsDivisor
+	}
+	absorbed, hullDamage := AbsorbDamage(ship, damage)
+	c.addLog("The %s hits you for %d damage (%d absorbed by shields).", c.Enemy.Name, damage, absorbed)
+
+	// modules are only at risk once a shot gets through the shields
+	if hullDamage > 0 && len(ship.Modules) > 0 && rand.Intn(100) < moduleDamageChance {
+		module := &ship.Modules[rand.Intn(len(ship.Modules))]
+		module.Status = "damaged"
+		c.addLog("Your %s has been damaged!", module.Name)
+	}
+
+	if ship.HullIntegrity <= 0 {
+		c.Outcome = CombatDefeat
+		c.addLog("Your hull gives way...")
+	}
+}
+
+func (c *CombatEncounter) addLog(format string, args ...any) {
+	c.Log = append(c.Log, fmt.Sprintf("[T%d] ", c.Turn)+fmt.Sprintf(format, args...))
+}
+
+// ApplyCombatRewards gives the player the enemy's loot and reputation changes for a victory
+// a truce only changes reputation with the enemy's own faction, in their favour
+// it returns the units of loot left behind for want of room in the hold
+func ApplyCombatRewards(save *FullGameSave, c *CombatEncounter) int {
+	lost := 0
+	switch c.Outcome {
+	case CombatVictory:
+		save.Player.Credits += c.Enemy.Loot.Credits
+		save.GameMetadata.Stats.Earn(c.Enemy.Loot.Credits)
+		for _, item := range c.Enemy.Loot.Items {
+			lost += StowCargo(save, item.Name, item.Quantity)
+		}
+		for faction, delta := range c.Enemy.Reputation {
+			ChangeReputation(&save.Player.Reputation, faction, delta)
+		}
+	case CombatTruce:
+		ChangeReputation(&save.Player.Reputation, c.Enemy.Faction, 2)
+	}
+	return lost
+}
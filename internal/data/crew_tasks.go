@@ -0,0 +1,64 @@
+package data
+
+// CrewTask is a ship job that a crew member can be assigned to
+// the id is stored in CrewMember.AssignedTaskId
+type CrewTask string
+
+const (
+	CrewTaskShields           CrewTask = "shields"
+	CrewTaskEngineMaintenance CrewTask = "engine_maintenance"
+	CrewTaskDamageControl     CrewTask = "damage_control"
+	CrewTaskResearch          CrewTask = "research"
+)
+
+// AllCrewTasks lists the tasks in the order they are offered in the crew view
+var AllCrewTasks = []CrewTask{
+	CrewTaskShields,
+	CrewTaskEngineMaintenance,
+	CrewTaskDamageControl,
+	CrewTaskResearch,
+}
+
+func (t CrewTask) String() string {
+	switch t {
+	case CrewTaskShields:
+		return "Shield Maintenance"
+	case CrewTaskEngineMaintenance:
+		return "Engine Maintenance"
+	case CrewTaskDamageControl:
+		return "Damage Control"
+	case CrewTaskResearch:
+		return "Research Lab"
+	default:
+		return "Unassigned"
+	}
+}
+
+// AssignCrewTask puts the crew member on the given task, an empty task clears the assignment
+func AssignCrewTask(member *CrewMember, task CrewTask) {
+	if task == "" {
+		member.AssignedTaskId = nil
+		return
+	}
+	id := string(task)
+	member.AssignedTaskId = &id
+}
+
+// CurrentTask returns the task the crew member is assigned to, or "" if none
+func (c CrewMember) CurrentTask() CrewTask {
+	if c.AssignedTaskId == nil {
+		return ""
+	}
+	return CrewTask(*c.AssignedTaskId)
+}
+
+// AssignedToTask returns the healthy crew members working on the given task
+func AssignedToTask(crew []CrewMember, task CrewTask) []CrewMember {
+	var assigned []CrewMember
+	for _, member := range crew {
+		if member.CurrentTask() == task && member.Health > 0 {
+			assigned = append(assigned, member)
+		}
+	}
+	return assigned
+}
@@ -25,14 +25,20 @@ const version = "1.0.1-beta"
 // ---------------------
 
 type FullGameSave struct {
-	GameTitle    string       `json:"gameTitle"`
-	GameMetadata GameMetadata `json:"gameMetadata"`
-	Player       Player       `json:"player"`
-	Ship         Ship         `json:"ship"`
-	Crew         []CrewMember `json:"crew"`
-	Missions     []Mission    `json:"missions"`
-	GameMap      GameMap      `json:"gameMap"`
-	Collection   Collection   `json:"collection"`
+	GameTitle    string         `json:"gameTitle"`
+	GameMetadata GameMetadata   `json:"gameMetadata"`
+	Player       Player         `json:"player"`
+	Ship         Ship           `json:"ship"`
+	Crew         []CrewMember   `json:"crew"`
+	Missions     []Mission      `json:"missions"`
+	GameMap      GameMap        `json:"gameMap"`
+	Collection   Collection     `json:"collection"`
+	Quests       QuestLog       `json:"quests"`
+	EventLog     map[int]int    `json:"eventLog,omitempty"` // clock hour each random event last happened
+	Log          []LogEntry     `json:"log,omitempty"`      // the captain's log, oldest entry first
+	Contacts     []Contact      `json:"contacts,omitempty"` // alien species the crew has met
+	Stations     []StationState `json:"stations,omitempty"` // what the player has done at each station
+	Stranded     *StrandedState `json:"stranded,omitempty"` // set while the ship is out of fuel
 }
 
 type GameMetadata struct {
@@ -42,6 +48,10 @@ type GameMetadata struct {
 	TotalPlayTime      TotalPlayTime      `json:"totalPlayTime"`
 	DifficultySettings DifficultySettings `json:"difficultySettings"`
 	GameOver           bool               `json:"gameOver"`
+	ClockHours         int                `json:"clockHours"`              // hours on the game clock since the game began
+	NextMissionID      int                `json:"nextMissionId,omitempty"` // id the next generated mission gets
+	LossCause          LossCause          `json:"lossCause,omitempty"`     // why the run ended, once GameOver is set
+	Stats              RunStats           `json:"stats"`                   // totals for the epilogue
 }
 
 type TotalPlayTime struct {
@@ -64,6 +74,8 @@ type Player struct {
 	Level            int        `json:"level"`
 	Credits          int        `json:"credits"`
 	Reputation       Reputation `json:"reputation"`
+	Perks            []string   `json:"perks,omitempty"`     // ids of the perks picked, repeated once per rank
+	PerkPicks        int        `json:"perkPicks,omitempty"` // perk picks earned but not yet spent
 }
 
 type Reputation struct {
@@ -76,24 +88,25 @@ type Reputation struct {
 // ---------------------
 
 type Ship struct {
-	ShipId            string   `json:"shipId"`
-	ShipName          string   `json:"shipName"`
-	HullIntegrity     int      `json:"hullIntegrity"`
-	MaxHullIntegrity  int      `json:"maxHullIntegrity"`
-	ShieldStrength    int      `json:"shieldStrength"`
-	MaxShieldStrength int      `json:"maxShieldStrength"`
-	Fuel              int      `json:"fuel"`
-	MaxFuel           int      `json:"maxFuel"`
-	EngineHealth      int      `json:"engineHealth"`
-	MaxEngineHealth   int      `json:"maxEngineHealth"`
-	HasFTLDrive       bool     `json:"hasFTLDrive"`
-	FTLDriveHealth    int      `json:"ftlDriveHealth"`
-	FTLDriveCharge    int      `json:"ftlDriveCharge"`
-	Food              int      `json:"food"`
-	Location          Location `json:"location"`
-	Cargo             Cargo    `json:"cargo"`
-	Modules           []Module `json:"modules"`
-	Upgrades          Upgrades `json:"upgrades"`
+	ShipId            string        `json:"shipId"`
+	ShipName          string        `json:"shipName"`
+	HullIntegrity     int           `json:"hullIntegrity"`
+	MaxHullIntegrity  int           `json:"maxHullIntegrity"`
+	ShieldStrength    int           `json:"shieldStrength"`
+	MaxShieldStrength int           `json:"maxShieldStrength"`
+	Fuel              int           `json:"fuel"`
+	MaxFuel           int           `json:"maxFuel"`
+	EngineHealth      int           `json:"engineHealth"`
+	MaxEngineHealth   int           `json:"maxEngineHealth"`
+	HasFTLDrive       bool          `json:"hasFTLDrive"`
+	FTLDriveHealth    int           `json:"ftlDriveHealth"`
+	FTLDriveCharge    int           `json:"ftlDriveCharge"`
+	Food              int           `json:"food"`
+	Location          Location      `json:"location"`
+	Cargo             Cargo         `json:"cargo"`
+	Modules           []Module      `json:"modules"`
+	Upgrades          Upgrades      `json:"upgrades"`
+	Research          ResearchState `json:"research"`
 }
 
 type Coordinates struct {
@@ -122,9 +135,12 @@ type Module struct {
 }
 
 type Upgrades struct {
-	Engine         UpgradeLevel `json:"engine"`
-	WeaponSystems  UpgradeLevel `json:"weaponSystems"`
-	CargoExpansion UpgradeLevel `json:"cargoExpansion"`
+	Engine          UpgradeLevel `json:"engine"`
+	WeaponSystems   UpgradeLevel `json:"weaponSystems"`
+	CargoExpansion  UpgradeLevel `json:"cargoExpansion"`
+	HullPlating     UpgradeLevel `json:"hullPlating"`
+	ShieldGenerator UpgradeLevel `json:"shieldGenerator"`
+	SpecimenVault   UpgradeLevel `json:"specimenVault"`
 }
 
 type UpgradeLevel struct {
@@ -178,11 +194,15 @@ type Location struct {
 
 type GameMap struct {
 	StarSystems []StarSystem `json:"starSystems"`
+	FogOfWar    bool         `json:"fogOfWar,omitempty"` // only scanned systems and planets show up on the map
 }
 
 type StarSystem struct {
-	Name    string   `json:"name"`
-	Planets []Planet `json:"planets"`
+	Name        string      `json:"name"`
+	Faction     string      `json:"faction,omitempty"` // id of the faction in control of the system
+	Coordinates Coordinates `json:"coordinates"`       // position in the galaxy, in light years
+	Scanned     bool        `json:"scanned,omitempty"`
+	Planets     []Planet    `json:"planets"`
 }
 
 type Planet struct {
@@ -191,6 +211,10 @@ type Planet struct {
 	Resources    []Resource        `json:"resources"`
 	Coordinates  Coordinates       `json:"coordinates"`
 	Requirements []CrewRequirement `json:"requirements"`
+	Faction      string            `json:"faction,omitempty"`   // overrides the star system's faction
+	Visited      bool              `json:"visited,omitempty"`   // set the first time the ship arrives
+	Scanned      bool              `json:"scanned,omitempty"`   // charted on the map
+	ExpiresAt    int               `json:"expiresAt,omitempty"` // game clock hour an anomaly fades at
 }
 
 type Resource struct {
@@ -220,6 +244,29 @@ type Mission struct {
 	Received     string        `json:"Received"`
 	Category     string        `json:"Category"`
 	Dialogue     []string      `json:"dialogue"`
+	Conversation *DialogueTree `json:"Conversation,omitempty"` // briefing with speakers and choices, replaces Dialogue when set
+	Enemy        string        `json:"Enemy,omitempty"`        // id of an enemy ship that must be defeated on arrival
+	Faction      string        `json:"Faction,omitempty"`      // faction that offered the mission
+	QuestID      string        `json:"QuestId,omitempty"`      // quest graph node the mission was created from
+	Branch       string        `json:"Branch,omitempty"`       // id of the quest branch chosen in the dialogue
+	Briefed      bool          `json:"Briefed,omitempty"`      // the briefing has been played through and isn't shown again
+	Objectives   []Objective   `json:"Objectives,omitempty"`
+	TimeLimit    int           `json:"TimeLimit,omitempty"`    // hours on the game clock to finish the mission once accepted
+	Deadline     int           `json:"Deadline,omitempty"`     // game clock hour at which the mission expires
+	Reason       string        `json:"Reason,omitempty"`       // why the mission failed or was abandoned
+	AcceptedAt   int           `json:"AcceptedAt,omitempty"`   // game clock hour the mission entered the journal
+	EndedAt      int           `json:"EndedAt,omitempty"`      // game clock hour the mission was completed, failed or abandoned
+	Danger       int           `json:"Danger,omitempty"`       // how risky a generated mission is, from 1 to MaxDanger
+	OfferExpires int           `json:"OfferExpires,omitempty"` // game clock hour the posting comes off the station's board
+}
+
+// SameAs reports whether two missions are the same journal entry
+// quest missions are matched by quest id, everything else by id and title
+func (m Mission) SameAs(other Mission) bool {
+	if m.QuestID != "" || other.QuestID != "" {
+		return m.QuestID == other.QuestID
+	}
+	return m.Id == other.Id && m.Title == other.Title
 }
 
 type MissionStatus int
@@ -317,7 +364,7 @@ func generateRandomID(prefix string) string {
 
 func DefaultCollection() Collection {
 	return Collection{
-		MaxCapacity:  100,
+		MaxCapacity:  BaseCollectionCapacity,
 		UsedCapacity: 0,
 		Items: []CollectionItem{
 			{
@@ -390,25 +437,6 @@ func CreateNewFullGameSave(difficulty, shipName, playerName string) error {
 	now := time.Now()
 
 	defaultMissions := []Mission{
-		{
-			Step:         0,
-			Id:           0,
-			Title:        "Rescue Mission",
-			Description:  "Rescue the stranded astronaut on a rogue asteroid",
-			Status:       MissionStatusNotStarted,
-			Location:     Location{StarSystemName: "Sol", PlanetName: "Asteroid X", Coordinates: Coordinates{X: 0, Y: 0, Z: 1}},
-			Income:       1000,
-			Requirements: "Pilot",
-			Received:     "Commander Vega (ISS)",
-			Category:     "Main",
-			Dialogue: []string{
-				"Commander, we've received a distress signal from the outer asteroid belt. Faint, but repeating.",
-				"There's a lone astronaut stranded out there on what looks like a rogue asteroid, designation AX-7.",
-				"Reports suggest the asteroid's trajectory is... unnatural. It doesn't match any known orbital mechanics.",
-				"The astronaut might have seen something out there... something important.",
-				"Get them home safely. Bring them back to the ISS [0,0,0] for debriefing. This could be bigger than just a rescue.",
-			},
-		},
 		{
 			Id:           11,
 			Title:        "Solar Flare Response",
@@ -427,10 +455,16 @@ func CreateNewFullGameSave(difficulty, shipName, playerName string) error {
 		},
 	}
 
+	// the Main story starts from the opening quest of the quest graph
+	if opening := FindQuest(OpeningQuestID); opening != nil {
+		defaultMissions = append([]Mission{opening.Mission()}, defaultMissions...)
+	}
+
 	defaultGameMap := GameMap{
 		StarSystems: []StarSystem{
 			{
-				Name: "Sol",
+				Name:        "Sol",
+				Coordinates: Coordinates{X: 0, Y: 0, Z: 0},
 				Planets: []Planet{
 					{
 						Name:        "ISS",
@@ -476,7 +510,8 @@ func CreateNewFullGameSave(difficulty, shipName, playerName string) error {
 				},
 			},
 			{
-				Name: "Alpha Centauri",
+				Name:        "Alpha Centauri",
+				Coordinates: Coordinates{X: 3, Y: -2, Z: -2},
 				Planets: []Planet{
 					{
 						Name:        "Proxima b",
@@ -499,7 +534,8 @@ func CreateNewFullGameSave(difficulty, shipName, playerName string) error {
 				},
 			},
 			{
-				Name: "Sirius",
+				Name:        "Sirius",
+				Coordinates: Coordinates{X: -5, Y: 6, Z: -2},
 				Planets: []Planet{
 					{
 						Name:        "Sirius I",
@@ -528,7 +564,8 @@ func CreateNewFullGameSave(difficulty, shipName, playerName string) error {
 				},
 			},
 			{
-				Name: "Vega",
+				Name:        "Vega",
+				Coordinates: Coordinates{X: 15, Y: -12, Z: 14},
 				Planets: []Planet{
 					{
 						Name:        "Vega I",
@@ -552,6 +589,9 @@ func CreateNewFullGameSave(difficulty, shipName, playerName string) error {
 		},
 	}
 
+	// star systems added or reworked by mods
+	mergeModGalaxy(&defaultGameMap, true)
+
 	fullSave := FullGameSave{
 		GameTitle: "Project Starbyte",
 		GameMetadata: GameMetadata{
@@ -634,6 +674,24 @@ func CreateNewFullGameSave(difficulty, shipName, playerName string) error {
 					Level:    1,
 					Status:   "operational",
 				},
+				{
+					ModuleId: generateRandomID("MOD_SHD_"),
+					Name:     ShieldModuleName,
+					Level:    1,
+					Status:   "operational",
+				},
+				{
+					ModuleId: generateRandomID("MOD_SCN_"),
+					Name:     ScannerModuleName,
+					Level:    1,
+					Status:   "operational",
+				},
+				{
+					ModuleId: generateRandomID("MOD_FAB_"),
+					Name:     FabricatorModuleName,
+					Level:    1,
+					Status:   "operational",
+				},
 			},
 			Upgrades: Upgrades{
 				Engine: UpgradeLevel{
@@ -648,6 +706,18 @@ func CreateNewFullGameSave(difficulty, shipName, playerName string) error {
 					CurrentLevel: 0,
 					MaxLevel:     10,
 				},
+				HullPlating: UpgradeLevel{
+					CurrentLevel: 0,
+					MaxLevel:     10,
+				},
+				ShieldGenerator: UpgradeLevel{
+					CurrentLevel: 0,
+					MaxLevel:     10,
+				},
+				SpecimenVault: UpgradeLevel{
+					CurrentLevel: 0,
+					MaxLevel:     10,
+				},
 			},
 		},
 		Crew: []CrewMember{
@@ -683,6 +753,9 @@ func CreateNewFullGameSave(difficulty, shipName, playerName string) error {
 		Collection: DefaultCollection(),
 	}
 
+	// new games start out in the dark beyond the home system
+	ChartStartingArea(&fullSave)
+
 	saveData := []FullGameSave{fullSave}
 
 	dataBytes, err := json.MarshalIndent(saveData, "", "  ")
@@ -719,9 +792,25 @@ func LoadFullGameSave() (*FullGameSave, error) {
 	if len(saves) == 0 {
 		return nil, nil
 	}
+	saves[0].applyDefaults()
 	return &saves[0], nil
 }
 
+// applyDefaults fills in fields that older save files don't have yet
+func (s *FullGameSave) applyDefaults() {
+	ensureUpgradeDefaults(&s.Ship.Upgrades)
+	ensureShieldModule(&s.Ship)
+	ensureFabricatorModule(&s.Ship)
+	mergeModGalaxy(&s.GameMap, false)
+	ensureSpeciesHomeworlds(&s.GameMap)
+	ensureStations(&s.GameMap)
+	assignFactionTerritory(&s.GameMap)
+	linkStoryMissions(s)
+	DiscoverLocation(&s.GameMap, s.Ship.Location)
+	ApplyUpgradeEffects(&s.Ship)
+	ApplyCollectionCapacity(s)
+}
+
 func SaveGame(save *FullGameSave) error {
 	saveData := []FullGameSave{*save}
 
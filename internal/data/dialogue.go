@@ -0,0 +1,147 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// DefaultPortrait is shown beside speakers that don't have a glyph of their own
+const DefaultPortrait = "◆"
+
+// DialogueTree is a conversation made of nodes, each node is a line from a speaker
+// that either leads on to the next node or offers the player a choice
+type DialogueTree struct {
+	Start string         `json:"start,omitempty"` // id of the first node, defaults to the first in the list
+	Nodes []DialogueNode `json:"nodes"`
+}
+
+// DialogueNode is a single line of a conversation
+type DialogueNode struct {
+	ID       string           `json:"id"`
+	Speaker  string           `json:"speaker,omitempty"`
+	Portrait string           `json:"portrait,omitempty"` // glyph shown beside the speaker's name
+	Text     string           `json:"text"`
+	Next     string           `json:"next,omitempty"` // node that follows when there are no choices, empty ends the conversation
+	Choices  []DialogueChoice `json:"choices,omitempty"`
+}
+
+// DialogueChoice is a reply the player can pick
+type DialogueChoice struct {
+	Text       string           `json:"text"`
+	Next       string           `json:"next,omitempty"`       // node the choice leads to, empty ends the conversation
+	Conditions []QuestCondition `json:"conditions,omitempty"` // the choice is only offered when all of these hold
+	Effects    []QuestEffect    `json:"effects,omitempty"`    // applied when the choice is picked
+	Branch     string           `json:"branch,omitempty"`     // quest branch the choice settles on
+}
+
+// LinearDialogue turns plain lines into a conversation that runs from the first line to the last
+func LinearDialogue(speaker, portrait string, lines []string) DialogueTree {
+	var tree DialogueTree
+	for i, line := range lines {
+		node := DialogueNode{ID: strconv.Itoa(i), Speaker: speaker, Portrait: portrait, Text: line}
+		if i < len(lines)-1 {
+			node.Next = strconv.Itoa(i + 1)
+		}
+		tree.Nodes = append(tree.Nodes, node)
+	}
+	return tree
+}
+
+// MissionDialogue returns the briefing for a mission
+// missions without a conversation of their own get their dialogue lines spoken by whoever gave the mission,
+// ending on a choice between the quest's branches if it has any and no talks with a species settle them instead
+func MissionDialogue(m Mission) DialogueTree {
+	if m.Conversation != nil {
+		return *m.Conversation
+	}
+	tree := LinearDialogue(m.Received, DefaultPortrait, m.Dialogue)
+	if quest := FindQuest(m.QuestID); quest != nil && len(tree.Nodes) > 0 && NegotiatesWith(m) == "" {
+		last := &tree.Nodes[len(tree.Nodes)-1]
+		for _, branch := range quest.Branches {
+			last.Choices = append(last.Choices, DialogueChoice{Text: branch.Label, Branch: branch.ID})
+		}
+	}
+	return tree
+}
+
+// Find returns the node with the given id, or nil if the tree has none by that id
+func (t DialogueTree) Find(id string) *DialogueNode {
+	for i := range t.Nodes {
+		if t.Nodes[i].ID == id {
+			return &t.Nodes[i]
+		}
+	}
+	return nil
+}
+
+// First returns the node the conversation starts on, or nil if the tree is empty
+func (t DialogueTree) First() *DialogueNode {
+	if t.Start != "" {
+		return t.Find(t.Start)
+	}
+	if len(t.Nodes) == 0 {
+		return nil
+	}
+	return &t.Nodes[0]
+}
+
+// AvailableChoices returns the node's choices whose conditions hold
+func (n DialogueNode) AvailableChoices(save *FullGameSave) []DialogueChoice {
+	var choices []DialogueChoice
+	for _, choice := range n.Choices {
+		if save == nil || choice.Available(save) {
+			choices = append(choices, choice)
+		}
+	}
+	return choices
+}
+
+// Available reports whether every condition on the choice holds
+func (c DialogueChoice) Available(save *FullGameSave) bool {
+	for _, condition := range c.Conditions {
+		if !condition.Met(save) {
+			return false
+		}
+	}
+	return true
+}
+
+// ApplyDialogueChoice applies the effects of a picked choice to the save
+func ApplyDialogueChoice(save *FullGameSave, choice DialogueChoice) {
+	for _, effect := range choice.Effects {
+		effect.Apply(save)
+	}
+}
+
+// Validate reports nodes that point at nodes that don't exist and choices with broken conditions
+func (t DialogueTree) Validate() error {
+	var errs []error
+	ids := make(map[string]bool)
+	for _, node := range t.Nodes {
+		if node.ID == "" || ids[node.ID] {
+			errs = append(errs, fmt.Errorf("dialogue node %q: missing or duplicate id", node.ID))
+		}
+		ids[node.ID] = true
+	}
+	if t.Start != "" && !ids[t.Start] {
+		errs = append(errs, fmt.Errorf("dialogue starts at %q, which doesn't exist", t.Start))
+	}
+
+	for _, node := range t.Nodes {
+		if node.Next != "" && !ids[node.Next] {
+			errs = append(errs, fmt.Errorf("dialogue node %q: next node %q doesn't exist", node.ID, node.Next))
+		}
+		for _, choice := range node.Choices {
+			if choice.Next != "" && !ids[choice.Next] {
+				errs = append(errs, fmt.Errorf("dialogue node %q: choice %q leads to %q, which doesn't exist", node.ID, choice.Text, choice.Next))
+			}
+			for _, condition := range choice.Conditions {
+				if err := condition.Validate(); err != nil {
+					errs = append(errs, fmt.Errorf("dialogue node %q: choice %q: %w", node.ID, choice.Text, err))
+				}
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,266 @@
+package data
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// negotiation tuning
+const (
+	NegotiationRounds      = 3
+	NegotiationHours       = 6   // hours talks with a species take on the game clock
+	GiftCost               = 150 // credits a gift costs
+	baseNegotiationChance  = 40  // % chance an approach succeeds before the modifiers
+	commsChancePerDegree   = 8   // % added for every degree of the best Communications Officer aboard
+	standingChanceDivisor  = 4   // a quarter of the standing with the species' faction is added to every chance
+	shareChancePerTier     = 5   // % added for every tier of the research note shared
+	successRapport         = 2   // rapport gained when an approach lands
+	accordRapport          = 3   // rapport needed for an accord, anything from zero up is a truce
+	accordStanding         = 20
+	truceStanding          = 5
+	hostileStanding        = -20
+	minNegotiationChance   = 5
+	maxNegotiationChance   = 95
+	breakOffRapportPenalty = 1 // walking out of talks is taken as a slight
+)
+
+// NegotiationApproach is how the crew tries to win the species over in a round of talks
+type NegotiationApproach int
+
+const (
+	ApproachGreet NegotiationApproach = iota
+	ApproachGift
+	ApproachShare
+	ApproachStandFirm
+	ApproachBreakOff
+)
+
+// AllApproaches lists the approaches in the order they are shown to the player
+var AllApproaches = []NegotiationApproach{
+	ApproachGreet,
+	ApproachGift,
+	ApproachShare,
+	ApproachStandFirm,
+	ApproachBreakOff,
+}
+
+func (a NegotiationApproach) String() string {
+	return [...]string{"Greet them in their own tongue", "Offer a gift", "Share research", "Stand firm", "Break off talks"}[a]
+}
+
+// temperamentModifiers is the % each temperament adds to the chance of an approach, indexed by approach
+var temperamentModifiers = map[Temperament][]int{
+	TemperamentProud:   {15, -5, 5, -20, 0},
+	TemperamentMartial: {0, -15, -5, 20, 0},
+	TemperamentCurious: {5, 5, 25, -10, 0},
+}
+
+// NegotiationOutcome is how talks with a species ended, it doubles as the quest branch the talks settle on
+type NegotiationOutcome string
+
+const (
+	NegotiationOngoing NegotiationOutcome = ""
+	NegotiationAccord  NegotiationOutcome = "accord"  // the species opens its market
+	NegotiationTruce   NegotiationOutcome = "truce"   // nobody is happy, but nobody is shooting
+	NegotiationHostile NegotiationOutcome = "hostile" // the talks broke down
+)
+
+func (o NegotiationOutcome) String() string {
+	switch o {
+	case NegotiationAccord:
+		return "Accord"
+	case NegotiationTruce:
+		return "Truce"
+	case NegotiationHostile:
+		return "Hostile"
+	}
+	return "Ongoing"
+}
+
+// Negotiation is the state of a single round of talks with a species
+type Negotiation struct {
+	Species      Species
+	FirstContact bool // the crew had never met the species before these talks
+	Round        int
+	Rapport      int
+	Outcome      NegotiationOutcome
+	Memories     []string // what the species remembers of the player's past actions
+	Log          []string
+	history      int // % the species' memories and the player's standing add to every approach
+}
+
+// NewNegotiation opens talks with a species, weighing up what it remembers of the player
+func NewNegotiation(save *FullGameSave, species Species) *Negotiation {
+	n := &Negotiation{
+		Species:      species,
+		FirstContact: FindContact(save, species.ID) == nil,
+		Round:        1,
+		history:      Standing(save.Player.Reputation, species.Faction) / standingChanceDivisor,
+	}
+	for _, memory := range species.Memories {
+		met := true
+		for _, condition := range memory.Conditions {
+			met = met && condition.Met(save)
+		}
+		if met {
+			n.history += memory.Modifier
+			n.Memories = append(n.Memories, memory.Text)
+		}
+	}
+	if n.FirstContact {
+		n.Log = append(n.Log, fmt.Sprintf("%s of the %s answers your hail.", species.Envoy, species.Name))
+	} else {
+		n.Log = append(n.Log, fmt.Sprintf("%s agrees to hear you out.", species.Envoy))
+	}
+	return n
+}
+
+// Chance returns the % chance an approach wins the species over
+// it rests on the best Communications Officer's degree, xenolinguistics research,
+// the species' temperament and what it remembers of the player
+func (n *Negotiation) Chance(save *FullGameSave, approach NegotiationApproach) int {
+	chance := baseNegotiationChance +
+		commsChancePerDegree*bestDegree(save.Crew, CrewRoleCommunicationsOfficer) +
+		save.Ship.Research.Bonus().HailChance +
+		temperamentModifiers[n.Species.Temperament][approach] +
+		n.history
+	if approach == ApproachShare {
+		if tier := lowestNoteHeld(save.Collection); tier > 0 {
+			chance += shareChancePerTier * tier
+		}
+	}
+	return min(max(chance, minNegotiationChance), maxNegotiationChance)
+}
+
+// CanUse reports why an approach can't be taken right now, nil when it can
+func (n *Negotiation) CanUse(save *FullGameSave, approach NegotiationApproach) error {
+	switch approach {
+	case ApproachGift:
+		if save.Player.Credits < GiftCost {
+			return fmt.Errorf("a gift costs %d credits", GiftCost)
+		}
+	case ApproachShare:
+		if lowestNoteHeld(save.Collection) == 0 {
+			return fmt.Errorf("there are no research notes to share")
+		}
+	}
+	return nil
+}
+
+// Negotiate plays out a round of talks, paying for the approach and rolling for the species' response
+// talks end after NegotiationRounds rounds or once the crew breaks them off
+func Negotiate(save *FullGameSave, n *Negotiation, approach NegotiationApproach) error {
+	if n.Outcome != NegotiationOngoing {
+		return fmt.Errorf("the talks are over")
+	}
+	if err := n.CanUse(save, approach); err != nil {
+		return err
+	}
+
+	// the chance is worked out before the approach is paid for, a shared note counts for the talks it is shared in
+	chance := n.Chance(save, approach)
+	name := n.Species.Name
+	switch approach {
+	case ApproachBreakOff:
+		n.Rapport -= breakOffRapportPenalty
+		n.addLog("You break off the talks. The %s take it poorly.", name)
+		n.conclude()
+		return nil
+	case ApproachGift:
+		save.Player.Credits -= GiftCost
+	case ApproachShare:
+		tier := lowestNoteHeld(save.Collection)
+		for i := range save.Collection.ResearchNotes {
+			if save.Collection.ResearchNotes[i].Tier == tier {
+				save.Collection.ResearchNotes[i].Quantity--
+			}
+		}
+		save.Collection.UsedCapacity = CollectionUsed(save.Collection)
+	}
+
+	if rand.Intn(100) < chance {
+		n.Rapport += successRapport
+		n.addLog("%s. The %s warm to you.", approach, name)
+	} else {
+		n.Rapport--
+		n.addLog("%s. The %s are unmoved.", approach, name)
+	}
+
+	n.Round++
+	if n.Round > NegotiationRounds {
+		n.conclude()
+	}
+	return nil
+}
+
+// conclude settles the outcome of the talks from the rapport built up
+func (n *Negotiation) conclude() {
+	switch {
+	case n.Rapport >= accordRapport:
+		n.Outcome = NegotiationAccord
+	case n.Rapport >= 0:
+		n.Outcome = NegotiationTruce
+	default:
+		n.Outcome = NegotiationHostile
+	}
+	n.addLog("The talks end: %s.", n.Outcome)
+}
+
+func (n *Negotiation) addLog(format string, args ...any) {
+	n.Log = append(n.Log, fmt.Sprintf("[R%d] ", min(n.Round, NegotiationRounds))+fmt.Sprintf(format, args...))
+}
+
+// StandingChange returns the standing the outcome of the talks is worth with the species' faction
+func (n *Negotiation) StandingChange() int {
+	switch n.Outcome {
+	case NegotiationAccord:
+		return accordStanding
+	case NegotiationTruce:
+		return truceStanding
+	case NegotiationHostile:
+		return hostileStanding
+	}
+	return 0
+}
+
+// ApplyNegotiation records concluded talks, changing standing with the species' faction
+// an accord opens the species' market, a breakdown closes it again
+func ApplyNegotiation(save *FullGameSave, n *Negotiation) {
+	if n.Outcome == NegotiationOngoing {
+		return
+	}
+	MakeContact(save, n.Species)
+	contact := FindContact(save, n.Species.ID)
+	contact.Talks++
+	contact.Outcome = n.Outcome
+	switch n.Outcome {
+	case NegotiationAccord:
+		contact.MarketOpen = true
+	case NegotiationHostile:
+		contact.MarketOpen = false
+	}
+	ChangeReputation(&save.Player.Reputation, n.Species.Faction, n.StandingChange())
+	WriteLog(save, LogDiplomacy, "Talks with the %s ended: %s.", n.Species.Name, n.Outcome)
+}
+
+// lowestNoteHeld returns the lowest tier of research note the collection holds, 0 if it holds none
+func lowestNoteHeld(collection Collection) int {
+	lowest := 0
+	for _, note := range collection.ResearchNotes {
+		if note.Quantity > 0 && (lowest == 0 || note.Tier < lowest) {
+			lowest = note.Tier
+		}
+	}
+	return lowest
+}
+
+// CanOpenTalks reports why the crew can't open talks with a species right now, nil when they can
+func CanOpenTalks(save *FullGameSave, species Species) error {
+	if save.Ship.Location.StarSystemName != species.HomeSystem.Name {
+		return fmt.Errorf("the %s only receive envoys in the %s system", species.Name, species.HomeSystem.Name)
+	}
+	if contact := FindContact(save, species.ID); contact != nil && contact.Outcome == NegotiationAccord {
+		return fmt.Errorf("the %s are already at accord with you", species.Name)
+	}
+	return nil
+}
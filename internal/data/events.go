@@ -3,7 +3,12 @@ package data
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"math/rand"
+	"slices"
+	"strings"
 
 	_ "embed"
 )
@@ -11,42 +16,391 @@ import (
 //go:embed events.json
 var embeddedEvents []byte
 
+// ItemEffectPrefix marks event effects that add or remove cargo, e.g. "item:Scrap Metal"
+const ItemEffectPrefix = "item:"
+
+// eventEffectKeys are the plain effect keys an event outcome may use
+var eventEffectKeys = []string{"fuel", "credits", "morale", "food", "shields", "hull"}
+
 type Event struct {
-	ID          int      `json:"id"`
-	Title       string   `json:"title"`
-	Description string   `json:"description"`
-	Dialogue    []string `json:"dialogue"`
-	Choices     []Choice `json:"choices"`
+	ID          int              `json:"id"`
+	Title       string           `json:"title"`
+	Description string           `json:"description"`
+	Faction     string           `json:"faction,omitempty"`     // faction the event belongs to, if any
+	MinStanding *int             `json:"minStanding,omitempty"` // only happens at or above this standing with Faction
+	MaxStanding *int             `json:"maxStanding,omitempty"` // only happens at or below this standing with Faction
+	Conditions  []QuestCondition `json:"conditions,omitempty"`  // all must hold for the event to happen
+	Weight      int              `json:"weight,omitempty"`      // relative chance of being picked, defaults to 1
+	Cooldown    int              `json:"cooldown,omitempty"`    // hours on the game clock before the event can happen again
+	FollowUp    bool             `json:"followUp,omitempty"`    // only happens when chained from another event
+	Speaker     string           `json:"speaker,omitempty"`     // who speaks the dialogue, defaults to the bridge officer
+	Portrait    string           `json:"portrait,omitempty"`    // glyph shown beside the speaker
+	Dialogue    []string         `json:"dialogue"`
+	Choices     []Choice         `json:"choices"`
+}
+
+// DefaultEventSpeaker speaks the dialogue of events that don't name a speaker of their own
+const DefaultEventSpeaker = "Bridge Officer"
+
+// Conversation returns the event's dialogue as lines spoken by its speaker
+func (e Event) Conversation() DialogueTree {
+	speaker := e.Speaker
+	if speaker == "" {
+		speaker = DefaultEventSpeaker
+	}
+	return LinearDialogue(speaker, e.Portrait, e.Dialogue)
 }
 
+// Choice is an option offered by an event
+// a choice without Outcomes always ends with its own Outcome, Effects, Combat and Next
 type Choice struct {
+	Text       string         `json:"text"`
+	Effects    map[string]int `json:"effects"`
+	Outcome    string         `json:"outcome"`
+	Combat     string         `json:"combat,omitempty"` // id of an enemy ship to fight after the event
+	Next       int            `json:"next,omitempty"`   // id of a follow-up event that happens straight after
+	Outcomes   []Outcome      `json:"outcomes,omitempty"`
+	SkillCheck *SkillCheck    `json:"skillCheck,omitempty"`
+	Script     string         `json:"script,omitempty"` // script run after the outcome is picked, e.g. "artifact.star:reveal_origin"
+}
+
+// Outcome is one possible result of a choice, picked at random by weight
+type Outcome struct {
+	Weight  int            `json:"weight,omitempty"` // defaults to 1
 	Text    string         `json:"text"`
 	Effects map[string]int `json:"effects"`
-	Outcome string         `json:"outcome"`
+	Combat  string         `json:"combat,omitempty"`
+	Next    int            `json:"next,omitempty"`
+	Quests  []string       `json:"-"` // quests a script offered
+	Error   string         `json:"-"` // why the choice's script failed
 }
 
+// SkillCheck rolls against the best crew member of a role, the choice falls through to Failure when it misses
+type SkillCheck struct {
+	Role       CrewRole `json:"role"`
+	Difficulty int      `json:"difficulty"` // the degree a crew member needs for even odds
+	Failure    Outcome  `json:"failure"`
+}
+
+// skill check odds
+const (
+	skillCheckBase    = 50 // chance in percent when the crew member's degree matches the difficulty
+	skillCheckPerStep = 15 // chance gained or lost for each degree above or below it
+	skillCheckMin     = 5
+	skillCheckMax     = 95
+)
+
 var Events []Event
 
 // Load events from JSON
 func LoadEvents() error {
-	var data []Event
-
-	// Decode directly from embedded JSON
-	err := json.NewDecoder(bytes.NewReader(embeddedEvents)).Decode(&data)
+	events, err := ParseEvents(bytes.NewReader(embeddedEvents))
 	if err != nil {
 		return err
 	}
 
-	Events = data
+	Events = mergeModEvents(events)
 	return nil
 }
 
-// Pick random event
-func GetRandomEvent() *Event {
-	if len(Events) == 0 {
+// ParseEvents decodes an events file and validates it, unknown fields and effect keys are rejected
+func ParseEvents(r io.Reader) ([]Event, error) {
+	var events []Event
+
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&events); err != nil {
+		return nil, err
+	}
+	if err := ValidateEvents(events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// ValidateEvents checks events for authoring mistakes and returns every problem found
+func ValidateEvents(events []Event) error {
+	var errs []error
+	ids := make(map[int]bool)
+	for _, event := range events {
+		if ids[event.ID] {
+			errs = append(errs, fmt.Errorf("event %d: duplicate id", event.ID))
+		}
+		ids[event.ID] = true
+	}
+
+	for _, event := range events {
+		errs = append(errs, validateEvent(event, ids)...)
+	}
+	return errors.Join(errs...)
+}
+
+// validateEvent checks a single event, ids holds every event a follow-up may point at
+func validateEvent(event Event, ids map[int]bool) []error {
+	var errs []error
+	fail := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf("event %d (%s): %s", event.ID, event.Title, fmt.Sprintf(format, args...)))
+	}
+
+	if event.Weight < 0 || event.Cooldown < 0 {
+		fail("weight and cooldown can't be negative")
+	}
+	if len(event.Choices) == 0 {
+		fail("has no choices")
+	}
+	if err := validatePlaceholders(eventTexts(event), eventPlaceholders); err != nil {
+		fail("%v", err)
+	}
+	for _, condition := range event.Conditions {
+		if err := condition.Validate(); err != nil {
+			fail("%v", err)
+		}
+	}
+
+	for i, choice := range event.Choices {
+		if choice.Script != "" {
+			if _, _, err := splitScriptRef(choice.Script); err != nil {
+				fail("choice %d: %v", i+1, err)
+			}
+		}
+		outcomes := ChoiceOutcomes(choice)
+		if choice.SkillCheck != nil {
+			if choice.SkillCheck.Role == "" {
+				fail("choice %d: skill check needs a role", i+1)
+			}
+			outcomes = append(outcomes, choice.SkillCheck.Failure)
+		}
+		for _, outcome := range outcomes {
+			if outcome.Weight < 0 {
+				fail("choice %d: outcome weight can't be negative", i+1)
+			}
+			for key := range outcome.Effects {
+				if err := ValidateEffectKey(key); err != nil {
+					fail("choice %d: %v", i+1, err)
+				}
+			}
+			if outcome.Next != 0 && !ids[outcome.Next] {
+				fail("choice %d: follow-up event %d doesn't exist", i+1, outcome.Next)
+			}
+			if outcome.Next != 0 && outcome.Combat != "" {
+				fail("choice %d: an outcome can lead to combat or a follow-up event, not both", i+1)
+			}
+		}
+	}
+	return errs
+}
+
+// CheckEventReferences checks that the factions, enemy ships and scripts events mention exist
+// it needs the faction and enemy ship registries loaded, so it's left to authoring tools rather than LoadEvents
+func CheckEventReferences(events []Event) error {
+	var errs []error
+	for _, event := range events {
+		fail := func(format string, args ...any) {
+			errs = append(errs, fmt.Errorf("event %d (%s): %s", event.ID, event.Title, fmt.Sprintf(format, args...)))
+		}
+
+		factions := []string{event.Faction}
+		for _, condition := range event.Conditions {
+			factions = append(factions, condition.Faction)
+		}
+		for i, choice := range event.Choices {
+			if choice.Script != "" {
+				if err := CheckScript(choice.Script); err != nil {
+					fail("choice %d: %v", i+1, err)
+				}
+			}
+			outcomes := ChoiceOutcomes(choice)
+			if choice.SkillCheck != nil {
+				outcomes = append(outcomes, choice.SkillCheck.Failure)
+			}
+			for _, outcome := range outcomes {
+				if outcome.Combat != "" && FindEnemyShip(outcome.Combat) == nil {
+					fail("choice %d: unknown enemy ship %q", i+1, outcome.Combat)
+				}
+				for key := range outcome.Effects {
+					if faction, ok := strings.CutPrefix(key, ReputationEffectPrefix); ok {
+						factions = append(factions, faction)
+					}
+				}
+			}
+		}
+		slices.Sort(factions)
+		for _, faction := range slices.Compact(factions) {
+			if faction != "" && FindFaction(faction) == nil {
+				fail("unknown faction %q", faction)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ValidateEffectKey reports an effect key events don't know how to apply
+func ValidateEffectKey(key string) error {
+	if faction, ok := strings.CutPrefix(key, ReputationEffectPrefix); ok {
+		if faction == "" {
+			return fmt.Errorf("effect %q needs a faction", key)
+		}
+		return nil
+	}
+	if item, ok := strings.CutPrefix(key, ItemEffectPrefix); ok {
+		if item == "" {
+			return fmt.Errorf("effect %q needs an item name", key)
+		}
 		return nil
 	}
+	for _, known := range eventEffectKeys {
+		if key == known {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown effect %q", key)
+}
 
-	event := Events[rand.Intn(len(Events))]
-	return &event
+// FindEvent returns the event with the given id, or nil if none exists
+func FindEvent(id int) *Event {
+	for i := range Events {
+		if Events[i].ID == id {
+			return &Events[i]
+		}
+	}
+	return nil
+}
+
+// EventAvailable reports whether the event's preconditions hold and it isn't cooling down
+func EventAvailable(save *FullGameSave, event Event) bool {
+	if !MeetsStanding(save.Player.Reputation, event.Faction, event.MinStanding, event.MaxStanding) {
+		return false
+	}
+	if last, ok := save.EventLog[event.ID]; ok && event.Cooldown > 0 &&
+		save.GameMetadata.ClockHours-last < event.Cooldown {
+		return false
+	}
+	for _, condition := range event.Conditions {
+		if !condition.Met(save) {
+			return false
+		}
+	}
+	return true
+}
+
+// Pick a random event whose preconditions hold, weighted by each event's weight
+// follow-up events only happen when chained and are never picked here
+func GetRandomEvent(save *FullGameSave) *Event {
+	var eligible []Event
+	total := 0
+	for _, event := range Events {
+		if !event.FollowUp && EventAvailable(save, event) {
+			eligible = append(eligible, event)
+			total += weight(event.Weight)
+		}
+	}
+	if len(eligible) == 0 {
+		return nil
+	}
+
+	roll := rand.Intn(total)
+	for _, event := range eligible {
+		roll -= weight(event.Weight)
+		if roll < 0 {
+			return &event
+		}
+	}
+	return nil
+}
+
+// RecordEvent notes when an event happened so its cooldown can run
+func RecordEvent(save *FullGameSave, id int) {
+	if save.EventLog == nil {
+		save.EventLog = make(map[int]int)
+	}
+	save.EventLog[id] = save.GameMetadata.ClockHours
+}
+
+// SkillCheckChance returns the chance in percent that the crew pass a skill check
+// it is zero when nobody able of the role is aboard
+func SkillCheckChance(crew []CrewMember, check SkillCheck) int {
+	best := -1
+	for _, member := range crew {
+		if member.Role == check.Role && member.Health > 0 {
+			best = max(best, member.Degree)
+		}
+	}
+	if best < 0 {
+		return 0
+	}
+	chance := skillCheckBase + skillCheckPerStep*(best-check.Difficulty)
+	return min(max(chance, skillCheckMin), skillCheckMax)
+}
+
+// ResolveChoice rolls the choice's skill check and outcomes and returns what happens
+// a choice with a script runs it afterwards, a script that fails leaves the outcome as it was and records the error
+func ResolveChoice(save *FullGameSave, choice Choice) Outcome {
+	outcome := rollOutcome(save, choice)
+	if choice.Script == "" {
+		return outcome
+	}
+
+	result, err := RunEventScript(choice.Script, save)
+	if err == nil && result.Next != 0 && outcome.Combat != "" {
+		err = fmt.Errorf("%s: chain() can't lead on from an outcome that ends in combat", choice.Script)
+	}
+	if err != nil {
+		outcome.Error = err.Error()
+		return outcome
+	}
+
+	// the outcome is a copy, but its effects map is still shared with the event
+	effects := make(map[string]int, len(outcome.Effects)+len(result.Effects))
+	for key, value := range outcome.Effects {
+		effects[key] = value
+	}
+	for key, value := range result.Effects {
+		effects[key] += value
+	}
+	outcome.Effects = effects
+	for _, line := range result.Dialogue {
+		outcome.Text = strings.TrimSpace(outcome.Text + "\n" + line)
+	}
+	if result.Next != 0 {
+		outcome.Next = result.Next
+	}
+	outcome.Quests = result.Quests
+	return outcome
+}
+
+// rollOutcome rolls the choice's skill check and picks one of its outcomes by weight
+func rollOutcome(save *FullGameSave, choice Choice) Outcome {
+	if choice.SkillCheck != nil && rand.Intn(100) >= SkillCheckChance(save.Crew, *choice.SkillCheck) {
+		return choice.SkillCheck.Failure
+	}
+
+	outcomes := ChoiceOutcomes(choice)
+	total := 0
+	for _, outcome := range outcomes {
+		total += weight(outcome.Weight)
+	}
+	roll := rand.Intn(total)
+	for _, outcome := range outcomes {
+		roll -= weight(outcome.Weight)
+		if roll < 0 {
+			return outcome
+		}
+	}
+	return outcomes[0]
+}
+
+// ChoiceOutcomes returns the outcomes a choice can end with when its skill check passes
+func ChoiceOutcomes(choice Choice) []Outcome {
+	if len(choice.Outcomes) > 0 {
+		return append([]Outcome(nil), choice.Outcomes...)
+	}
+	return []Outcome{{Text: choice.Outcome, Effects: choice.Effects, Combat: choice.Combat, Next: choice.Next}}
+}
+
+// weight treats an unset weight as 1
+func weight(w int) int {
+	if w <= 0 {
+		return 1
+	}
+	return w
 }
@@ -0,0 +1,196 @@
+package data
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"strings"
+)
+
+// ScannerModuleName is the module that extends the range of the ship's scans
+const ScannerModuleName = "Sensor Array"
+
+// AnomalyPlanetType marks the temporary points of interest that scans turn up
+const AnomalyPlanetType = "Anomaly"
+
+// scanning and discovery tuning
+const (
+	ScanHours            = 3  // hours a scan takes on the game clock
+	AnomalyLifetime      = 48 // hours an anomaly lingers before it fades
+	XPScan               = 5  // per body charted by a scan
+	XPAnomaly            = 75 // for reaching an anomaly
+	baseScanRange        = 20
+	scannerRangePerLevel = 10 // per level of a working sensor array
+	scanRangePerDegree   = 10 // per degree of the best navigator or scientist aboard
+	systemScanCost       = 2  // scan range needed for every light year to a neighbouring system
+	anomalyChance        = 30 // percent chance a scan turns up an anomaly
+)
+
+// anomalyFinds are the items an anomaly can leave behind
+var anomalyFinds = []CollectionItem{
+	{Name: "Exotic Matter Sample", Description: "A sample of unknown exotic matter.", Tier: 2},
+	{Name: "Strange Artifact", Description: "An object of unknown origin, humming faintly.", Tier: 3},
+	{Name: "Graviton Residue", Description: "Dust that settles a little too slowly.", Tier: 2},
+	{Name: "Crystallised Signal", Description: "A lattice that replays the anomaly's last transmission.", Tier: 4},
+}
+
+// ScanReport sums up what a scan charted
+type ScanReport struct {
+	Range   int
+	Systems []string // star systems charted
+	Planets []string // bodies charted in the ship's system
+	Anomaly *Planet  // anomaly the scan turned up, if any
+}
+
+// Discovery is what the first visit to a body is worth
+type Discovery struct {
+	Planet       Planet
+	XP           int
+	ResearchNote bool
+	Item         *CollectionItem // added to the collection, nil when there was no find or no room for it
+}
+
+// SystemCharted reports whether a star system shows up on the map, everything is charted without fog of war
+func (g GameMap) SystemCharted(system StarSystem) bool {
+	return !g.FogOfWar || system.Scanned
+}
+
+// PlanetCharted reports whether a body shows up on the map
+func (g GameMap) PlanetCharted(planet Planet) bool {
+	return !g.FogOfWar || planet.Scanned
+}
+
+// IsAnomaly reports whether the body is a temporary anomaly
+func (p Planet) IsAnomaly() bool {
+	return p.Type == AnomalyPlanetType
+}
+
+// ScanRange returns how far the ship's scans reach
+// a working sensor array, a skilled navigator or scientist and scanner research push it further
+func ScanRange(save *FullGameSave) int {
+	degree := 0
+	for _, member := range save.Crew {
+		if (member.Role == CrewRoleNavigator || member.Role == CrewRoleScientist) && member.Health > 0 {
+			degree = max(degree, member.Degree)
+		}
+	}
+	return baseScanRange + scannerRangePerLevel*ModuleLevel(save.Ship, ScannerModuleName) + scanRangePerDegree*degree +
+		save.Ship.Research.Bonus().ScanRange
+}
+
+// Scan charts the bodies around the ship and the star systems within range, and may turn up an anomaly
+// the caller runs the clock for ScanHours
+func Scan(save *FullGameSave) ScanReport {
+	report, system := chartSurroundings(save)
+	if system == nil {
+		return report
+	}
+
+	if rand.Intn(100) < anomalyChance && !slices.ContainsFunc(system.Planets, Planet.IsAnomaly) {
+		anomaly := newAnomaly(save.Ship.Location.Coordinates, report.Range, save.GameMetadata.ClockHours)
+		system.Planets = append(system.Planets, anomaly)
+		report.Anomaly = &anomaly
+	}
+
+	if len(report.Systems)+len(report.Planets) > 0 {
+		WriteLog(save, LogDiscovery, "Scanned out to range %d and charted %d new star systems and %d new bodies.",
+			report.Range, len(report.Systems), len(report.Planets))
+	}
+	if report.Anomaly != nil {
+		WriteLog(save, LogDiscovery, "Picked up %s in the %s system.", report.Anomaly.Name, system.Name)
+	}
+	return report
+}
+
+// chartSurroundings marks what is within scan range of the ship as scanned and returns the ship's star system
+func chartSurroundings(save *FullGameSave) (ScanReport, *StarSystem) {
+	report := ScanReport{Range: ScanRange(save)}
+	here := save.Ship.Location
+	i := slices.IndexFunc(save.GameMap.StarSystems, func(s StarSystem) bool { return s.Name == here.StarSystemName })
+	if i < 0 {
+		return report, nil
+	}
+	origin := save.GameMap.StarSystems[i].Coordinates
+
+	for j := range save.GameMap.StarSystems {
+		system := &save.GameMap.StarSystems[j]
+		if !system.Scanned && distance(origin, system.Coordinates)*systemScanCost <= float64(report.Range) {
+			system.Scanned = true
+			report.Systems = append(report.Systems, system.Name)
+		}
+	}
+
+	system := &save.GameMap.StarSystems[i]
+	for j := range system.Planets {
+		planet := &system.Planets[j]
+		if !planet.Scanned && distance(here.Coordinates, planet.Coordinates) <= float64(report.Range) {
+			planet.Scanned = true
+			report.Planets = append(report.Planets, planet.Name)
+		}
+	}
+	return report, system
+}
+
+// newAnomaly places an anomaly somewhere within scan range of the ship
+func newAnomaly(origin Coordinates, scanRange, now int) Planet {
+	offset := func() int { return rand.Intn(scanRange+1) - scanRange/2 }
+	return Planet{
+		Name: fmt.Sprintf("Anomaly %c-%d", 'A'+rand.Intn(26), 100+rand.Intn(900)),
+		Type: AnomalyPlanetType,
+		Coordinates: Coordinates{
+			X: origin.X + offset(),
+			Y: origin.Y + offset(),
+			Z: origin.Z + offset(),
+		},
+		Scanned:   true,
+		ExpiresAt: now + AnomalyLifetime,
+	}
+}
+
+// ExpireAnomalies removes the anomalies that have faded, unless the ship is at one
+func ExpireAnomalies(save *FullGameSave) {
+	now := save.GameMetadata.ClockHours
+	for i := range save.GameMap.StarSystems {
+		system := &save.GameMap.StarSystems[i]
+		system.Planets = slices.DeleteFunc(system.Planets, func(p Planet) bool {
+			here := system.Name == save.Ship.Location.StarSystemName && p.Name == save.Ship.Location.PlanetName
+			return p.IsAnomaly() && p.ExpiresAt <= now && !here
+		})
+	}
+}
+
+// Discover marks a body as visited and reports what the first visit is worth
+// every new body adds a research note, anomalies are worth more and leave something behind for the collection
+func Discover(save *FullGameSave, loc Location) (Discovery, bool) {
+	if !DiscoverLocation(&save.GameMap, loc) {
+		return Discovery{}, false
+	}
+	discovery := Discovery{Planet: loc.GetFullPlanet(save.GameMap), XP: XPDiscovery, ResearchNote: true}
+	if discovery.Planet.IsAnomaly() {
+		discovery.XP = XPAnomaly
+		find := anomalyFinds[rand.Intn(len(anomalyFinds))]
+		if AddCollectionItem(&save.Collection, find) {
+			discovery.Item = &find
+			WriteLog(save, LogDiscovery, "Investigated %s in the %s system and recovered a %s.", loc.PlanetName, loc.StarSystemName, find.Name)
+		} else {
+			WriteLog(save, LogDiscovery, "Investigated %s in the %s system, the collection was too full to keep what it left behind.", loc.PlanetName, loc.StarSystemName)
+		}
+	} else {
+		WriteLog(save, LogDiscovery, "Charted %s, a %s in the %s system.", loc.PlanetName, strings.ToLower(discovery.Planet.Type), loc.StarSystemName)
+	}
+	return discovery, true
+}
+
+// ChartStartingArea turns on fog of war for a new game, only the home system and what a first scan reaches are known
+func ChartStartingArea(save *FullGameSave) {
+	save.GameMap.FogOfWar = true
+	DiscoverLocation(&save.GameMap, save.Ship.Location)
+	chartSurroundings(save)
+}
+
+// distance is the straight line distance between two coordinates
+func distance(a, b Coordinates) float64 {
+	dx, dy, dz := float64(b.X-a.X), float64(b.Y-a.Y), float64(b.Z-a.Z)
+	return math.Sqrt(dx*dx + dy*dy + dz*dz)
+}
@@ -0,0 +1,272 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	_ "embed"
+)
+
+//go:embed fabrication.json
+var embeddedFabricationRecipes []byte
+
+// FabricatorModuleName is the module every fabrication recipe is made in
+const FabricatorModuleName = "Fabricator"
+
+const maxModuleLevel = 5 // fabricated refits can't take a module past this level
+
+// FabricationSource is where a recipe input is taken from
+type FabricationSource string
+
+const (
+	SourceCargo      FabricationSource = "cargo" // the default
+	SourceCollection FabricationSource = "collection"
+)
+
+// FabricationOutputKind is what a recipe makes
+type FabricationOutputKind string
+
+const (
+	OutputCargo   FabricationOutputKind = "cargo"   // units of a cargo item
+	OutputFuel    FabricationOutputKind = "fuel"    // fuel straight into the tanks
+	OutputFood    FabricationOutputKind = "food"    // rations for the crew
+	OutputHull    FabricationOutputKind = "hull"    // hull points patched
+	OutputModule  FabricationOutputKind = "module"  // a level of the named module
+	OutputUpgrade FabricationOutputKind = "upgrade" // a level of the named upgrade track
+)
+
+// FabricationInput is a number of one item a recipe uses up
+type FabricationInput struct {
+	Item     string            `json:"item"`
+	Quantity int               `json:"quantity"`
+	Source   FabricationSource `json:"source,omitempty"`
+}
+
+// FabricationOutput is what a recipe makes, Item names the cargo item, module or upgrade track
+type FabricationOutput struct {
+	Kind     FabricationOutputKind `json:"kind"`
+	Item     string                `json:"item,omitempty"`
+	Quantity int                   `json:"quantity"`
+}
+
+// FabricationRecipe turns cargo and collection items into something the ship can use
+type FabricationRecipe struct {
+	ID              string             `json:"id"`
+	Name            string             `json:"name"`
+	Description     string             `json:"description"`
+	Role            CrewRole           `json:"role"`                      // a crew member of this role has to do the work
+	Degree          int                `json:"degree"`                    // lowest degree they need
+	FabricatorLevel int                `json:"fabricatorLevel,omitempty"` // lowest working fabricator level, defaults to 1
+	Hours           int                `json:"hours"`                     // hours the work takes on the game clock
+	Inputs          []FabricationInput `json:"inputs"`
+	Output          FabricationOutput  `json:"output"`
+}
+
+var FabricationRecipes []FabricationRecipe
+
+// LoadFabricationRecipes loads the fabrication recipes from the embedded fabrication.json
+func LoadFabricationRecipes() error {
+	var recipes []FabricationRecipe
+	if err := json.NewDecoder(bytes.NewReader(embeddedFabricationRecipes)).Decode(&recipes); err != nil {
+		return err
+	}
+	var errs []error
+	for _, recipe := range recipes {
+		if err := recipe.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	FabricationRecipes = recipes
+	return errors.Join(errs...)
+}
+
+// Validate checks a recipe for authoring mistakes
+func (r FabricationRecipe) Validate() error {
+	var errs []error
+	fail := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf("recipe %q: %s", r.ID, fmt.Sprintf(format, args...)))
+	}
+	if r.ID == "" || r.Name == "" {
+		fail("needs an id and a name")
+	}
+	if r.Role == "" {
+		fail("needs a crew role")
+	}
+	if len(r.Inputs) == 0 {
+		fail("needs at least one input")
+	}
+	for _, input := range r.Inputs {
+		if input.Item == "" || input.Quantity <= 0 {
+			fail("inputs need an item and a quantity")
+		}
+		if input.Source != "" && input.Source != SourceCargo && input.Source != SourceCollection {
+			fail("unknown input source %q", input.Source)
+		}
+	}
+	switch r.Output.Kind {
+	case OutputCargo, OutputModule:
+		if r.Output.Item == "" {
+			fail("%s output needs an item", r.Output.Kind)
+		}
+	case OutputUpgrade:
+		if _, ok := FindUpgradeType(r.Output.Item); !ok {
+			fail("unknown upgrade %q", r.Output.Item)
+		}
+	case OutputFuel, OutputFood, OutputHull:
+	default:
+		fail("unknown output kind %q", r.Output.Kind)
+	}
+	if r.Output.Quantity <= 0 {
+		fail("output needs a quantity")
+	}
+	return errors.Join(errs...)
+}
+
+// FindFabricationRecipe returns the recipe with the given id, or nil if none exists
+func FindFabricationRecipe(id string) *FabricationRecipe {
+	for i := range FabricationRecipes {
+		if FabricationRecipes[i].ID == id {
+			return &FabricationRecipes[i]
+		}
+	}
+	return nil
+}
+
+// Held returns how many of the input the ship has
+func (i FabricationInput) Held(save *FullGameSave) int {
+	if i.Source == SourceCollection {
+		if item := FindCollectionItem(&save.Collection, i.Item); item != nil {
+			return item.Quantity
+		}
+		return 0
+	}
+	return CargoQuantity(save.Ship.Cargo, i.Item)
+}
+
+// Fabricator returns the crew member who can work a recipe, the most experienced of the right role still standing
+func (r FabricationRecipe) Fabricator(crew []CrewMember) *CrewMember {
+	var best *CrewMember
+	for i := range crew {
+		member := &crew[i]
+		if member.Role == r.Role && member.Health > 0 && member.Degree >= r.Degree && (best == nil || member.Degree > best.Degree) {
+			best = member
+		}
+	}
+	return best
+}
+
+// CanFabricate reports why a recipe can't be made right now, nil when it can
+func CanFabricate(save *FullGameSave, recipe FabricationRecipe) error {
+	if level := ModuleLevel(save.Ship, FabricatorModuleName); level < max(recipe.FabricatorLevel, 1) {
+		if level == 0 {
+			return fmt.Errorf("the ship needs a working %s", FabricatorModuleName)
+		}
+		return fmt.Errorf("the %s needs a level %d %s", recipe.Name, recipe.FabricatorLevel, FabricatorModuleName)
+	}
+	if recipe.Fabricator(save.Crew) == nil {
+		return fmt.Errorf("the %s needs a degree %d %s", recipe.Name, recipe.Degree, recipe.Role)
+	}
+	for _, input := range recipe.Inputs {
+		if input.Held(save) < input.Quantity {
+			return fmt.Errorf("not enough %s", input.Item)
+		}
+	}
+
+	ship := save.Ship
+	switch recipe.Output.Kind {
+	case OutputCargo:
+		// the inputs taken out of the hold make room for the output
+		freed := 0
+		for _, input := range recipe.Inputs {
+			if input.Source != SourceCollection {
+				freed += input.Quantity
+			}
+		}
+		if ship.Cargo.UsedCapacity-freed+recipe.Output.Quantity > ship.Cargo.Capacity {
+			return fmt.Errorf("no room in the cargo hold")
+		}
+	case OutputFuel:
+		if ship.Fuel >= ship.MaxFuel {
+			return fmt.Errorf("the fuel tanks are full")
+		}
+	case OutputHull:
+		if ship.HullIntegrity >= ship.MaxHullIntegrity {
+			return fmt.Errorf("the hull doesn't need patching")
+		}
+	case OutputModule:
+		i := slices.IndexFunc(ship.Modules, func(m Module) bool { return m.Name == recipe.Output.Item })
+		if i < 0 {
+			return fmt.Errorf("the ship has no %s to refit", recipe.Output.Item)
+		}
+		if ship.Modules[i].Level >= maxModuleLevel {
+			return fmt.Errorf("the %s is already at its highest level", recipe.Output.Item)
+		}
+	case OutputUpgrade:
+		t, _ := FindUpgradeType(recipe.Output.Item)
+		if level := ship.Upgrades.Level(t); level.CurrentLevel >= level.MaxLevel {
+			return fmt.Errorf("the %s is already at its highest level", recipe.Output.Item)
+		}
+	}
+	return nil
+}
+
+// Fabricate uses up a recipe's inputs to make its output and returns who did the work
+// the caller runs the clock for the recipe's hours
+func Fabricate(save *FullGameSave, recipe FabricationRecipe) (CrewMember, error) {
+	if err := CanFabricate(save, recipe); err != nil {
+		return CrewMember{}, err
+	}
+	fabricator := *recipe.Fabricator(save.Crew)
+
+	for _, input := range recipe.Inputs {
+		if input.Source == SourceCollection {
+			removeCollectionItem(&save.Collection, input.Item, input.Quantity)
+		} else {
+			RemoveCargoItem(&save.Ship.Cargo, input.Item, input.Quantity)
+		}
+	}
+
+	ship := &save.Ship
+	output := recipe.Output
+	switch output.Kind {
+	case OutputCargo:
+		AddCargoItem(&ship.Cargo, output.Item, output.Quantity)
+	case OutputFuel:
+		ship.Fuel = min(ship.Fuel+output.Quantity, ship.MaxFuel)
+	case OutputFood:
+		ship.Food += output.Quantity
+	case OutputHull:
+		ship.HullIntegrity = min(ship.HullIntegrity+output.Quantity, ship.MaxHullIntegrity)
+	case OutputModule:
+		for i := range ship.Modules {
+			if ship.Modules[i].Name == output.Item {
+				ship.Modules[i].Level = min(ship.Modules[i].Level+output.Quantity, maxModuleLevel)
+			}
+		}
+	case OutputUpgrade:
+		t, _ := FindUpgradeType(output.Item)
+		level := ship.Upgrades.Level(t)
+		level.CurrentLevel = min(level.CurrentLevel+output.Quantity, level.MaxLevel)
+		ApplyUpgradeEffects(ship)
+		ApplyCollectionCapacity(save)
+	}
+
+	WriteLog(save, LogFabrication, "%s fabricated %s.", fabricator.Name, recipe.Name)
+	return fabricator, nil
+}
+
+// ensureFabricatorModule gives older saves the fabricator that new ships start with
+func ensureFabricatorModule(ship *Ship) {
+	if slices.ContainsFunc(ship.Modules, func(m Module) bool { return m.Name == FabricatorModuleName }) {
+		return
+	}
+	ship.Modules = append(ship.Modules, Module{
+		ModuleId: generateRandomID("MOD_FAB_"),
+		Name:     FabricatorModuleName,
+		Level:    1,
+		Status:   "operational",
+	})
+}
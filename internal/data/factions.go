@@ -0,0 +1,88 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+
+	_ "embed"
+)
+
+//go:embed factions.json
+var embeddedFactions []byte
+
+// Faction is one of the powers the player can gain or lose standing with
+type Faction struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Systems     []string `json:"systems"` // star systems the faction controls at the start of a game
+}
+
+var Factions []Faction
+
+// LoadFactions loads the faction registry from the embedded factions.json
+func LoadFactions() error {
+	var factions []Faction
+	if err := json.NewDecoder(bytes.NewReader(embeddedFactions)).Decode(&factions); err != nil {
+		return err
+	}
+	Factions = factions
+	return nil
+}
+
+// FindFaction returns the faction with the given id, or nil if it isn't in the registry
+func FindFaction(id string) *Faction {
+	for i := range Factions {
+		if Factions[i].ID == id {
+			return &Factions[i]
+		}
+	}
+	return nil
+}
+
+// FactionName returns the display name of a faction, falling back to its id
+func FactionName(id string) string {
+	if faction := FindFaction(id); faction != nil {
+		return faction.Name
+	}
+	return id
+}
+
+// ControllingFaction returns the faction in control of a location
+// a planet or station with its own faction overrides the faction of its star system
+func ControllingFaction(gameMap GameMap, loc Location) string {
+	for _, system := range gameMap.StarSystems {
+		if system.Name != loc.StarSystemName {
+			continue
+		}
+		for _, planet := range system.Planets {
+			if planet.Name == loc.PlanetName && planet.Faction != "" {
+				return planet.Faction
+			}
+		}
+		return system.Faction
+	}
+	return ""
+}
+
+// assignFactionTerritory gives every unclaimed star system the faction that controls it in the registry
+func assignFactionTerritory(gameMap *GameMap) {
+	if len(Factions) == 0 {
+		if err := LoadFactions(); err != nil {
+			return
+		}
+	}
+	for i := range gameMap.StarSystems {
+		system := &gameMap.StarSystems[i]
+		if system.Faction != "" {
+			continue
+		}
+		for _, faction := range Factions {
+			for _, name := range faction.Systems {
+				if name == system.Name {
+					system.Faction = faction.ID
+				}
+			}
+		}
+	}
+}
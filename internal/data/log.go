@@ -0,0 +1,97 @@
+package data
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LogExportPath is where the captain's log is exported to as Markdown
+const LogExportPath = "GameData/save/captains_log.md"
+
+// LogEntryType sorts captain's log entries so they can be filtered
+type LogEntryType string
+
+const (
+	LogArrival     LogEntryType = "arrival"
+	LogEvent       LogEntryType = "event"
+	LogCrew        LogEntryType = "crew" // hires and deaths
+	LogPurchase    LogEntryType = "purchase"
+	LogMission     LogEntryType = "mission"
+	LogDiscovery   LogEntryType = "discovery"
+	LogCollection  LogEntryType = "collection" // items analyzed, sold, donated or crafted
+	LogFabrication LogEntryType = "fabrication"
+	LogDiplomacy   LogEntryType = "diplomacy" // first contacts and negotiations
+	LogNote        LogEntryType = "note"      // written by the player
+)
+
+// LogEntryTypes lists every entry type in the order the log filters cycle through them
+var LogEntryTypes = []LogEntryType{LogArrival, LogEvent, LogCrew, LogPurchase, LogMission, LogDiscovery, LogCollection, LogFabrication, LogDiplomacy, LogNote}
+
+// LogEntry is a single line of the captain's log
+type LogEntry struct {
+	Hour int          `json:"hour"` // game clock hour the entry was written
+	Type LogEntryType `json:"type"`
+	Text string       `json:"text"`
+}
+
+// Label returns the entry type with its first letter capitalised, e.g. "Arrival"
+func (t LogEntryType) Label() string {
+	if t == "" {
+		return "All"
+	}
+	return strings.ToUpper(string(t[:1])) + string(t[1:])
+}
+
+// WriteLog adds an entry to the captain's log at the current hour of the game clock
+func WriteLog(save *FullGameSave, entryType LogEntryType, format string, args ...any) {
+	save.Log = append(save.Log, LogEntry{
+		Hour: save.GameMetadata.ClockHours,
+		Type: entryType,
+		Text: fmt.Sprintf(format, args...),
+	})
+}
+
+// FilterLog returns the entries of a type whose text contains the query, newest first
+// an empty type matches every entry and the query ignores case
+func FilterLog(entries []LogEntry, entryType LogEntryType, query string) []LogEntry {
+	query = strings.ToLower(query)
+	var filtered []LogEntry
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		if entryType != "" && entry.Type != entryType {
+			continue
+		}
+		if query != "" && !strings.Contains(strings.ToLower(entry.Text), query) {
+			continue
+		}
+		filtered = append(filtered, entry)
+	}
+	return filtered
+}
+
+// LogMarkdown renders the captain's log as a Markdown document, one section per day
+func LogMarkdown(save *FullGameSave) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Captain's log of the %s\n\n", save.Ship.ShipName)
+	fmt.Fprintf(&b, "Commander %s\n", save.Player.PlayerName)
+
+	day := -1
+	for _, entry := range save.Log {
+		if Day(entry.Hour) != day {
+			day = Day(entry.Hour)
+			fmt.Fprintf(&b, "\n## Stardate %d\n\n", StardateEpoch+day)
+		}
+		fmt.Fprintf(&b, "- **SD %s** _%s_: %s\n", Stardate(entry.Hour), entry.Type, entry.Text)
+	}
+	return b.String()
+}
+
+// ExportLog writes the captain's log to LogExportPath and returns where it went
+func ExportLog(save *FullGameSave) (string, error) {
+	if err := os.MkdirAll(filepath.Dir(LogExportPath), 0755); err != nil {
+		return "", err
+	}
+	return LogExportPath, os.WriteFile(LogExportPath, []byte(LogMarkdown(save)), 0644)
+}
@@ -0,0 +1,161 @@
+package data
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+)
+
+// mission board tuning
+const (
+	BoardSize               = 3    // postings on a station's board, more once the owner likes the player
+	MaxDanger               = 3    // danger rating of the riskiest postings
+	firstGeneratedMissionID = 1000 // generated missions are numbered from here, clear of the authored ones
+	postingMinHours         = 24   // shortest time a posting stays on the board
+	postingMaxHours         = 72   // longest time a posting stays on the board
+	localDestinationChance  = 60   // % of postings sent somewhere in the station's own system
+	longHaulDistance        = 60   // distance from which a trip counts as dangerous in itself
+	payoutPerDistance       = 15   // credits added for every unit of distance to the destination
+	payoutPerDanger         = 400  // credits added for every point of danger past the first
+)
+
+// NewMissionID returns a mission id no other mission in the save has, ids keep counting up across saves
+func NewMissionID(save *FullGameSave) int {
+	id := max(save.GameMetadata.NextMissionID, firstGeneratedMissionID)
+	save.GameMetadata.NextMissionID = id + 1
+	return id
+}
+
+// BoardSizeFor returns how many postings a station's board holds for the player
+// stations run by a faction that likes the player post more work
+func BoardSizeFor(save *FullGameSave, station Station) int {
+	switch TierFor(Standing(save.Player.Reputation, station.Faction)) {
+	case TierAllied:
+		return BoardSize + 2
+	case TierFriendly:
+		return BoardSize + 1
+	}
+	return BoardSize
+}
+
+// MissionBoard returns a station's board as it stands now on the game clock
+// postings past their expiry are taken down and new ones are put up in their place
+func MissionBoard(save *FullGameSave, station Station, templates []MissionTemplate) []Mission {
+	state := stationState(save, station)
+	now := save.GameMetadata.ClockHours
+	state.Board = slices.DeleteFunc(state.Board, func(m Mission) bool { return m.OfferExpires <= now })
+	for len(state.Board) < BoardSizeFor(save, station) {
+		posting, ok := GeneratePosting(save, station, templates)
+		if !ok {
+			break
+		}
+		state.Board = append(state.Board, posting)
+	}
+	return slices.Clone(state.Board)
+}
+
+// TakePosting removes an accepted mission from a station's board
+func TakePosting(save *FullGameSave, station Station, id int) {
+	state := stationState(save, station)
+	state.Board = slices.DeleteFunc(state.Board, func(m Mission) bool { return m.Id == id })
+}
+
+// GeneratePosting makes a mission for a station's board from a template the player's standing allows
+// the payout grows with the distance to the destination and the danger of the job
+func GeneratePosting(save *FullGameSave, station Station, templates []MissionTemplate) (Mission, bool) {
+	templates = AvailableMissionTemplates(templates, save.Player.Reputation)
+	from := Location{StarSystemName: station.StarSystem, PlanetName: station.Planet}
+	from.Coordinates = from.GetFullPlanet(save.GameMap).Coordinates
+	dest, ok := missionDestination(save.GameMap, from)
+	if len(templates) == 0 || !ok {
+		return Mission{}, false
+	}
+	t := templates[rand.Intn(len(templates))]
+
+	distance := NewLocationService(save.GameMap).CalculateDistance(from.Coordinates, dest.Coordinates, from.StarSystemName, dest.StarSystemName)
+	danger := MissionDanger(save, t, dest, distance)
+	income := t.Income + rand.Intn(t.Income/2+1) + payoutPerDistance*distance + payoutPerDanger*(danger-1)
+	income = AdjustPrice(income, RewardModifier(save.Player.Reputation, t.Faction))
+
+	// the template's text is filled in for this destination, giver and payout
+	vars := locationVars(save, dest)
+	vars["station"] = station.Name
+	vars["reward"] = fmt.Sprintf("%d credits", income)
+	giver := t.Received
+	if len(t.Givers) > 0 {
+		giver = t.Givers[rand.Intn(len(t.Givers))]
+	}
+	if giver != "" {
+		vars["giver"] = giver
+	} else {
+		giver = "{giver}" // drawn from the word list
+	}
+
+	mission := Mission{
+		Id:           NewMissionID(save),
+		Step:         t.Step,
+		Title:        t.Title,
+		Description:  t.Description,
+		Status:       MissionStatusNotStarted,
+		Location:     dest,
+		Income:       income,
+		Requirements: t.Requirements,
+		Received:     giver,
+		Category:     t.Category,
+		Dialogue:     t.Dialogue,
+		Conversation: t.Conversation,
+		Enemy:        t.Enemy,
+		Faction:      t.Faction,
+		Objectives:   slices.Clone(t.Objectives),
+		TimeLimit:    t.TimeLimit,
+		Danger:       danger,
+		OfferExpires: save.GameMetadata.ClockHours + postingMinHours + rand.Intn(postingMaxHours-postingMinHours+1),
+	}
+	vars.renderMission(&mission)
+	return mission, true
+}
+
+// MissionDanger rates how risky a job is from 1 to MaxDanger
+// fights, destinations held by factions that dislike the player and long hauls all add to it
+func MissionDanger(save *FullGameSave, t MissionTemplate, dest Location, distance int) int {
+	danger := 1
+	if t.Enemy != "" || slices.ContainsFunc(t.Objectives, func(o Objective) bool {
+		return o.Type == ObjectiveCombat || o.Type == ObjectiveProtect
+	}) {
+		danger++
+	}
+	if faction := ControllingFaction(save.GameMap, dest); faction != "" && TierFor(Standing(save.Player.Reputation, faction)) <= TierUnfriendly {
+		danger++
+	}
+	if distance >= longHaulDistance {
+		danger++
+	}
+	return min(danger, MaxDanger)
+}
+
+// missionDestination picks where a posting sends the ship, usually somewhere in the same system
+// anomalies fade too soon to send anyone to, and the station itself is never the destination
+func missionDestination(gameMap GameMap, from Location) (Location, bool) {
+	var local, remote []Location
+	for _, system := range gameMap.StarSystems {
+		for _, planet := range system.Planets {
+			if planet.ExpiresAt != 0 || (system.Name == from.StarSystemName && planet.Name == from.PlanetName) {
+				continue
+			}
+			loc := NewLocationFromPlanet(system, planet)
+			if system.Name == from.StarSystemName {
+				local = append(local, loc)
+			} else {
+				remote = append(remote, loc)
+			}
+		}
+	}
+	pool := local
+	if len(remote) > 0 && (len(local) == 0 || rand.Intn(100) >= localDestinationChance) {
+		pool = remote
+	}
+	if len(pool) == 0 {
+		return Location{}, false
+	}
+	return pool[rand.Intn(len(pool))], true
+}
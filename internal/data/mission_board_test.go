@@ -0,0 +1,99 @@
+package data
+
+import (
+	"slices"
+	"testing"
+)
+
+// testBoardStation is a station in a one system galaxy with a few destinations to send postings to
+var testBoardStation = Station{ID: "test_port", Name: "Test Port", StarSystem: "Sol", Planet: "Port"}
+
+var testBoardTemplates = []MissionTemplate{{Title: "Haul to {planet}", Income: 100, Received: "Dock Master"}}
+
+func newBoardSave() *FullGameSave {
+	return &FullGameSave{
+		GameMap: GameMap{StarSystems: []StarSystem{{
+			Name:    "Sol",
+			Planets: []Planet{{Name: "Port", Type: StationPlanetType}, {Name: "Mars"}, {Name: "Venus"}},
+		}}},
+	}
+}
+
+// boardIDs returns the ids of the postings on a board in order
+func boardIDs(board []Mission) []int {
+	ids := make([]int, len(board))
+	for i, m := range board {
+		ids[i] = m.Id
+	}
+	return ids
+}
+
+func TestMissionBoardExpiry(t *testing.T) {
+	tests := []struct {
+		name     string
+		expires  []int // when the postings already on the board, ids 1, 2 and 3, come off it
+		now      int
+		wantKept []int
+	}{
+		{name: "nothing expired", expires: []int{10, 20, 30}, now: 5, wantKept: []int{1, 2, 3}},
+		{name: "expiring on the hour", expires: []int{10, 20, 30}, now: 10, wantKept: []int{2, 3}},
+		{name: "some expired", expires: []int{30, 10, 20}, now: 25, wantKept: []int{1}},
+		{name: "all expired", expires: []int{10, 20, 30}, now: 40, wantKept: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			save := newBoardSave()
+			save.GameMetadata.ClockHours = tt.now
+			var board []Mission
+			for i, expires := range tt.expires {
+				board = append(board, Mission{Id: i + 1, OfferExpires: expires})
+			}
+			save.Stations = []StationState{{Station: testBoardStation.ID, StockDay: Day(tt.now), Board: board}}
+
+			got := MissionBoard(save, testBoardStation, testBoardTemplates)
+			if len(got) != BoardSize {
+				t.Fatalf("board has %d postings, want %d", len(got), BoardSize)
+			}
+			ids := boardIDs(got)
+			if !slices.Equal(ids[:len(tt.wantKept)], tt.wantKept) {
+				t.Errorf("kept postings %v, want %v", ids[:len(tt.wantKept)], tt.wantKept)
+			}
+			for _, posting := range got[len(tt.wantKept):] {
+				if posting.Id < firstGeneratedMissionID {
+					t.Errorf("new posting has id %d, want one from %d up", posting.Id, firstGeneratedMissionID)
+				}
+				if posting.OfferExpires <= tt.now {
+					t.Errorf("new posting expires at %d, want after %d", posting.OfferExpires, tt.now)
+				}
+			}
+		})
+	}
+}
+
+func TestMissionBoardIDsStable(t *testing.T) {
+	save := newBoardSave()
+
+	first := boardIDs(MissionBoard(save, testBoardStation, testBoardTemplates))
+	if again := boardIDs(MissionBoard(save, testBoardStation, testBoardTemplates)); !slices.Equal(first, again) {
+		t.Fatalf("board ids changed between looks: %v then %v", first, again)
+	}
+
+	// taking a posting leaves the others alone and its replacement gets an id never used before
+	TakePosting(save, testBoardStation, first[0])
+	after := boardIDs(MissionBoard(save, testBoardStation, testBoardTemplates))
+	if !slices.Equal(after[:len(first)-1], first[1:]) {
+		t.Errorf("board after taking %d = %v, want %v kept", first[0], after, first[1:])
+	}
+	if replacement := after[len(after)-1]; slices.Contains(first, replacement) {
+		t.Errorf("replacement posting reused id %d", replacement)
+	}
+
+	seen := make(map[int]bool)
+	for range 20 {
+		id := NewMissionID(save)
+		if seen[id] || slices.Contains(after, id) {
+			t.Fatalf("NewMissionID() returned %d twice", id)
+		}
+		seen[id] = true
+	}
+}
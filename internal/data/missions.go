@@ -3,7 +3,8 @@ package data
 import (
 	"bytes"
 	"encoding/json"
-	"math/rand"
+	"errors"
+	"fmt"
 
 	_ "embed"
 )
@@ -21,13 +22,15 @@ type MissionTemplate struct {
 	Income       int           `json:"Income"`
 	Requirements string        `json:"Requirements"`
 	Received     string        `json:"Received"`
+	Givers       []string      `json:"Givers,omitempty"` // who may post the mission, one is picked for {giver} in place of Received
 	Category     string        `json:"Category"`
 	Dialogue     []string      `json:"dialogue"`
-}
-
-type PlanetWithSystem struct {
-	StarSystemName string
-	Planet         Planet
+	Conversation *DialogueTree `json:"Conversation,omitempty"`
+	Enemy        string        `json:"Enemy,omitempty"`
+	Faction      string        `json:"Faction,omitempty"`
+	MinStanding  *int          `json:"MinStanding,omitempty"` // lowest standing with Faction at which the mission is offered
+	Objectives   []Objective   `json:"Objectives,omitempty"`
+	TimeLimit    int           `json:"TimeLimit,omitempty"` // hours on the game clock to finish the mission, 0 for no deadline
 }
 
 // loads missions from the embedded mission_templates.json data
@@ -42,59 +45,141 @@ func LoadMissionTemplates() ([]MissionTemplate, error) {
 		return nil, err
 	}
 
-	return data.Missions, nil
+	// placeholders and objectives are checked here, a template that can't be filled in is a bug in the data
+	var errs []error
+	for _, t := range data.Missions {
+		errs = append(errs, ValidateMissionTemplate(t)...)
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	return mergeModTemplates(data.Missions), nil
 }
 
-// find parent star system for planet
-func FlattenPlanetsWithSystems(systems []StarSystem) []PlanetWithSystem {
-	var result []PlanetWithSystem
-	for _, system := range systems {
-		for _, planet := range system.Planets {
-			result = append(result, PlanetWithSystem{
-				StarSystemName: system.Name,
-				Planet:         planet,
-			})
+// ValidateMissionTemplate checks a mission template for authoring mistakes
+func ValidateMissionTemplate(t MissionTemplate) []error {
+	var errs []error
+	if t.Title == "" {
+		errs = append(errs, fmt.Errorf("mission %d: needs a title", t.Id))
+	}
+	if t.TimeLimit < 0 {
+		errs = append(errs, fmt.Errorf("mission %d (%s): time limit can't be negative", t.Id, t.Title))
+	}
+	if err := validatePlaceholders(missionTemplateTexts(t), missionPlaceholders); err != nil {
+		errs = append(errs, fmt.Errorf("mission %d (%s): %w", t.Id, t.Title, err))
+	}
+	if t.Conversation != nil {
+		if err := t.Conversation.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("mission %d (%s): %w", t.Id, t.Title, err))
 		}
 	}
-	return result
+	for _, objective := range t.Objectives {
+		if !objective.Type.Valid() {
+			errs = append(errs, fmt.Errorf("mission %d (%s): unknown objective type %q", t.Id, t.Title, objective.Type))
+		}
+		if objective.Type == ObjectiveNegotiate && FindSpecies(objective.Species) == nil {
+			errs = append(errs, fmt.Errorf("mission %d (%s): unknown species %q", t.Id, t.Title, objective.Species))
+		}
+		if objective.Type == ObjectiveScript {
+			if _, _, err := splitScriptRef(objective.Script); err != nil {
+				errs = append(errs, fmt.Errorf("mission %d (%s): %w", t.Id, t.Title, err))
+			}
+			if objective.Description == "" {
+				errs = append(errs, fmt.Errorf("mission %d (%s): script objectives need a description", t.Id, t.Title))
+			}
+		}
+	}
+	return errs
 }
 
-// generate a semi-generated mission
-func GenerateMissionFromTemplate(id int, templates []MissionTemplate, planets []PlanetWithSystem, currentStarSystem string) Mission {
-	t := templates[rand.Intn(len(templates))] // Random mission template
-
-	// Filter planets to only include those in the current star system
-	var localPlanets []PlanetWithSystem
-	for _, planet := range planets {
-		if planet.StarSystemName == currentStarSystem {
-			localPlanets = append(localPlanets, planet)
+// AvailableMissionTemplates returns the templates whose faction is willing to offer work to the player
+// factions that are Unfriendly or worse stop offering work unless the template sets a lower MinStanding
+func AvailableMissionTemplates(templates []MissionTemplate, rep Reputation) []MissionTemplate {
+	var available []MissionTemplate
+	for _, t := range templates {
+		minStanding := t.MinStanding
+		if minStanding == nil {
+			neutral := -9
+			minStanding = &neutral
+		}
+		if MeetsStanding(rep, t.Faction, minStanding, nil) {
+			available = append(available, t)
 		}
 	}
+	return available
+}
+
+// standing lost with the giver's faction when a mission doesn't get done
+const (
+	MissionAbandonPenalty = 10
+	MissionFailurePenalty = 5
+)
+
+// AcceptMission stamps a mission entering the journal with the game clock hour and starts its deadline
+func AcceptMission(m *Mission, now int) {
+	m.AcceptedAt = now
+	m.OfferExpires = 0 // the posting is off the board
+	if m.TimeLimit > 0 && m.Deadline == 0 {
+		m.Deadline = now + m.TimeLimit
+	}
+}
 
-	// Fallback: If no planets in current system, just use all
-	if len(localPlanets) == 0 {
-		localPlanets = planets
+// TimeLeft returns the hours left before the mission's deadline, or false if it has none
+func TimeLeft(m Mission, now int) (int, bool) {
+	if m.Deadline == 0 {
+		return 0, false
 	}
+	return max(m.Deadline-now, 0), true
+}
+
+// MissionActive reports whether a mission can still be worked on
+func MissionActive(m Mission) bool {
+	return m.Status == MissionStatusNotStarted || m.Status == MissionStatusInProgress
+}
+
+// FailMission marks the mission at index i as failed, costing standing with the faction that gave it
+// a quest mission also fails its quest, which can send the quest line down another path
+func FailMission(save *FullGameSave, i int, reason string) QuestUpdate {
+	return endMission(save, i, MissionStatusFailed, reason, MissionFailurePenalty)
+}
 
-	p := localPlanets[rand.Intn(len(localPlanets))] // Pick random planet in same star system
-	income := 1000 + rand.Intn(1500)                // Random income (Could add mission difficulty multipliers)
-
-	// Build and return the mission
-	return Mission{
-		Id:          id,
-		Step:        t.Step,
-		Title:       t.Title,
-		Description: t.Description,
-		Status:      0,
-		Location: Location{
-			StarSystemName: p.StarSystemName,
-			PlanetName:     p.Planet.Name,
-			Coordinates:    p.Planet.Coordinates,
-		},
-		Income:       income,
-		Requirements: t.Requirements,
-		Received:     t.Received,
-		Category:     t.Category,
-		Dialogue:     t.Dialogue,
+// AbandonMission marks the mission at index i as abandoned, the giver's faction takes that badly
+func AbandonMission(save *FullGameSave, i int) QuestUpdate {
+	return endMission(save, i, MissionStatusAbandoned, "Abandoned by the captain", MissionAbandonPenalty)
+}
+
+// endMission closes a mission without completing it
+func endMission(save *FullGameSave, i int, status MissionStatus, reason string, penalty int) QuestUpdate {
+	mission := &save.Missions[i]
+	if !MissionActive(*mission) {
+		return QuestUpdate{}
+	}
+	mission.Status = status
+	mission.Reason = reason
+	mission.EndedAt = save.GameMetadata.ClockHours
+	ChangeReputation(&save.Player.Reputation, mission.Faction, -penalty)
+	WriteLog(save, LogMission, "%s: %s. %s.", status, mission.Title, reason)
+
+	if mission.QuestID == "" {
+		return QuestUpdate{}
+	}
+	return FailQuest(save, mission.QuestID)
+}
+
+// ExpireMissions fails every active mission whose deadline has passed on the game clock
+func ExpireMissions(save *FullGameSave) ([]string, QuestUpdate) {
+	now := save.GameMetadata.ClockHours
+	var expired []string
+	var update QuestUpdate
+	for i, mission := range save.Missions {
+		if !MissionActive(mission) || mission.Deadline == 0 || now < mission.Deadline {
+			continue
+		}
+		expired = append(expired, mission.Title)
+		failed := FailMission(save, i, "The deadline passed")
+		update.Offered = append(update.Offered, failed.Offered...)
+		update.Failed = append(update.Failed, failed.Failed...)
 	}
+	return expired, update
 }
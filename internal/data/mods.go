@@ -0,0 +1,357 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+)
+
+// ModsDir is where players drop content mods, each mod is a folder of content files
+const ModsDir = "GameData/mods"
+
+// files a mod folder may contain, each merges with or overrides the embedded content by id
+const (
+	ModEventsFile   = "events.json"            // array of events, keyed by id
+	ModMissionsFile = "mission_templates.json" // {"missions": [...]} like the embedded file, keyed by Id
+	ModGalaxyFile   = "galaxy.json"            // array of star systems, keyed by name
+	ModScriptsDir   = "scripts"                // .star script files, keyed by file name
+)
+
+// ModReport sums up what happened when the mods were loaded
+type ModReport struct {
+	Loaded   []string // names of the mods that were loaded
+	Problems []error  // invalid content and conflicts between mods, each with a file and line
+}
+
+// content merged in from mods, in load order
+var (
+	modEvents    []Event
+	modTemplates []MissionTemplate
+	modSystems   []StarSystem
+)
+
+// modEntry is one element of a mod file along with where it came from
+type modEntry struct {
+	Source string // file:line the element starts on
+	Raw    json.RawMessage
+}
+
+// LoadMods reads every mod folder in dir, skipping the disabled ones, and validates its content
+// invalid entries are left out and reported, mods are loaded in folder name order and later mods win conflicts
+// LoadEvents, LoadMissionTemplates and new games pick up the merged content afterwards
+func LoadMods(dir string, disabled []string) ModReport {
+	var report ModReport
+	modEvents, modTemplates, modSystems = nil, nil, nil
+	modScripts = make(map[string][]byte)
+	Scripts = nil // reloaded with the modded scripts on next use
+
+	folders, err := os.ReadDir(dir)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			report.Problems = append(report.Problems, err)
+		}
+		return report
+	}
+
+	// embedded events are needed to check follow-ups that point back into the base game
+	eventIDs := make(map[int]bool)
+	if base, err := ParseEvents(bytes.NewReader(embeddedEvents)); err == nil {
+		for _, event := range base {
+			eventIDs[event.ID] = true
+		}
+	}
+
+	eventSources := make(map[int]string)
+	templateSources := make(map[int]string)
+	systemSources := make(map[string]string)
+	scriptSources := make(map[string]string)
+
+	for _, folder := range folders {
+		if !folder.IsDir() || slices.Contains(disabled, folder.Name()) {
+			continue
+		}
+		path := filepath.Join(dir, folder.Name())
+		report.Loaded = append(report.Loaded, folder.Name())
+
+		// events are decoded first and validated once the whole mod is known, so follow-ups can point at each other
+		var events []Event
+		var sources []string
+		for _, entry := range readModFile(filepath.Join(path, ModEventsFile), "", &report) {
+			var event Event
+			if err := decodeModEntry(entry, &event); err != nil {
+				report.Problems = append(report.Problems, err)
+				continue
+			}
+			events = append(events, event)
+			sources = append(sources, entry.Source)
+		}
+		// follow-ups may only point at events that pass, so dropping one can fail another, repeat until none are dropped
+		pending := make([]int, len(events))
+		for i := range events {
+			pending[i] = i
+		}
+		for dropped := true; dropped; {
+			ids := maps.Clone(eventIDs)
+			for _, i := range pending {
+				ids[events[i].ID] = true
+			}
+			dropped = false
+			pending = slices.DeleteFunc(pending, func(i int) bool {
+				errs := validateEvent(events[i], ids)
+				if len(errs) > 0 {
+					report.Problems = append(report.Problems, modProblems(sources[i], errs)...)
+					dropped = true
+				}
+				return len(errs) > 0
+			})
+		}
+		for _, i := range pending {
+			event := events[i]
+			eventIDs[event.ID] = true
+			recordSource(&report, eventSources, event.ID, sources[i], "event "+strconv.Itoa(event.ID))
+			modEvents = append(modEvents, event)
+		}
+
+		for _, entry := range readModFile(filepath.Join(path, ModMissionsFile), "missions", &report) {
+			var template MissionTemplate
+			if err := decodeModEntry(entry, &template); err != nil {
+				report.Problems = append(report.Problems, err)
+				continue
+			}
+			if errs := ValidateMissionTemplate(template); len(errs) > 0 {
+				report.Problems = append(report.Problems, modProblems(entry.Source, errs)...)
+				continue
+			}
+			recordSource(&report, templateSources, template.Id, entry.Source, "mission "+strconv.Itoa(template.Id))
+			modTemplates = append(modTemplates, template)
+		}
+
+		for _, entry := range readModFile(filepath.Join(path, ModGalaxyFile), "", &report) {
+			var system StarSystem
+			if err := decodeModEntry(entry, &system); err != nil {
+				report.Problems = append(report.Problems, err)
+				continue
+			}
+			if errs := validateStarSystem(system); len(errs) > 0 {
+				report.Problems = append(report.Problems, modProblems(entry.Source, errs)...)
+				continue
+			}
+			recordSource(&report, systemSources, system.Name, entry.Source, "star system "+system.Name)
+			modSystems = append(modSystems, system)
+		}
+
+		files, _ := filepath.Glob(filepath.Join(path, ModScriptsDir, "*.star"))
+		for _, file := range files {
+			src, err := os.ReadFile(file)
+			if err != nil {
+				report.Problems = append(report.Problems, err)
+				continue
+			}
+			// running the top level catches syntax errors, which are reported with their line
+			if _, err := execScript(file, src); err != nil {
+				report.Problems = append(report.Problems, err)
+				continue
+			}
+			name := filepath.Base(file)
+			recordSource(&report, scriptSources, name, file, "script "+name)
+			modScripts[name] = src
+		}
+	}
+	return report
+}
+
+// recordSource notes where a piece of content came from and reports a conflict when an earlier mod already changed it
+func recordSource[K comparable](report *ModReport, sources map[K]string, key K, source, what string) {
+	if previous, ok := sources[key]; ok {
+		report.Problems = append(report.Problems, fmt.Errorf("%s: %s conflicts with %s, the later mod wins", source, what, previous))
+	}
+	sources[key] = source
+}
+
+// readModFile splits a mod file into its entries, a missing file has none
+// key names the array inside a wrapping object, or is empty when the file is the array itself
+func readModFile(path, key string, report *ModReport) []modEntry {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			report.Problems = append(report.Problems, err)
+		}
+		return nil
+	}
+
+	entries, err := splitModFile(content, key)
+	if err != nil {
+		var syntax *json.SyntaxError
+		if errors.As(err, &syntax) {
+			err = fmt.Errorf("%s:%d: %w", path, lineAt(content, int(syntax.Offset)), err)
+		} else {
+			err = fmt.Errorf("%s: %w", path, err)
+		}
+		report.Problems = append(report.Problems, err)
+		return nil
+	}
+	for i := range entries {
+		entries[i].Source = path + ":" + entries[i].Source
+	}
+	return entries
+}
+
+// splitModFile returns the elements of the array in a mod file, their sources hold only the line number
+func splitModFile(content []byte, key string) ([]modEntry, error) {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+
+	if key != "" {
+		if err := expectDelim(decoder, '{'); err != nil {
+			return nil, err
+		}
+		for {
+			token, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			if token == json.Delim('}') {
+				return nil, nil // the file doesn't have the array
+			}
+			if token == key {
+				break
+			}
+			var skip json.RawMessage
+			if err := decoder.Decode(&skip); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := expectDelim(decoder, '['); err != nil {
+		return nil, err
+	}
+	var entries []modEntry
+	for decoder.More() {
+		start := skipSeparators(content, int(decoder.InputOffset()))
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			return nil, err
+		}
+		entries = append(entries, modEntry{Source: strconv.Itoa(lineAt(content, start)), Raw: raw})
+	}
+	return entries, nil
+}
+
+// decodeModEntry decodes a mod entry strictly so misspelt fields are caught
+func decodeModEntry(entry modEntry, v any) error {
+	decoder := json.NewDecoder(bytes.NewReader(entry.Raw))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return fmt.Errorf("%s: %w", entry.Source, err)
+	}
+	return nil
+}
+
+// expectDelim reads the next token and checks it is the given delimiter
+func expectDelim(decoder *json.Decoder, delim json.Delim) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token != delim {
+		return fmt.Errorf("expected %q, found %v", delim, token)
+	}
+	return nil
+}
+
+// skipSeparators moves an offset past the whitespace and comma in front of the next array element
+func skipSeparators(content []byte, offset int) int {
+	for offset < len(content) && bytes.IndexByte([]byte(" \t\r\n,"), content[offset]) >= 0 {
+		offset++
+	}
+	return offset
+}
+
+// lineAt returns the line an offset in the content falls on
+func lineAt(content []byte, offset int) int {
+	return bytes.Count(content[:min(offset, len(content))], []byte("\n")) + 1
+}
+
+// modProblems prefixes validation errors with the file and line they came from
+func modProblems(source string, errs []error) []error {
+	problems := make([]error, len(errs))
+	for i, err := range errs {
+		problems[i] = fmt.Errorf("%s: %w", source, err)
+	}
+	return problems
+}
+
+// validateStarSystem checks a star system from a mod
+func validateStarSystem(system StarSystem) []error {
+	var errs []error
+	if system.Name == "" {
+		return []error{errors.New("star system needs a name")}
+	}
+	seen := make(map[string]bool)
+	for _, planet := range system.Planets {
+		switch {
+		case planet.Name == "":
+			errs = append(errs, fmt.Errorf("star system %s: planet needs a name", system.Name))
+		case seen[planet.Name]:
+			errs = append(errs, fmt.Errorf("star system %s: duplicate planet %s", system.Name, planet.Name))
+		case planet.Type == "":
+			errs = append(errs, fmt.Errorf("star system %s: planet %s needs a type", system.Name, planet.Name))
+		}
+		seen[planet.Name] = true
+	}
+	return errs
+}
+
+// mergeModEvents overrides events by id with the ones from mods and adds the new ones
+func mergeModEvents(events []Event) []Event {
+	for _, event := range modEvents {
+		i := slices.IndexFunc(events, func(e Event) bool { return e.ID == event.ID })
+		if i >= 0 {
+			events[i] = event
+		} else {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// mergeModTemplates overrides mission templates by id with the ones from mods and adds the new ones
+func mergeModTemplates(templates []MissionTemplate) []MissionTemplate {
+	for _, template := range modTemplates {
+		i := slices.IndexFunc(templates, func(t MissionTemplate) bool { return t.Id == template.Id })
+		if i >= 0 {
+			templates[i] = template
+		} else {
+			templates = append(templates, template)
+		}
+	}
+	return templates
+}
+
+// mergeModGalaxy adds the star systems from mods to a galaxy
+// a new game takes the modded systems as they are, a saved galaxy only gains the systems and planets it's missing
+// so the player's progress through it isn't lost
+func mergeModGalaxy(gameMap *GameMap, override bool) {
+	for _, system := range modSystems {
+		i := slices.IndexFunc(gameMap.StarSystems, func(s StarSystem) bool { return s.Name == system.Name })
+		switch {
+		case i < 0:
+			gameMap.StarSystems = append(gameMap.StarSystems, system)
+		case override:
+			gameMap.StarSystems[i] = system
+		default:
+			existing := &gameMap.StarSystems[i]
+			for _, planet := range system.Planets {
+				if !slices.ContainsFunc(existing.Planets, func(p Planet) bool { return p.Name == planet.Name }) {
+					existing.Planets = append(existing.Planets, planet)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestLoadModsDropsFollowUpsOfInvalidEvents(t *testing.T) {
+	dir := t.TempDir()
+	mod := filepath.Join(dir, "chain")
+	if err := os.MkdirAll(mod, 0755); err != nil {
+		t.Fatal(err)
+	}
+	// 9001 leads to 9002, which leads to 9003, which has no choices and fails validation
+	// 9004 stands on its own and should be the only event loaded
+	events := `[
+  {"id": 9001, "title": "First", "dialogue": ["..."], "choices": [{"text": "On", "outcome": "On.", "next": 9002}]},
+  {"id": 9002, "title": "Second", "dialogue": ["..."], "choices": [{"text": "On", "outcome": "On.", "next": 9003}]},
+  {"id": 9003, "title": "Broken", "dialogue": ["..."], "choices": []},
+  {"id": 9004, "title": "Alone", "dialogue": ["..."], "choices": [{"text": "Leave", "outcome": "Left."}]}
+]`
+	if err := os.WriteFile(filepath.Join(mod, ModEventsFile), []byte(events), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { LoadMods(t.TempDir(), nil) })
+
+	report := LoadMods(dir, nil)
+	var loaded []int
+	for _, event := range modEvents {
+		loaded = append(loaded, event.ID)
+	}
+	if !slices.Equal(loaded, []int{9004}) {
+		t.Errorf("loaded mod events %v, want only 9004", loaded)
+	}
+	if len(report.Problems) != 3 {
+		t.Errorf("report has %d problems, want one for each dropped event: %v", len(report.Problems), report.Problems)
+	}
+}
@@ -0,0 +1,252 @@
+package data
+
+import "fmt"
+
+type ObjectiveType string
+
+const (
+	ObjectiveTravel    ObjectiveType = "travel"    // reach a location
+	ObjectiveDeliver   ObjectiveType = "deliver"   // bring cargo to a location, the cargo is handed over
+	ObjectiveReturn    ObjectiveType = "return"    // report back to whoever gave the mission
+	ObjectiveScan      ObjectiveType = "scan"      // scan a location with a crew member of the right role
+	ObjectiveCombat    ObjectiveType = "combat"    // defeat an enemy ship
+	ObjectiveProtect   ObjectiveType = "protect"   // keep a crew member of a role alive until the mission ends
+	ObjectiveScript    ObjectiveType = "script"    // met once a script returns True
+	ObjectiveNegotiate ObjectiveType = "negotiate" // hold talks with a species, the outcome settles the quest branch
+)
+
+// Valid reports whether the objective type is one the game knows how to check
+func (t ObjectiveType) Valid() bool {
+	switch t {
+	case ObjectiveTravel, ObjectiveDeliver, ObjectiveReturn, ObjectiveScan, ObjectiveCombat, ObjectiveProtect, ObjectiveScript, ObjectiveNegotiate:
+		return true
+	}
+	return false
+}
+
+// Objective is one step of a mission, missions complete once every objective is met
+// objectives are worked through in order, except protect objectives which hold for the whole mission
+type Objective struct {
+	Type        ObjectiveType `json:"type"`
+	Description string        `json:"description,omitempty"` // overrides the generated description
+	Location    Location      `json:"location"`              // defaults to the mission location when empty
+	Item        string        `json:"item,omitempty"`
+	Quantity    int           `json:"quantity,omitempty"`
+	Enemy       string        `json:"enemy,omitempty"`
+	Species     string        `json:"species,omitempty"` // species negotiate objectives hold talks with
+	Role        CrewRole      `json:"role,omitempty"`
+	Script      string        `json:"script,omitempty"` // check run by script objectives, e.g. "missions.star:senior_scientist"
+	Done        bool          `json:"done,omitempty"`
+}
+
+// ObjectiveResult reports what happened when a mission's objectives were checked
+type ObjectiveResult struct {
+	Progressed bool   // at least one objective was met
+	Failed     bool   // a protect objective was broken
+	Reason     string // why the mission failed
+	Err        error  // a script objective's check failed to run
+}
+
+// Target returns the location the objective takes place at
+func (o Objective) Target(m Mission) Location {
+	if o.Location.PlanetName != "" {
+		return o.Location
+	}
+	return m.Location
+}
+
+// Describe describes the objective for the checklist
+func (o Objective) Describe(m Mission) string {
+	if o.Description != "" {
+		return o.Description
+	}
+	target := o.Target(m).PlanetName
+	switch o.Type {
+	case ObjectiveTravel:
+		return fmt.Sprintf("Travel to %s", target)
+	case ObjectiveDeliver:
+		return fmt.Sprintf("Deliver %dx %s to %s", max(o.Quantity, 1), o.Item, target)
+	case ObjectiveReturn:
+		return fmt.Sprintf("Return to %s", target)
+	case ObjectiveScan:
+		return fmt.Sprintf("Scan %s with a %s", target, o.scanRole())
+	case ObjectiveCombat:
+		name := o.Enemy
+		if enemy := FindEnemyShip(o.Enemy); enemy != nil {
+			name = enemy.Name
+		}
+		return fmt.Sprintf("Defeat the %s", name)
+	case ObjectiveProtect:
+		return fmt.Sprintf("Keep your %s alive", o.Role)
+	case ObjectiveNegotiate:
+		name := o.Species
+		if species := FindSpecies(o.Species); species != nil {
+			name = species.Name
+		}
+		return fmt.Sprintf("Negotiate with the %s", name)
+	}
+	return string(o.Type)
+}
+
+// scanRole returns the crew role needed to carry out a scan
+func (o Objective) scanRole() CrewRole {
+	if o.Role != "" {
+		return o.Role
+	}
+	return CrewRoleScientist
+}
+
+// MissionObjectives returns the mission's objectives
+// missions without any get the implicit ones, defeat the mission's enemy and reach its location
+func MissionObjectives(m Mission) []Objective {
+	if len(m.Objectives) > 0 {
+		return m.Objectives
+	}
+	var objectives []Objective
+	if m.Enemy != "" {
+		objectives = append(objectives, Objective{Type: ObjectiveCombat, Enemy: m.Enemy})
+	}
+	return append(objectives, Objective{Type: ObjectiveTravel})
+}
+
+// ensureObjectives gives a mission its implicit objectives so their progress can be stored
+func ensureObjectives(m *Mission) {
+	if len(m.Objectives) == 0 {
+		m.Objectives = MissionObjectives(*m)
+	}
+}
+
+// CurrentObjective returns the objective the mission is waiting on, or nil if only protect objectives remain
+func CurrentObjective(m *Mission) *Objective {
+	ensureObjectives(m)
+	for i := range m.Objectives {
+		objective := &m.Objectives[i]
+		if !objective.Done && objective.Type != ObjectiveProtect {
+			return objective
+		}
+	}
+	return nil
+}
+
+// Destination returns where the ship has to go next to make progress on the mission
+func (m Mission) Destination() Location {
+	if m.Status == MissionStatusInProgress {
+		if objective := CurrentObjective(&m); objective != nil {
+			return objective.Target(m)
+		}
+	}
+	return m.Location
+}
+
+// ObjectivesComplete reports whether every objective of the mission has been met
+func ObjectivesComplete(m Mission) bool {
+	for _, objective := range MissionObjectives(m) {
+		if !objective.Done && objective.Type != ObjectiveProtect {
+			return false
+		}
+	}
+	return true
+}
+
+// UpdateObjectives checks the mission's objectives against the game state and marks the ones that are met
+// delivered cargo is taken out of the hold
+func UpdateObjectives(m *Mission, save *FullGameSave) ObjectiveResult {
+	var result ObjectiveResult
+	ensureObjectives(m)
+
+	for _, objective := range m.Objectives {
+		if objective.Type == ObjectiveProtect && !crewRoleAlive(save.Crew, objective.Role) {
+			result.Failed = true
+			result.Reason = fmt.Sprintf("Your %s didn't make it", objective.Role)
+			return result
+		}
+	}
+
+	for {
+		objective := CurrentObjective(m)
+		if objective == nil {
+			return result
+		}
+		met, err := objectiveMet(*objective, *m, save)
+		if err != nil {
+			result.Err = err
+		}
+		if !met {
+			return result
+		}
+		if objective.Type == ObjectiveDeliver {
+			RemoveCargoItem(&save.Ship.Cargo, objective.Item, max(objective.Quantity, 1))
+		}
+		objective.Done = true
+		result.Progressed = true
+	}
+}
+
+// objectiveMet reports whether the game state satisfies an objective
+// combat objectives are only ever met by RecordVictory, negotiate objectives by RecordNegotiation
+func objectiveMet(o Objective, m Mission, save *FullGameSave) (bool, error) {
+	atTarget := save.Ship.Location.IsEqual(o.Target(m))
+	switch o.Type {
+	case ObjectiveTravel, ObjectiveReturn:
+		return atTarget, nil
+	case ObjectiveDeliver:
+		return atTarget && CargoQuantity(save.Ship.Cargo, o.Item) >= max(o.Quantity, 1), nil
+	case ObjectiveScan:
+		return atTarget && crewRoleAlive(save.Crew, o.scanRole()), nil
+	case ObjectiveScript:
+		return RunObjectiveScript(o.Script, m, save)
+	}
+	return false, nil
+}
+
+// RecordVictory marks the mission's combat objectives against the defeated enemy as met
+func RecordVictory(m *Mission, enemyID string) bool {
+	ensureObjectives(m)
+	recorded := false
+	for i := range m.Objectives {
+		objective := &m.Objectives[i]
+		if objective.Type == ObjectiveCombat && objective.Enemy == enemyID && !objective.Done {
+			objective.Done = true
+			recorded = true
+		}
+	}
+	return recorded
+}
+
+// RecordNegotiation marks the mission's negotiate objectives with the species as met
+// and settles the quest branch on the outcome of the talks
+func RecordNegotiation(m *Mission, species string, outcome NegotiationOutcome) bool {
+	ensureObjectives(m)
+	recorded := false
+	for i := range m.Objectives {
+		objective := &m.Objectives[i]
+		if objective.Type == ObjectiveNegotiate && objective.Species == species && !objective.Done {
+			objective.Done = true
+			recorded = true
+		}
+	}
+	if recorded {
+		m.Branch = string(outcome)
+	}
+	return recorded
+}
+
+// NegotiatesWith returns the species the mission's talks are held with, empty if it has no negotiate objective
+func NegotiatesWith(m Mission) string {
+	for _, objective := range m.Objectives {
+		if objective.Type == ObjectiveNegotiate {
+			return objective.Species
+		}
+	}
+	return ""
+}
+
+// crewRoleAlive reports whether a living crew member has the role
+func crewRoleAlive(crew []CrewMember, role CrewRole) bool {
+	for _, member := range crew {
+		if member.Role == role && member.Health > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,124 @@
+package data
+
+import "testing"
+
+// solPlanet returns the location of a planet in the Sol system
+func solPlanet(planet string) Location {
+	return Location{StarSystemName: "Sol", PlanetName: planet}
+}
+
+// countDone returns how many of the mission's objectives are met
+func countDone(m Mission) int {
+	done := 0
+	for _, objective := range m.Objectives {
+		if objective.Done {
+			done++
+		}
+	}
+	return done
+}
+
+func TestUpdateObjectivesInOrder(t *testing.T) {
+	mission := Mission{
+		Location: solPlanet("Home"),
+		Status:   MissionStatusInProgress,
+		Objectives: []Objective{
+			{Type: ObjectiveProtect, Role: CrewRolePilot},
+			{Type: ObjectiveTravel, Location: solPlanet("Relay")},
+			{Type: ObjectiveDeliver, Location: solPlanet("Depot"), Item: "Ore", Quantity: 2},
+			{Type: ObjectiveScan, Location: solPlanet("Depot")},
+			{Type: ObjectiveCombat, Enemy: "raider"},
+			{Type: ObjectiveReturn},
+		},
+	}
+	save := &FullGameSave{
+		Ship: Ship{Cargo: Cargo{Capacity: 10, Items: []CargoItem{{Name: "Ore", Quantity: 3}}}},
+		Crew: []CrewMember{{Role: CrewRolePilot, Health: 100}},
+	}
+
+	// each step moves the ship, and the objectives met there are ticked off in order
+	steps := []struct {
+		name     string
+		at       string
+		before   func()
+		wantDone int
+	}{
+		{name: "the return waits its turn", at: "Home", wantDone: 0},
+		{name: "reaching the relay", at: "Relay", wantDone: 1},
+		{name: "the depot can't be scanned without a scientist", at: "Depot", wantDone: 2},
+		{name: "a scientist joins", at: "Depot", before: func() {
+			save.Crew = append(save.Crew, CrewMember{Role: CrewRoleScientist, Health: 100})
+		}, wantDone: 3},
+		{name: "the raider is still out there", at: "Home", wantDone: 3},
+		{name: "back home after the fight", at: "Home", before: func() { RecordVictory(&mission, "raider") }, wantDone: 5},
+	}
+	for _, step := range steps {
+		if step.before != nil {
+			step.before()
+		}
+		save.Ship.Location = solPlanet(step.at)
+		result := UpdateObjectives(&mission, save)
+		if result.Failed || result.Err != nil {
+			t.Fatalf("%s: result %+v, want no failure", step.name, result)
+		}
+		if got := countDone(mission); got != step.wantDone {
+			t.Fatalf("%s: %d objectives done, want %d", step.name, got, step.wantDone)
+		}
+	}
+
+	if !ObjectivesComplete(mission) {
+		t.Error("ObjectivesComplete() = false with only the protect objective left")
+	}
+	if got := CargoQuantity(save.Ship.Cargo, "Ore"); got != 1 {
+		t.Errorf("%d Ore left in the hold, want the delivered 2 handed over", got)
+	}
+}
+
+func TestUpdateObjectivesProtect(t *testing.T) {
+	tests := []struct {
+		name       string
+		crew       []CrewMember
+		wantFailed bool
+	}{
+		{name: "alive", crew: []CrewMember{{Role: CrewRoleMedic, Health: 10}}},
+		{name: "one of two lost", crew: []CrewMember{{Role: CrewRoleMedic}, {Role: CrewRoleMedic, Health: 10}}},
+		{name: "dead", crew: []CrewMember{{Role: CrewRoleMedic}}, wantFailed: true},
+		{name: "never aboard", crew: []CrewMember{{Role: CrewRolePilot, Health: 100}}, wantFailed: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the ship is already at the destination, so only a broken protect objective stops the mission
+			mission := Mission{Location: solPlanet("Home"), Objectives: []Objective{
+				{Type: ObjectiveProtect, Role: CrewRoleMedic},
+				{Type: ObjectiveTravel},
+			}}
+			save := &FullGameSave{Ship: Ship{Location: solPlanet("Home")}, Crew: tt.crew}
+
+			result := UpdateObjectives(&mission, save)
+			if result.Failed != tt.wantFailed {
+				t.Fatalf("Failed = %v, want %v", result.Failed, tt.wantFailed)
+			}
+			if tt.wantFailed && (result.Reason == "" || result.Progressed || mission.Objectives[1].Done) {
+				t.Errorf("result %+v, want a reason and no progress", result)
+			}
+			if !tt.wantFailed && !ObjectivesComplete(mission) {
+				t.Errorf("mission not complete with the %s safe at the destination", CrewRoleMedic)
+			}
+		})
+	}
+}
+
+func TestImplicitObjectives(t *testing.T) {
+	mission := Mission{Location: solPlanet("Home"), Enemy: "raider"}
+	save := &FullGameSave{Ship: Ship{Location: solPlanet("Home")}}
+
+	if result := UpdateObjectives(&mission, save); result.Progressed {
+		t.Fatal("reached the destination before the mission's enemy was defeated")
+	}
+	if !RecordVictory(&mission, "raider") || RecordVictory(&mission, "raider") {
+		t.Fatal("RecordVictory() should record the win once")
+	}
+	if result := UpdateObjectives(&mission, save); !result.Progressed || !ObjectivesComplete(mission) {
+		t.Errorf("result %+v, want the mission complete once the enemy is beaten", result)
+	}
+}
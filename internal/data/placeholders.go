@@ -0,0 +1,203 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"regexp"
+	"slices"
+
+	_ "embed"
+)
+
+//go:embed wordlists.json
+var embeddedWordLists []byte
+
+// WordLists are the words a placeholder is drawn from when the situation gives it no value, keyed by placeholder
+var WordLists map[string][]string
+
+// placeholderPattern matches a placeholder such as {planet}
+var placeholderPattern = regexp.MustCompile(`\{([a-z]+)\}`)
+
+// placeholders filled from the situation the text is shown in, on top of the word lists
+var (
+	eventPlaceholders   = []string{"planet", "system", "crew"}
+	missionPlaceholders = []string{"planet", "system", "giver", "reward", "station", "crew"}
+)
+
+// LoadWordLists loads the placeholder word lists from the embedded wordlists.json
+func LoadWordLists() error {
+	var lists map[string][]string
+	if err := json.NewDecoder(bytes.NewReader(embeddedWordLists)).Decode(&lists); err != nil {
+		return err
+	}
+	var errs []error
+	for name, words := range lists {
+		if len(words) == 0 {
+			errs = append(errs, fmt.Errorf("word list %q is empty", name))
+		}
+		if !placeholderPattern.MatchString("{" + name + "}") {
+			errs = append(errs, fmt.Errorf("word list %q: names are lowercase letters only", name))
+		}
+	}
+	WordLists = lists
+	return errors.Join(errs...)
+}
+
+// wordList returns the words for a placeholder, the lists are loaded on first use
+func wordList(name string) []string {
+	if WordLists == nil {
+		if err := LoadWordLists(); err != nil {
+			return nil
+		}
+	}
+	return WordLists[name]
+}
+
+// TextVars are the values placeholders are filled with
+// a placeholder without a value is drawn from its word list and keeps that word for every later line
+type TextVars map[string]string
+
+// Fill replaces the placeholders in a text, any that can't be resolved are left as they are
+func (v TextVars) Fill(text string) string {
+	return placeholderPattern.ReplaceAllStringFunc(text, func(match string) string {
+		name := match[1 : len(match)-1]
+		if value, ok := v[name]; ok {
+			return value
+		}
+		if words := wordList(name); len(words) > 0 {
+			v[name] = words[rand.Intn(len(words))]
+			return v[name]
+		}
+		return match
+	})
+}
+
+// fillAll replaces the placeholders in each of the lines
+func (v TextVars) fillAll(lines []string) []string {
+	filled := make([]string, len(lines))
+	for i, line := range lines {
+		filled[i] = v.Fill(line)
+	}
+	return filled
+}
+
+// fillConversation returns a copy of a conversation with the placeholders of every line and reply replaced
+func (v TextVars) fillConversation(tree *DialogueTree) *DialogueTree {
+	if tree == nil {
+		return nil
+	}
+	filled := DialogueTree{Start: tree.Start, Nodes: slices.Clone(tree.Nodes)}
+	for i := range filled.Nodes {
+		node := &filled.Nodes[i]
+		node.Speaker = v.Fill(node.Speaker)
+		node.Text = v.Fill(node.Text)
+		node.Choices = slices.Clone(node.Choices)
+		for j := range node.Choices {
+			node.Choices[j].Text = v.Fill(node.Choices[j].Text)
+		}
+	}
+	return &filled
+}
+
+// validatePlaceholders reports the placeholders in the texts that neither the situation nor a word list can fill
+func validatePlaceholders(texts []string, known []string) error {
+	var errs []error
+	for _, text := range texts {
+		for _, match := range placeholderPattern.FindAllStringSubmatch(text, -1) {
+			if name := match[1]; !slices.Contains(known, name) && len(wordList(name)) == 0 {
+				errs = append(errs, fmt.Errorf("placeholder {%s} can't be resolved", name))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// conversationTexts returns every line and reply of a conversation
+func conversationTexts(tree *DialogueTree) []string {
+	if tree == nil {
+		return nil
+	}
+	var texts []string
+	for _, node := range tree.Nodes {
+		texts = append(texts, node.Speaker, node.Text)
+		for _, choice := range node.Choices {
+			texts = append(texts, choice.Text)
+		}
+	}
+	return texts
+}
+
+// locationVars returns the placeholders a location fills, along with a crew member picked from those still alive
+func locationVars(save *FullGameSave, loc Location) TextVars {
+	vars := TextVars{
+		"planet": loc.PlanetName,
+		"system": loc.StarSystemName,
+	}
+	var alive []string
+	for _, member := range save.Crew {
+		if member.Health > 0 {
+			alive = append(alive, member.Name)
+		}
+	}
+	if len(alive) > 0 {
+		vars["crew"] = alive[rand.Intn(len(alive))]
+	}
+	return vars
+}
+
+// RenderEvent returns a copy of an event with its placeholders filled in for where the ship is
+func RenderEvent(save *FullGameSave, event Event) Event {
+	vars := locationVars(save, save.Ship.Location)
+	event.Title = vars.Fill(event.Title)
+	event.Description = vars.Fill(event.Description)
+	event.Speaker = vars.Fill(event.Speaker)
+	event.Dialogue = vars.fillAll(event.Dialogue)
+	event.Choices = slices.Clone(event.Choices)
+	for i := range event.Choices {
+		choice := &event.Choices[i]
+		choice.Text = vars.Fill(choice.Text)
+		choice.Outcome = vars.Fill(choice.Outcome)
+		choice.Outcomes = slices.Clone(choice.Outcomes)
+		for j := range choice.Outcomes {
+			choice.Outcomes[j].Text = vars.Fill(choice.Outcomes[j].Text)
+		}
+		if choice.SkillCheck != nil {
+			check := *choice.SkillCheck
+			check.Failure.Text = vars.Fill(check.Failure.Text)
+			choice.SkillCheck = &check
+		}
+	}
+	return event
+}
+
+// eventTexts returns every text of an event a placeholder may appear in
+func eventTexts(event Event) []string {
+	texts := slices.Concat([]string{event.Title, event.Description, event.Speaker}, event.Dialogue)
+	for _, choice := range event.Choices {
+		texts = append(texts, choice.Text, choice.Outcome)
+		for _, outcome := range choice.Outcomes {
+			texts = append(texts, outcome.Text)
+		}
+		if choice.SkillCheck != nil {
+			texts = append(texts, choice.SkillCheck.Failure.Text)
+		}
+	}
+	return texts
+}
+
+// renderMission fills the placeholders of a mission generated from a template
+func (v TextVars) renderMission(m *Mission) {
+	m.Title = v.Fill(m.Title)
+	m.Description = v.Fill(m.Description)
+	m.Received = v.Fill(m.Received)
+	m.Dialogue = v.fillAll(m.Dialogue)
+	m.Conversation = v.fillConversation(m.Conversation)
+}
+
+// missionTemplateTexts returns every text of a mission template a placeholder may appear in
+func missionTemplateTexts(t MissionTemplate) []string {
+	return slices.Concat([]string{t.Title, t.Description, t.Received}, t.Givers, t.Dialogue, conversationTexts(t.Conversation))
+}
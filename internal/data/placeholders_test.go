@@ -0,0 +1,47 @@
+package data
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidatePlaceholders(t *testing.T) {
+	WordLists = map[string][]string{"giver": {"Dock Master"}, "cargo": {"ore", "grain"}}
+	t.Cleanup(func() { WordLists = nil })
+	known := []string{"planet", "system"}
+
+	tests := []struct {
+		name      string
+		texts     []string
+		wantNames []string // placeholders reported as unresolvable
+	}{
+		{name: "no placeholders", texts: []string{"Plain text.", ""}},
+		{name: "known to the situation", texts: []string{"Head to {planet} in {system}."}},
+		{name: "from a word list", texts: []string{"{giver} needs {cargo} moved."}},
+		{name: "unknown", texts: []string{"Meet {contact} at {planet}."}, wantNames: []string{"contact"}},
+		{name: "unknown in several texts", texts: []string{"{contact}", "fine", "{reward} and {cargo}"}, wantNames: []string{"contact", "reward"}},
+		{name: "not a placeholder", texts: []string{"{Planet}, {planet2} and { planet } are left alone, as is {}"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePlaceholders(tt.texts, known)
+			if len(tt.wantNames) == 0 {
+				if err != nil {
+					t.Fatalf("validatePlaceholders() error = %v, want none", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("validatePlaceholders() returned no error, want %v reported", tt.wantNames)
+			}
+			if got := len(strings.Split(err.Error(), "\n")); got != len(tt.wantNames) {
+				t.Errorf("validatePlaceholders() reported %d problems, want %d: %v", got, len(tt.wantNames), err)
+			}
+			for _, name := range tt.wantNames {
+				if !strings.Contains(err.Error(), "{"+name+"}") {
+					t.Errorf("validatePlaceholders() error = %v, want {%s} reported", err, name)
+				}
+			}
+		})
+	}
+}
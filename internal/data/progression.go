@@ -0,0 +1,168 @@
+package data
+
+import "fmt"
+
+// experience awarded for each kind of activity
+const (
+	XPEventResolved = 15
+	XPDiscovery     = 25
+	XPCombatVictory = 40
+	XPCombatTruce   = 20
+	xpMissionBase   = 50
+	xpMissionPerCr  = 20 // one extra XP for every this many credits a mission pays
+	xpTradePerCr    = 50 // one XP for every this many credits spent at a station, small purchases earn none
+
+	BaseCrewCapacity = 6 // crew members the ship can carry without the Extra Berth perk
+	baseFuelPrice    = 5 // credits per unit of fuel at a station
+)
+
+// XPForLevel returns the total experience needed to reach the given level
+// each level costs 100 XP more than the one before it
+func XPForLevel(level int) int {
+	if level <= 1 {
+		return 0
+	}
+	return 50 * level * (level - 1)
+}
+
+// MissionXP returns the experience for completing a mission, bigger jobs are worth more
+func MissionXP(mission Mission) int {
+	return xpMissionBase + mission.Income/xpMissionPerCr
+}
+
+// TradeXP returns the experience for spending credits at a station
+// it only grows with what is spent so splitting a purchase up earns nothing extra
+func TradeXP(credits int) int {
+	return credits / xpTradePerCr
+}
+
+// AwardExperience adds experience to the player, levelling them up as thresholds are passed
+// every level gained grants a perk pick, it returns the number of levels gained
+func AwardExperience(player *Player, xp int) int {
+	if xp <= 0 {
+		return 0
+	}
+	if player.Level < 1 {
+		player.Level = 1
+	}
+	player.ExperiencePoints += xp
+
+	gained := 0
+	for player.ExperiencePoints >= XPForLevel(player.Level+1) {
+		player.Level++
+		player.PerkPicks++
+		gained++
+	}
+	return gained
+}
+
+// ---------------------
+// Perks
+// ---------------------
+
+// Perk is a bonus the player can pick on levelling up, some can be picked more than once
+type Perk struct {
+	ID          string
+	Name        string
+	Description string
+	MaxRank     int
+}
+
+const (
+	PerkFrugalFueler = "frugal_fueler"
+	PerkExtraBerth   = "extra_berth"
+	PerkNegotiator   = "negotiator"
+	PerkInsight      = "insight"
+)
+
+// AllPerks lists the perks in the order they are offered
+var AllPerks = []Perk{
+	{ID: PerkFrugalFueler, Name: "Frugal Fueler", Description: "Fuel costs 1¢ less per unit at stations.", MaxRank: 3},
+	{ID: PerkExtraBerth, Name: "Extra Berth", Description: "Room for one more crew member.", MaxRank: 3},
+	{ID: PerkNegotiator, Name: "Negotiator", Description: "Completed missions pay out 10% more.", MaxRank: 3},
+	{ID: PerkInsight, Name: "Captain's Insight", Description: "See the effects of event choices before you make them.", MaxRank: 1},
+}
+
+// FindPerk returns the perk with the given id, or nil if none exists
+func FindPerk(id string) *Perk {
+	for i := range AllPerks {
+		if AllPerks[i].ID == id {
+			return &AllPerks[i]
+		}
+	}
+	return nil
+}
+
+// PerkRank returns how many times the player has picked a perk
+func PerkRank(player Player, id string) int {
+	rank := 0
+	for _, perk := range player.Perks {
+		if perk == id {
+			rank++
+		}
+	}
+	return rank
+}
+
+// ChoosePerk spends one of the player's perk picks on the given perk
+func ChoosePerk(player *Player, id string) error {
+	perk := FindPerk(id)
+	if perk == nil {
+		return fmt.Errorf("unknown perk %q", id)
+	}
+	if player.PerkPicks <= 0 {
+		return fmt.Errorf("no perk picks available")
+	}
+	if PerkRank(*player, id) >= perk.MaxRank {
+		return fmt.Errorf("%s is already at its highest rank", perk.Name)
+	}
+	player.Perks = append(player.Perks, id)
+	player.PerkPicks--
+	return nil
+}
+
+// FuelPrice returns what the player pays per unit of fuel before station modifiers
+func FuelPrice(player Player) int {
+	return max(baseFuelPrice-PerkRank(player, PerkFrugalFueler), 1)
+}
+
+// CrewCapacity returns how many crew members the player's ship can carry
+func CrewCapacity(player Player) int {
+	return BaseCrewCapacity + PerkRank(player, PerkExtraBerth)
+}
+
+// BerthsTaken returns how many berths the crew fill, the dead don't keep theirs
+func BerthsTaken(crew []CrewMember) int {
+	taken := 0
+	for _, member := range crew {
+		if member.Health > 0 {
+			taken++
+		}
+	}
+	return taken
+}
+
+// MissionPayoutModifier returns the percentage of a mission's income the player receives
+func MissionPayoutModifier(player Player) int {
+	return 100 + 10*PerkRank(player, PerkNegotiator)
+}
+
+// DiscoverLocation marks a planet as visited, and charts it and its system, and reports whether this was the first visit
+func DiscoverLocation(gameMap *GameMap, loc Location) bool {
+	for i := range gameMap.StarSystems {
+		system := &gameMap.StarSystems[i]
+		if system.Name != loc.StarSystemName {
+			continue
+		}
+		system.Scanned = true
+		for j := range system.Planets {
+			planet := &system.Planets[j]
+			if planet.Name == loc.PlanetName && !planet.Visited {
+				planet.Visited = true
+				planet.Scanned = true
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,28 @@
+package data
+
+import "testing"
+
+func TestBerthsLeft(t *testing.T) {
+	alive := CrewMember{Health: 60}
+	dead := CrewMember{Health: 0}
+
+	tests := []struct {
+		name  string
+		perks []string
+		crew  []CrewMember
+		want  int // berths still free
+	}{
+		{name: "empty ship", want: BaseCrewCapacity},
+		{name: "living crew fill berths", crew: []CrewMember{alive, alive}, want: BaseCrewCapacity - 2},
+		{name: "the dead free theirs", crew: []CrewMember{alive, dead, dead}, want: BaseCrewCapacity - 1},
+		{name: "extra berths", perks: []string{PerkExtraBerth, PerkExtraBerth}, crew: []CrewMember{alive}, want: BaseCrewCapacity + 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			player := Player{Perks: tt.perks}
+			if got := CrewCapacity(player) - BerthsTaken(tt.crew); got != tt.want {
+				t.Errorf("berths left = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
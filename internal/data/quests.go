@@ -0,0 +1,536 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	_ "embed"
+)
+
+//go:embed quests.json
+var embeddedQuests []byte
+
+// OpeningQuestID is the first quest of the Main story, given to every new game
+const OpeningQuestID = "main.rescue"
+
+// Quest is one node of a quest graph, it becomes a Mission in the journal once its prerequisites are met
+type Quest struct {
+	ID            string           `json:"id"`
+	Line          string           `json:"line"` // quest line the quest belongs to, shown as the mission category
+	Title         string           `json:"title"`
+	Description   string           `json:"description"`
+	Location      Location         `json:"location"`
+	Income        int              `json:"income"`
+	Requirements  string           `json:"requirements"`
+	Received      string           `json:"received"`
+	Faction       string           `json:"faction,omitempty"`
+	Enemy         string           `json:"enemy,omitempty"`
+	Dialogue      []string         `json:"dialogue"`
+	Conversation  *DialogueTree    `json:"conversation,omitempty"` // briefing with speakers and choices, replaces Dialogue when set
+	Objectives    []Objective      `json:"objectives,omitempty"`
+	TimeLimit     int              `json:"timeLimit,omitempty"`     // hours on the game clock to finish the quest once offered
+	Prerequisites []QuestCondition `json:"prerequisites,omitempty"` // all must hold before the quest is offered
+	Excludes      []string         `json:"excludes,omitempty"`      // quests that lock this one out once completed
+	FailIf        []QuestCondition `json:"failIf,omitempty"`        // the quest fails as soon as any of these holds
+	Branches      []QuestBranch    `json:"branches,omitempty"`      // choices offered at the end of the dialogue
+	Rewards       []QuestEffect    `json:"rewards,omitempty"`
+	Next          string           `json:"next,omitempty"`   // quest that follows when there are no branches
+	OnFail        string           `json:"onFail,omitempty"` // quest that follows a failure
+}
+
+// QuestBranch is a dialogue choice that decides where the quest line goes next
+type QuestBranch struct {
+	ID      string        `json:"id"`
+	Label   string        `json:"label"`
+	Next    string        `json:"next,omitempty"`
+	Rewards []QuestEffect `json:"rewards,omitempty"`
+}
+
+// quest condition types
+const (
+	ConditionQuestCompleted = "questCompleted"
+	ConditionQuestFailed    = "questFailed"
+	ConditionReputation     = "reputation"
+	ConditionItem           = "item"
+	ConditionCrewRole       = "crewRole"
+	ConditionLocationType   = "locationType" // the type of planet the ship is at, e.g. "Gas Giant"
+	ConditionSystem         = "system"       // the star system the ship is in
+	ConditionShipStat       = "shipStat"     // fuel, hull, shields, food or engine between Min and Max
+	ConditionContact        = "contact"      // the crew has met the species in Value, and their last talks ended in Outcome if set
+)
+
+// QuestCondition is a single check against the game state, which fields are used depends on Type
+// random events share the same conditions as their preconditions
+type QuestCondition struct {
+	Type     string `json:"type"`
+	Quest    string `json:"quest,omitempty"`
+	Faction  string `json:"faction,omitempty"`
+	Min      *int   `json:"min,omitempty"`
+	Max      *int   `json:"max,omitempty"`
+	Item     string `json:"item,omitempty"`
+	Quantity int    `json:"quantity,omitempty"`
+	Role     string `json:"role,omitempty"`
+	Degree   int    `json:"degree,omitempty"`
+	Value    string `json:"value,omitempty"` // location type or star system name
+	Stat     string `json:"stat,omitempty"`
+	Outcome  string `json:"outcome,omitempty"` // outcome of the last talks with a species
+}
+
+// quest effect types
+const (
+	EffectCredits    = "credits"
+	EffectReputation = "reputation"
+	EffectItem       = "item"
+	EffectExperience = "experience"
+	EffectFuel       = "fuel"
+)
+
+// QuestEffect is a typed reward applied when a quest or branch completes
+type QuestEffect struct {
+	Type    string `json:"type"`
+	Faction string `json:"faction,omitempty"`
+	Item    string `json:"item,omitempty"`
+	Amount  int    `json:"amount"`
+}
+
+// QuestLog tracks the player's progress through the quest graph
+type QuestLog struct {
+	Pending   []string `json:"pending,omitempty"` // unlocked quests still waiting on their prerequisites
+	Completed []string `json:"completed,omitempty"`
+	Failed    []string `json:"failed,omitempty"`
+}
+
+// QuestUpdate reports what changed after the quest graph was advanced
+type QuestUpdate struct {
+	Offered []Mission // missions added to the journal
+	Failed  []string  // titles of quests that failed
+}
+
+var Quests []Quest
+
+// LoadQuests loads the quest graph from the embedded quests.json
+func LoadQuests() error {
+	var quests []Quest
+	if err := json.NewDecoder(bytes.NewReader(embeddedQuests)).Decode(&quests); err != nil {
+		return err
+	}
+	for _, quest := range quests {
+		if quest.Conversation == nil {
+			continue
+		}
+		if err := quest.Conversation.Validate(); err != nil {
+			return fmt.Errorf("quest %s: %w", quest.ID, err)
+		}
+	}
+	Quests = quests
+	return nil
+}
+
+// FindQuest returns the quest with the given id, or nil if none exists
+// the quest graph is loaded on first use
+func FindQuest(id string) *Quest {
+	if len(Quests) == 0 {
+		if err := LoadQuests(); err != nil {
+			return nil
+		}
+	}
+	for i := range Quests {
+		if Quests[i].ID == id {
+			return &Quests[i]
+		}
+	}
+	return nil
+}
+
+// Mission builds the journal entry for a quest
+func (q Quest) Mission() Mission {
+	return Mission{
+		QuestID:      q.ID,
+		Title:        q.Title,
+		Description:  q.Description,
+		Status:       MissionStatusNotStarted,
+		Location:     q.Location,
+		Income:       q.Income,
+		Requirements: q.Requirements,
+		Received:     q.Received,
+		Category:     q.Line,
+		Dialogue:     q.Dialogue,
+		Conversation: q.Conversation,
+		Enemy:        q.Enemy,
+		Faction:      q.Faction,
+		Objectives:   slices.Clone(q.Objectives),
+		TimeLimit:    q.TimeLimit,
+	}
+}
+
+// FindBranch returns the branch with the given id, or nil if the quest has none by that id
+func (q Quest) FindBranch(id string) *QuestBranch {
+	for i := range q.Branches {
+		if q.Branches[i].ID == id {
+			return &q.Branches[i]
+		}
+	}
+	return nil
+}
+
+// BranchLabels returns the dialogue choices for the quest's branches
+func (q Quest) BranchLabels() []string {
+	var labels []string
+	for _, branch := range q.Branches {
+		labels = append(labels, branch.Label)
+	}
+	return labels
+}
+
+// Met reports whether the condition holds for the save
+func (c QuestCondition) Met(save *FullGameSave) bool {
+	switch c.Type {
+	case ConditionQuestCompleted:
+		return slices.Contains(save.Quests.Completed, c.Quest)
+	case ConditionQuestFailed:
+		return slices.Contains(save.Quests.Failed, c.Quest)
+	case ConditionReputation:
+		return MeetsStanding(save.Player.Reputation, c.Faction, c.Min, c.Max)
+	case ConditionItem:
+		return CargoQuantity(save.Ship.Cargo, c.Item) >= max(c.Quantity, 1)
+	case ConditionCrewRole:
+		for _, member := range save.Crew {
+			if string(member.Role) == c.Role && member.Health > 0 && member.Degree >= c.Degree {
+				return true
+			}
+		}
+		return false
+	case ConditionLocationType:
+		return save.Ship.Location.GetFullPlanet(save.GameMap).Type == c.Value
+	case ConditionSystem:
+		return save.Ship.Location.StarSystemName == c.Value
+	case ConditionShipStat:
+		value, ok := shipStat(save.Ship, c.Stat)
+		return ok && (c.Min == nil || value >= *c.Min) && (c.Max == nil || value <= *c.Max)
+	case ConditionContact:
+		contact := FindContact(save, c.Value)
+		return contact != nil && (c.Outcome == "" || string(contact.Outcome) == c.Outcome)
+	}
+	return false
+}
+
+// Validate reports a condition with an unknown type or missing the fields its type needs
+func (c QuestCondition) Validate() error {
+	switch c.Type {
+	case ConditionQuestCompleted, ConditionQuestFailed:
+		if c.Quest == "" {
+			return fmt.Errorf("%s condition needs a quest", c.Type)
+		}
+	case ConditionReputation:
+		if c.Faction == "" {
+			return fmt.Errorf("reputation condition needs a faction")
+		}
+	case ConditionItem:
+		if c.Item == "" {
+			return fmt.Errorf("item condition needs an item")
+		}
+	case ConditionCrewRole:
+		if c.Role == "" {
+			return fmt.Errorf("crewRole condition needs a role")
+		}
+	case ConditionLocationType, ConditionSystem, ConditionContact:
+		if c.Value == "" {
+			return fmt.Errorf("%s condition needs a value", c.Type)
+		}
+	case ConditionShipStat:
+		if _, ok := shipStat(Ship{}, c.Stat); !ok {
+			return fmt.Errorf("unknown ship stat %q", c.Stat)
+		}
+	default:
+		return fmt.Errorf("unknown condition type %q", c.Type)
+	}
+	return nil
+}
+
+// shipStat returns the current value of a ship stat used by conditions
+func shipStat(ship Ship, stat string) (int, bool) {
+	switch stat {
+	case "fuel":
+		return ship.Fuel, true
+	case "hull":
+		return ship.HullIntegrity, true
+	case "shields":
+		return ship.ShieldStrength, true
+	case "food":
+		return ship.Food, true
+	case "engine":
+		return ship.EngineHealth, true
+	}
+	return 0, false
+}
+
+// String describes the condition for the player
+func (c QuestCondition) String() string {
+	switch c.Type {
+	case ConditionQuestCompleted:
+		return fmt.Sprintf("complete %s", questTitle(c.Quest))
+	case ConditionQuestFailed:
+		return fmt.Sprintf("fail %s", questTitle(c.Quest))
+	case ConditionReputation:
+		switch {
+		case c.Min != nil && c.Max != nil:
+			return fmt.Sprintf("%s standing between %d and %d", FactionName(c.Faction), *c.Min, *c.Max)
+		case c.Min != nil:
+			return fmt.Sprintf("%s standing of at least %d", FactionName(c.Faction), *c.Min)
+		case c.Max != nil:
+			return fmt.Sprintf("%s standing of at most %d", FactionName(c.Faction), *c.Max)
+		}
+	case ConditionItem:
+		return fmt.Sprintf("%dx %s in cargo", max(c.Quantity, 1), c.Item)
+	case ConditionCrewRole:
+		if c.Degree > 0 {
+			return fmt.Sprintf("a degree %d %s", c.Degree, c.Role)
+		}
+		return fmt.Sprintf("a %s in the crew", c.Role)
+	case ConditionLocationType:
+		return fmt.Sprintf("at a %s", c.Value)
+	case ConditionSystem:
+		return fmt.Sprintf("in the %s system", c.Value)
+	case ConditionContact:
+		name := c.Value
+		if species := FindSpecies(c.Value); species != nil {
+			name = species.Name
+		}
+		if c.Outcome != "" {
+			return fmt.Sprintf("talks with the %s ended: %s", name, NegotiationOutcome(c.Outcome))
+		}
+		return fmt.Sprintf("contact with the %s", name)
+	case ConditionShipStat:
+		switch {
+		case c.Min != nil && c.Max != nil:
+			return fmt.Sprintf("%s between %d and %d", c.Stat, *c.Min, *c.Max)
+		case c.Min != nil:
+			return fmt.Sprintf("%s of at least %d", c.Stat, *c.Min)
+		case c.Max != nil:
+			return fmt.Sprintf("%s of at most %d", c.Stat, *c.Max)
+		}
+	}
+	return c.Type
+}
+
+// Apply applies the effect to the save
+func (e QuestEffect) Apply(save *FullGameSave) {
+	switch e.Type {
+	case EffectCredits:
+		save.Player.Credits = max(save.Player.Credits+e.Amount, 0)
+		save.GameMetadata.Stats.Earn(e.Amount) // only a reward counts, not a cost
+	case EffectReputation:
+		ChangeReputation(&save.Player.Reputation, e.Faction, e.Amount)
+	case EffectItem:
+		if e.Amount < 0 {
+			RemoveCargoItem(&save.Ship.Cargo, e.Item, -e.Amount)
+		} else {
+			StowCargo(save, e.Item, e.Amount)
+		}
+	case EffectExperience:
+		AwardExperience(&save.Player, e.Amount)
+	case EffectFuel:
+		save.Ship.Fuel = min(max(save.Ship.Fuel+e.Amount, 0), save.Ship.MaxFuel)
+	}
+}
+
+// questTitle returns the title of a quest, falling back to its id
+func questTitle(id string) string {
+	if quest := FindQuest(id); quest != nil {
+		return quest.Title
+	}
+	return id
+}
+
+// questActive reports whether the quest is waiting in the pending list or the journal
+func questActive(save *FullGameSave, id string) bool {
+	if slices.Contains(save.Quests.Pending, id) {
+		return true
+	}
+	for _, mission := range save.Missions {
+		if mission.QuestID == id && (mission.Status == MissionStatusNotStarted || mission.Status == MissionStatusInProgress) {
+			return true
+		}
+	}
+	return false
+}
+
+// questBlocked reports whether a quest has been locked out by a mutually exclusive quest or has failed
+func questBlocked(save *FullGameSave, quest Quest) bool {
+	for _, id := range quest.Excludes {
+		if slices.Contains(save.Quests.Completed, id) {
+			return true
+		}
+	}
+	for _, condition := range quest.FailIf {
+		if condition.Met(save) {
+			return true
+		}
+	}
+	return false
+}
+
+// unlockQuest queues a quest to be offered once its prerequisites are met
+func unlockQuest(save *FullGameSave, id string) {
+	if id == "" || FindQuest(id) == nil || questActive(save, id) ||
+		slices.Contains(save.Quests.Completed, id) || slices.Contains(save.Quests.Failed, id) {
+		return
+	}
+	save.Quests.Pending = append(save.Quests.Pending, id)
+}
+
+// failQuest records a quest as failed and unlocks its failure path
+func failQuest(save *FullGameSave, quest Quest, update *QuestUpdate) {
+	save.Quests.Failed = append(save.Quests.Failed, quest.ID)
+	update.Failed = append(update.Failed, quest.Title)
+	unlockQuest(save, quest.OnFail)
+}
+
+// CompleteQuest applies the rewards of a finished quest and the branch chosen in its dialogue,
+// then unlocks whatever follows and advances the rest of the graph
+func CompleteQuest(save *FullGameSave, id, branchID string) QuestUpdate {
+	quest := FindQuest(id)
+	if quest == nil || slices.Contains(save.Quests.Completed, id) {
+		return QuestUpdate{}
+	}
+	save.Quests.Completed = append(save.Quests.Completed, id)
+
+	for _, effect := range quest.Rewards {
+		effect.Apply(save)
+	}
+
+	next := quest.Next
+	if branch := quest.FindBranch(branchID); branch != nil {
+		for _, effect := range branch.Rewards {
+			effect.Apply(save)
+		}
+		next = branch.Next
+	}
+	unlockQuest(save, next)
+
+	return AdvanceQuests(save)
+}
+
+// OfferQuest unlocks a quest outside its quest line, e.g. from a script, and offers it once its prerequisites are met
+func OfferQuest(save *FullGameSave, id string) QuestUpdate {
+	unlockQuest(save, id)
+	return AdvanceQuests(save)
+}
+
+// FailQuest records a quest whose mission failed during play, then advances the rest of the graph
+func FailQuest(save *FullGameSave, id string) QuestUpdate {
+	quest := FindQuest(id)
+	if quest == nil || slices.Contains(save.Quests.Failed, id) {
+		return QuestUpdate{}
+	}
+	var update QuestUpdate
+	failQuest(save, *quest, &update)
+
+	advanced := AdvanceQuests(save)
+	update.Offered = advanced.Offered
+	update.Failed = append(update.Failed, advanced.Failed...)
+	return update
+}
+
+// AdvanceQuests fails quests that can no longer be finished and offers pending quests whose prerequisites are met
+func AdvanceQuests(save *FullGameSave) QuestUpdate {
+	var update QuestUpdate
+
+	// quests in the journal fail when a rival path is completed or a fail state is reached
+	for i := range save.Missions {
+		mission := &save.Missions[i]
+		if mission.QuestID == "" || (mission.Status != MissionStatusNotStarted && mission.Status != MissionStatusInProgress) {
+			continue
+		}
+		quest := FindQuest(mission.QuestID)
+		if quest == nil || !questBlocked(save, *quest) {
+			continue
+		}
+		mission.Status = MissionStatusFailed
+		failQuest(save, *quest, &update)
+	}
+
+	// failing or offering a quest can unlock another, so keep going until the pending list settles
+	for progressed := true; progressed; {
+		progressed = false
+		pending := save.Quests.Pending
+		save.Quests.Pending = nil
+		for _, id := range pending {
+			quest := FindQuest(id)
+			switch {
+			case quest == nil:
+			case questBlocked(save, *quest):
+				failQuest(save, *quest, &update)
+				progressed = true
+			case questReady(save, *quest):
+				mission := quest.Mission()
+				AcceptMission(&mission, save.GameMetadata.ClockHours)
+				save.Missions = append(save.Missions, mission)
+				update.Offered = append(update.Offered, mission)
+				progressed = true
+			case !slices.Contains(save.Quests.Pending, id):
+				save.Quests.Pending = append(save.Quests.Pending, id)
+			}
+		}
+	}
+
+	return update
+}
+
+// questReady reports whether every prerequisite of the quest holds
+func questReady(save *FullGameSave, quest Quest) bool {
+	for _, condition := range quest.Prerequisites {
+		if !condition.Met(save) {
+			return false
+		}
+	}
+	return true
+}
+
+// MissingPrerequisites lists the prerequisites of a quest the player doesn't meet yet
+func MissingPrerequisites(save *FullGameSave, id string) []string {
+	quest := FindQuest(id)
+	if quest == nil {
+		return nil
+	}
+	var missing []string
+	for _, condition := range quest.Prerequisites {
+		if !condition.Met(save) {
+			missing = append(missing, condition.String())
+		}
+	}
+	return missing
+}
+
+// linkStoryMissions gives Main story missions from older saves their quest ids and records their progress
+func linkStoryMissions(save *FullGameSave) {
+	for i := range save.Missions {
+		mission := &save.Missions[i]
+		if mission.QuestID != "" || mission.Category != "Main" {
+			continue
+		}
+		for _, quest := range questsByTitle(mission.Title) {
+			mission.QuestID = quest.ID
+			if mission.Status == MissionStatusCompleted && !slices.Contains(save.Quests.Completed, quest.ID) {
+				save.Quests.Completed = append(save.Quests.Completed, quest.ID)
+			}
+			break
+		}
+	}
+}
+
+// questsByTitle returns the quests with the given title
+func questsByTitle(title string) []Quest {
+	if FindQuest(OpeningQuestID) == nil {
+		return nil
+	}
+	var quests []Quest
+	for _, quest := range Quests {
+		if quest.Title == title {
+			quests = append(quests, quest)
+		}
+	}
+	return quests
+}
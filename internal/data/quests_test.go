@@ -0,0 +1,137 @@
+package data
+
+import (
+	"slices"
+	"testing"
+)
+
+// useQuestGraph swaps the embedded quest graph for a small one for the length of a test:
+//
+//	start -left-> medic (needs a living medic), rival of hold
+//	      -right-> hold -> done, failing hold leads to retreat
+func useQuestGraph(t *testing.T) {
+	t.Helper()
+	Quests = []Quest{
+		{ID: "start", Title: "Start", Income: 100, Rewards: []QuestEffect{{Type: EffectCredits, Amount: 50}},
+			Branches: []QuestBranch{
+				{ID: "left", Next: "medic", Rewards: []QuestEffect{{Type: EffectCredits, Amount: 25}}},
+				{ID: "right", Next: "hold"},
+			},
+			Next: "hold"},
+		{ID: "medic", Title: "Medic", Prerequisites: []QuestCondition{{Type: ConditionCrewRole, Role: string(CrewRoleMedic)}}},
+		{ID: "hold", Title: "Hold", Excludes: []string{"medic"}, Next: "done", OnFail: "retreat"},
+		{ID: "done", Title: "Done"},
+		{ID: "retreat", Title: "Retreat"},
+	}
+	t.Cleanup(func() { Quests = nil })
+}
+
+// journal returns the quest ids of the missions in the journal with the given status
+func journal(save *FullGameSave, status MissionStatus) []string {
+	var ids []string
+	for _, mission := range save.Missions {
+		if mission.Status == status {
+			ids = append(ids, mission.QuestID)
+		}
+	}
+	return ids
+}
+
+func TestCompleteQuest(t *testing.T) {
+	useQuestGraph(t)
+	tests := []struct {
+		name        string
+		branch      string
+		crew        []CrewMember
+		wantCredits int
+		wantOffered []string
+		wantPending []string
+	}{
+		{name: "no branch follows next", wantCredits: 50, wantOffered: []string{"hold"}},
+		{name: "unknown branch follows next", branch: "up", wantCredits: 50, wantOffered: []string{"hold"}},
+		{name: "branch rewards are paid too", branch: "left", crew: []CrewMember{{Role: CrewRoleMedic, Health: 80}},
+			wantCredits: 75, wantOffered: []string{"medic"}},
+		{name: "waits for a medic", branch: "left", wantCredits: 75, wantPending: []string{"medic"}},
+		{name: "a dead medic won't do", branch: "left", crew: []CrewMember{{Role: CrewRoleMedic, Health: 0}},
+			wantCredits: 75, wantPending: []string{"medic"}},
+		{name: "other branch", branch: "right", wantCredits: 50, wantOffered: []string{"hold"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			save := &FullGameSave{Crew: tt.crew}
+			update := CompleteQuest(save, "start", tt.branch)
+
+			var offered []string
+			for _, mission := range update.Offered {
+				offered = append(offered, mission.QuestID)
+			}
+			if !slices.Equal(offered, tt.wantOffered) || !slices.Equal(journal(save, MissionStatusNotStarted), tt.wantOffered) {
+				t.Errorf("offered %v, journal has %v, want %v", offered, journal(save, MissionStatusNotStarted), tt.wantOffered)
+			}
+			if !slices.Equal(save.Quests.Pending, tt.wantPending) {
+				t.Errorf("pending %v, want %v", save.Quests.Pending, tt.wantPending)
+			}
+			if save.Player.Credits != tt.wantCredits || save.GameMetadata.Stats.CreditsEarned != tt.wantCredits {
+				t.Errorf("credits %d, %d earned, want %d", save.Player.Credits, save.GameMetadata.Stats.CreditsEarned, tt.wantCredits)
+			}
+		})
+	}
+}
+
+func TestCompleteQuestPaysOnce(t *testing.T) {
+	useQuestGraph(t)
+	save := &FullGameSave{}
+	CompleteQuest(save, "start", "")
+	if update := CompleteQuest(save, "start", ""); len(update.Offered) != 0 || save.Player.Credits != 50 {
+		t.Errorf("completing again offered %d and left %d credits, want nothing more", len(update.Offered), save.Player.Credits)
+	}
+}
+
+func TestAdvanceQuests(t *testing.T) {
+	useQuestGraph(t)
+	save := &FullGameSave{}
+	CompleteQuest(save, "start", "left")
+
+	// the medic quest waits until a medic joins, and nothing is offered twice
+	if update := AdvanceQuests(save); len(update.Offered) != 0 {
+		t.Fatalf("offered %v without a medic aboard", update.Offered)
+	}
+	save.Crew = append(save.Crew, CrewMember{Role: CrewRoleMedic, Health: 100})
+	if update := AdvanceQuests(save); len(update.Offered) != 1 || update.Offered[0].QuestID != "medic" {
+		t.Fatalf("offered %v once a medic joined, want medic", update.Offered)
+	}
+	if update := AdvanceQuests(save); len(update.Offered) != 0 {
+		t.Errorf("offered %v again", update.Offered)
+	}
+
+	// a rival quest taken up from a script fails once the medic quest is done, and its failure path opens
+	if update := OfferQuest(save, "hold"); len(update.Offered) != 1 {
+		t.Fatalf("OfferQuest() offered %v, want hold", update.Offered)
+	}
+	// the journal entry is closed by the game before the quest is completed
+	for i := range save.Missions {
+		if save.Missions[i].QuestID == "medic" {
+			save.Missions[i].Status = MissionStatusCompleted
+		}
+	}
+	update := CompleteQuest(save, "medic", "")
+	if !slices.Equal(update.Failed, []string{"Hold"}) || !slices.Equal(journal(save, MissionStatusFailed), []string{"hold"}) {
+		t.Errorf("failed %v, journal has %v failed, want hold", update.Failed, journal(save, MissionStatusFailed))
+	}
+	if !slices.Equal(journal(save, MissionStatusNotStarted), []string{"retreat"}) {
+		t.Errorf("journal has %v waiting, want retreat", journal(save, MissionStatusNotStarted))
+	}
+
+	// a failed quest can't be offered again
+	if update := OfferQuest(save, "hold"); len(update.Offered) != 0 {
+		t.Errorf("OfferQuest() offered %v after the quest failed", update.Offered)
+	}
+}
+
+func TestOfferQuestUnknown(t *testing.T) {
+	useQuestGraph(t)
+	save := &FullGameSave{}
+	if update := OfferQuest(save, "nowhere"); len(update.Offered) != 0 || len(save.Quests.Pending) != 0 {
+		t.Errorf("OfferQuest() of an unknown quest offered %v and left %v pending", update.Offered, save.Quests.Pending)
+	}
+}
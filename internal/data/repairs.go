@@ -0,0 +1,186 @@
+package data
+
+import "math"
+
+// RepairKind is the part of the ship a repair is aimed at
+type RepairKind int
+
+const (
+	RepairHull RepairKind = iota
+	RepairEngine
+	RepairFTLDrive
+	RepairModule
+)
+
+// RepairTarget is a single repairable part, ModuleId is only set for module repairs
+type RepairTarget struct {
+	Kind     RepairKind
+	ModuleId string
+}
+
+const (
+	maxSystemHealth = 100 // engine and FTL drive health are percentages
+
+	// station prices
+	hullRepairPrice   = 3   // credits per point of hull
+	engineRepairPrice = 4   // credits per point of engine health
+	ftlRepairPrice    = 6   // credits per point of FTL drive health
+	moduleRepairPrice = 100 // credits per module level
+
+	// engine wear
+	engineWearDistance     = 10 // distance units travelled per point of engine wear
+	mechanicWearReduction  = 15 // % less wear per degree of the mechanics on engine maintenance
+	maxMechanicWearReducer = 75 // mechanics can never remove more than this % of the wear
+
+	// self-repair
+	SelfRepairMaterial       = "Scrap Metal"    // used to patch the hull, engine and FTL drive
+	SelfRepairModuleMaterial = "Circuit Boards" // used to fix damaged modules
+	selfRepairPerDegree      = 2                // points repaired per engineer degree for each material used
+)
+
+// RepairTargets lists every part of the ship that currently needs repairs
+func RepairTargets(ship Ship) []RepairTarget {
+	var targets []RepairTarget
+	if ship.HullIntegrity < ship.MaxHullIntegrity {
+		targets = append(targets, RepairTarget{Kind: RepairHull})
+	}
+	if ship.EngineHealth < maxSystemHealth {
+		targets = append(targets, RepairTarget{Kind: RepairEngine})
+	}
+	if ship.HasFTLDrive && ship.FTLDriveHealth < maxSystemHealth {
+		targets = append(targets, RepairTarget{Kind: RepairFTLDrive})
+	}
+	for _, module := range ship.Modules {
+		if module.Status == "damaged" {
+			targets = append(targets, RepairTarget{Kind: RepairModule, ModuleId: module.ModuleId})
+		}
+	}
+	return targets
+}
+
+// Label returns the name of the part shown to the player
+func (t RepairTarget) Label(ship Ship) string {
+	switch t.Kind {
+	case RepairHull:
+		return "Hull"
+	case RepairEngine:
+		return "Engine"
+	case RepairFTLDrive:
+		return "FTL Drive"
+	default:
+		if module := findModule(&ship, t.ModuleId); module != nil {
+			return module.Name
+		}
+		return "Unknown Module"
+	}
+}
+
+// RepairNeeded returns how many points the target is missing, modules count as a single repair
+func RepairNeeded(ship Ship, target RepairTarget) int {
+	switch target.Kind {
+	case RepairHull:
+		return max(ship.MaxHullIntegrity-ship.HullIntegrity, 0)
+	case RepairEngine:
+		return max(maxSystemHealth-ship.EngineHealth, 0)
+	case RepairFTLDrive:
+		return max(maxSystemHealth-ship.FTLDriveHealth, 0)
+	default:
+		if module := findModule(&ship, target.ModuleId); module != nil && module.Status == "damaged" {
+			return 1
+		}
+		return 0
+	}
+}
+
+// StationRepairCost returns what a station charges to repair amount points of the target
+func StationRepairCost(ship Ship, target RepairTarget, amount int) int {
+	switch target.Kind {
+	case RepairHull:
+		return amount * hullRepairPrice
+	case RepairEngine:
+		return amount * engineRepairPrice
+	case RepairFTLDrive:
+		return amount * ftlRepairPrice
+	default:
+		if module := findModule(&ship, target.ModuleId); module != nil {
+			return max(module.Level, 1) * moduleRepairPrice
+		}
+		return 0
+	}
+}
+
+// ApplyRepair restores up to amount points of the target and returns how much was repaired
+func ApplyRepair(ship *Ship, target RepairTarget, amount int) int {
+	amount = min(amount, RepairNeeded(*ship, target))
+	if amount <= 0 {
+		return 0
+	}
+	switch target.Kind {
+	case RepairHull:
+		ship.HullIntegrity += amount
+	case RepairEngine:
+		ship.EngineHealth += amount
+	case RepairFTLDrive:
+		ship.FTLDriveHealth += amount
+	default:
+		findModule(ship, target.ModuleId).Status = "operational"
+	}
+	return amount
+}
+
+// EngineWear returns how much engine health a trip of the given distance costs
+// each Mechanic on engine maintenance cuts the wear by mechanicWearReduction% per degree
+func EngineWear(distance int, crew []CrewMember) int {
+	if distance <= 0 {
+		return 0
+	}
+	wear := math.Ceil(float64(distance) / engineWearDistance)
+
+	reduction := 0
+	for _, member := range AssignedToTask(crew, CrewTaskEngineMaintenance) {
+		if member.Role == CrewRoleMechanic {
+			reduction += member.Degree * mechanicWearReduction
+		}
+	}
+	reduction = min(reduction, maxMechanicWearReducer)
+
+	return int(math.Ceil(wear * float64(100-reduction) / 100))
+}
+
+// SelfRepair has the Engineers on damage control use one unit of material from the cargo hold
+// to repair the first damaged part, it returns the part repaired and the points restored
+// nothing happens if no engineer is assigned or the right material is missing
+func SelfRepair(ship *Ship, crew []CrewMember) (RepairTarget, int) {
+	degrees := 0
+	for _, member := range AssignedToTask(crew, CrewTaskDamageControl) {
+		if member.Role == CrewRoleEngineer {
+			degrees += member.Degree
+		}
+	}
+	if degrees == 0 {
+		return RepairTarget{}, 0
+	}
+
+	for _, target := range RepairTargets(*ship) {
+		material, amount := SelfRepairMaterial, degrees*selfRepairPerDegree
+		if target.Kind == RepairModule {
+			material, amount = SelfRepairModuleMaterial, 1
+		}
+		if CargoQuantity(ship.Cargo, material) == 0 {
+			continue
+		}
+		RemoveCargoItem(&ship.Cargo, material, 1)
+		return target, ApplyRepair(ship, target, amount)
+	}
+	return RepairTarget{}, 0
+}
+
+// findModule returns the module with the given id, or nil if the ship doesn't have it
+func findModule(ship *Ship, moduleId string) *Module {
+	for i := range ship.Modules {
+		if ship.Modules[i].ModuleId == moduleId {
+			return &ship.Modules[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package data
+
+const (
+	minStanding = -100
+	maxStanding = 100
+
+	// MissionReputationReward is the standing gained with a mission's faction when it is completed
+	MissionReputationReward = 5
+
+	// ReputationEffectPrefix marks event effects that change standing, e.g. "reputation:PirateClan"
+	ReputationEffectPrefix = "reputation:"
+)
+
+// ReputationTier groups standings into the bands that decide how a faction treats the player
+type ReputationTier int
+
+const (
+	TierHostile ReputationTier = iota
+	TierUnfriendly
+	TierNeutral
+	TierFriendly
+	TierAllied
+)
+
+func (t ReputationTier) String() string {
+	return [...]string{"Hostile", "Unfriendly", "Neutral", "Friendly", "Allied"}[t]
+}
+
+// TierFor returns the tier a standing falls into
+func TierFor(standing int) ReputationTier {
+	switch {
+	case standing <= -50:
+		return TierHostile
+	case standing <= -10:
+		return TierUnfriendly
+	case standing < 10:
+		return TierNeutral
+	case standing < 50:
+		return TierFriendly
+	default:
+		return TierAllied
+	}
+}
+
+// Standing returns the player's standing with a faction, 0 if they have never dealt with it
+func Standing(rep Reputation, faction string) int {
+	if standing, ok := rep.AlliedFactions[faction]; ok {
+		return standing
+	}
+	return rep.EnemyFactions[faction]
+}
+
+// ChangeReputation adjusts the player's standing with a faction, keeping it between -100 and 100
+// factions with a positive standing are kept in the allied list and the rest in the enemy list
+func ChangeReputation(rep *Reputation, faction string, delta int) {
+	if faction == "" || delta == 0 {
+		return
+	}
+	if rep.AlliedFactions == nil {
+		rep.AlliedFactions = map[string]int{}
+	}
+	if rep.EnemyFactions == nil {
+		rep.EnemyFactions = map[string]int{}
+	}
+
+	standing := max(min(Standing(*rep, faction)+delta, maxStanding), minStanding)
+	delete(rep.AlliedFactions, faction)
+	delete(rep.EnemyFactions, faction)
+	if standing > 0 {
+		rep.AlliedFactions[faction] = standing
+	} else {
+		rep.EnemyFactions[faction] = standing
+	}
+}
+
+// CanDock reports whether a faction lets the player use its stations
+func CanDock(rep Reputation, faction string) bool {
+	return faction == "" || TierFor(Standing(rep, faction)) > TierHostile
+}
+
+// PriceModifier returns the percentage a faction charges the player at its stations
+func PriceModifier(rep Reputation, faction string) int {
+	if faction == "" {
+		return 100
+	}
+	return [...]int{150, 120, 100, 90, 80}[TierFor(Standing(rep, faction))]
+}
+
+// RewardModifier returns the percentage of the usual payout a faction offers for its missions
+func RewardModifier(rep Reputation, faction string) int {
+	if faction == "" {
+		return 100
+	}
+	return [...]int{50, 80, 100, 110, 125}[TierFor(Standing(rep, faction))]
+}
+
+// AdjustPrice applies a percentage modifier to a price
+func AdjustPrice(price int, percent int) int {
+	return price * percent / 100
+}
+
+// MeetsStanding reports whether the player's standing with a faction lies within the given bounds
+// a nil bound is ignored, and content without a faction is always available
+func MeetsStanding(rep Reputation, faction string, minRep *int, maxRep *int) bool {
+	if faction == "" {
+		return true
+	}
+	standing := Standing(rep, faction)
+	if minRep != nil && standing < *minRep {
+		return false
+	}
+	if maxRep != nil && standing > *maxRep {
+		return false
+	}
+	return true
+}
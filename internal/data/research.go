@@ -0,0 +1,282 @@
+package data
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ResearchState is the ship's progress through the research tree
+type ResearchState struct {
+	Unlocked []string `json:"unlocked,omitempty"` // ids of the nodes researched
+	Active   string   `json:"active,omitempty"`   // id of the node under research, empty when the lab is idle
+	Progress int      `json:"progress,omitempty"` // work put into the active node
+}
+
+// ResearchCost is a number of research notes of one tier a node uses up
+type ResearchCost struct {
+	Tier     int
+	Quantity int
+}
+
+// ResearchBonus is what a node adds to the ship once researched
+type ResearchBonus struct {
+	ScanRange          int
+	FuelSaving         int // % of the fuel a trip would burn that is saved
+	ShieldRegen        int // shield points per regeneration cycle
+	MaxShields         int
+	CargoCapacity      int
+	CollectionCapacity int
+	HailChance         int // % added to the chance a hail is answered
+	NoteTier           int // tiers added to the notes analyzed items are written up as
+}
+
+// ResearchNode is a technology on the research tree
+// Work is the number of degree-hours researchers need to put in, each assigned researcher adds their degree every hour
+type ResearchNode struct {
+	ID          string
+	Name        string
+	Description string
+	Requires    []string // nodes that have to be researched first
+	Cost        []ResearchCost
+	Work        int
+	Bonus       ResearchBonus
+}
+
+// ResearchTree lists every node, parents always come before their children
+var ResearchTree = []ResearchNode{
+	{
+		ID:          "advanced_scanners",
+		Name:        "Advanced Scanners",
+		Description: "Finer filtering of sensor noise lets scans reach further.",
+		Cost:        []ResearchCost{{1, 2}},
+		Work:        24,
+		Bonus:       ResearchBonus{ScanRange: 15},
+	},
+	{
+		ID:          "deep_space_telemetry",
+		Name:        "Deep Space Telemetry",
+		Description: "Cross-referencing old survey data charts systems long before the ship gets there.",
+		Requires:    []string{"advanced_scanners"},
+		Cost:        []ResearchCost{{2, 2}, {3, 1}},
+		Work:        60,
+		Bonus:       ResearchBonus{ScanRange: 25},
+	},
+	{
+		ID:          "ftl_efficiency",
+		Name:        "FTL Efficiency",
+		Description: "Tuning the drive's jump profile burns less fuel on every trip.",
+		Cost:        []ResearchCost{{2, 2}},
+		Work:        36,
+		Bonus:       ResearchBonus{FuelSaving: 15},
+	},
+	{
+		ID:          "ftl_field_folding",
+		Name:        "FTL Field Folding",
+		Description: "Folding the drive field back on itself recovers even more of the fuel it spends.",
+		Requires:    []string{"ftl_efficiency"},
+		Cost:        []ResearchCost{{3, 1}, {4, 1}},
+		Work:        80,
+		Bonus:       ResearchBonus{FuelSaving: 15},
+	},
+	{
+		ID:          "shield_harmonics",
+		Name:        "Shield Harmonics",
+		Description: "Emitters cycled in harmony recharge the shields faster.",
+		Cost:        []ResearchCost{{1, 1}, {2, 1}},
+		Work:        30,
+		Bonus:       ResearchBonus{ShieldRegen: 2},
+	},
+	{
+		ID:          "phase_shielding",
+		Name:        "Phase Shielding",
+		Description: "Layering the field out of phase lets the shields hold far more.",
+		Requires:    []string{"shield_harmonics"},
+		Cost:        []ResearchCost{{3, 2}, {4, 1}},
+		Work:        72,
+		Bonus:       ResearchBonus{MaxShields: 20},
+	},
+	{
+		ID:          "cargo_compression",
+		Name:        "Cargo Compression",
+		Description: "Vacuum packing and smarter stowage fit more into the hold.",
+		Cost:        []ResearchCost{{1, 2}, {2, 1}},
+		Work:        30,
+		Bonus:       ResearchBonus{CargoCapacity: 30},
+	},
+	{
+		ID:          "specimen_stasis",
+		Name:        "Specimen Stasis",
+		Description: "Stasis fields keep specimens from degrading, so the collection can hold more.",
+		Requires:    []string{"cargo_compression"},
+		Cost:        []ResearchCost{{2, 2}},
+		Work:        40,
+		Bonus:       ResearchBonus{CollectionCapacity: 25},
+	},
+	{
+		ID:          "xenolinguistics",
+		Name:        "Xenolinguistics",
+		Description: "A working grammar of the languages heard out here gets hails answered more often.",
+		Cost:        []ResearchCost{{2, 1}, {3, 1}},
+		Work:        48,
+		Bonus:       ResearchBonus{HailChance: 15},
+	},
+	{
+		ID:          "xenoarchaeology",
+		Name:        "Xenoarchaeology",
+		Description: "Reading the markings on artifacts turns what they are made of into better research.",
+		Requires:    []string{"xenolinguistics", "advanced_scanners"},
+		Cost:        []ResearchCost{{4, 1}, {5, 1}},
+		Work:        100,
+		Bonus:       ResearchBonus{NoteTier: 1},
+	},
+}
+
+// FindResearchNode returns the node with the given id, or nil if the tree has none
+func FindResearchNode(id string) *ResearchNode {
+	for i := range ResearchTree {
+		if ResearchTree[i].ID == id {
+			return &ResearchTree[i]
+		}
+	}
+	return nil
+}
+
+// Researched reports whether the ship has researched a node
+func (r ResearchState) Researched(id string) bool {
+	return slices.Contains(r.Unlocked, id)
+}
+
+// Available reports whether every node a node requires has been researched
+func (r ResearchState) Available(node ResearchNode) bool {
+	for _, id := range node.Requires {
+		if !r.Researched(id) {
+			return false
+		}
+	}
+	return true
+}
+
+// Bonus adds up the bonuses of every node researched
+func (r ResearchState) Bonus() ResearchBonus {
+	var total ResearchBonus
+	for _, id := range r.Unlocked {
+		node := FindResearchNode(id)
+		if node == nil {
+			continue
+		}
+		b := node.Bonus
+		total.ScanRange += b.ScanRange
+		total.FuelSaving += b.FuelSaving
+		total.ShieldRegen += b.ShieldRegen
+		total.MaxShields += b.MaxShields
+		total.CargoCapacity += b.CargoCapacity
+		total.CollectionCapacity += b.CollectionCapacity
+		total.HailChance += b.HailChance
+		total.NoteTier += b.NoteTier
+	}
+	return total
+}
+
+// String lists what the bonus adds, e.g. "+15 scan range, +2 shield regen"
+func (b ResearchBonus) String() string {
+	var parts []string
+	add := func(value int, format string) {
+		if value != 0 {
+			parts = append(parts, fmt.Sprintf(format, value))
+		}
+	}
+	add(b.ScanRange, "+%d scan range")
+	add(b.FuelSaving, "%d%% less fuel per trip")
+	add(b.ShieldRegen, "+%d shield regen")
+	add(b.MaxShields, "+%d max shields")
+	add(b.CargoCapacity, "+%d cargo capacity")
+	add(b.CollectionCapacity, "+%d collection capacity")
+	add(b.HailChance, "+%d%% hail chance")
+	add(b.NoteTier, "+%d tier on analyzed items")
+	return strings.Join(parts, ", ")
+}
+
+// ResearchRate returns the work researchers put in every hour, the degrees of the
+// scientists and research specialists assigned to the lab
+func ResearchRate(crew []CrewMember) int {
+	rate := 0
+	for _, member := range AssignedToTask(crew, CrewTaskResearch) {
+		if member.Role == CrewRoleScientist || member.Role == CrewRoleResearchSpecialist {
+			rate += max(member.Degree, 1)
+		}
+	}
+	return rate
+}
+
+// StartResearch spends the notes a node costs and sets the lab to work on it
+func StartResearch(save *FullGameSave, id string) error {
+	node := FindResearchNode(id)
+	research := &save.Ship.Research
+	switch {
+	case node == nil:
+		return fmt.Errorf("unknown research %q", id)
+	case research.Researched(id):
+		return fmt.Errorf("%s is already researched", node.Name)
+	case research.Active != "":
+		return fmt.Errorf("the lab is busy with %s", FindResearchNode(research.Active).Name)
+	case !research.Available(*node):
+		return fmt.Errorf("%s builds on research not done yet", node.Name)
+	}
+	for _, cost := range node.Cost {
+		if ResearchNotesHeld(save.Collection, cost.Tier) < cost.Quantity {
+			return fmt.Errorf("%s needs %d tier %d research notes", node.Name, cost.Quantity, cost.Tier)
+		}
+	}
+
+	for _, cost := range node.Cost {
+		for i := range save.Collection.ResearchNotes {
+			if save.Collection.ResearchNotes[i].Tier == cost.Tier {
+				save.Collection.ResearchNotes[i].Quantity -= cost.Quantity
+			}
+		}
+	}
+	save.Collection.UsedCapacity = CollectionUsed(save.Collection)
+	research.Active = id
+	research.Progress = 0
+	WriteLog(save, LogDiscovery, "Research began on %s.", node.Name)
+	return nil
+}
+
+// progressResearch puts the researchers' hours into the active node and returns the node if it was finished
+func progressResearch(save *FullGameSave, hours int) *ResearchNode {
+	research := &save.Ship.Research
+	node := FindResearchNode(research.Active)
+	if node == nil {
+		research.Active = ""
+		return nil
+	}
+	research.Progress += ResearchRate(save.Crew) * hours
+	if research.Progress < node.Work {
+		return nil
+	}
+
+	research.Unlocked = append(research.Unlocked, node.ID)
+	research.Active = ""
+	research.Progress = 0
+	ApplyUpgradeEffects(&save.Ship)
+	ApplyCollectionCapacity(save)
+	WriteLog(save, LogDiscovery, "Research on %s is complete.", node.Name)
+	return node
+}
+
+// FuelAfterResearch returns the fuel left after a trip once research has saved some of what it burns
+func FuelAfterResearch(ship Ship, remaining int) int {
+	used := ship.Fuel - remaining
+	return remaining + used*min(ship.Research.Bonus().FuelSaving, 100)/100
+}
+
+// ResearchNotesHeld returns how many research notes of a tier the collection holds
+func ResearchNotesHeld(collection Collection, tier int) int {
+	for _, note := range collection.ResearchNotes {
+		if note.Tier == tier {
+			return note.Quantity
+		}
+	}
+	return 0
+}
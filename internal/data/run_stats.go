@@ -0,0 +1,196 @@
+package data
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+// HallOfFamePath is where finished runs are kept, it outlives every save
+const HallOfFamePath = "GameData/hall_of_fame.json"
+
+// HallOfFameSize is how many of the best runs the hall of fame keeps
+const HallOfFameSize = 10
+
+// LossCause is why a run ended, empty while the game goes on
+type LossCause string
+
+const (
+	LossNone          LossCause = ""
+	LossHullDestroyed LossCause = "hullDestroyed"
+	LossStranded      LossCause = "stranded"
+	LossCrewDead      LossCause = "crewDead"
+	LossMutiny        LossCause = "mutiny"
+)
+
+func (c LossCause) String() string {
+	switch c {
+	case LossHullDestroyed:
+		return "Hull destroyed"
+	case LossStranded:
+		return "Stranded without fuel"
+	case LossCrewDead:
+		return "Crew lost"
+	case LossMutiny:
+		return "Mutiny"
+	}
+	return "Unknown"
+}
+
+// Epitaph describes how the ship's story ended
+func (c LossCause) Epitaph(shipName string) string {
+	switch c {
+	case LossHullDestroyed:
+		return fmt.Sprintf("The hull of the %s gave way and the ship broke apart in the void.", shipName)
+	case LossStranded:
+		return fmt.Sprintf("With her tanks dry and every call for help spent, the %s drifts silently into the dark.", shipName)
+	case LossCrewDead:
+		return fmt.Sprintf("The last of the crew is gone. The %s sails on with no one at the helm.", shipName)
+	case LossMutiny:
+		return fmt.Sprintf("With their morale broken, the crew seized the %s and put the captain off at the nearest airlock.", shipName)
+	}
+	return fmt.Sprintf("The crew of the %s have perished in the depths of space.", shipName)
+}
+
+// RunStats are the totals kept over a run that can't be worked out from the save afterwards
+type RunStats struct {
+	DistanceTravelled int `json:"distanceTravelled"`
+	CreditsEarned     int `json:"creditsEarned"` // income from mission payouts, sales and combat rewards
+}
+
+// Earn counts income towards the credits earned over the run
+// it is called where income is paid out, so credits moved around any other way don't count
+func (s *RunStats) Earn(credits int) {
+	s.CreditsEarned += max(credits, 0)
+}
+
+// CheckLoss returns why the run is over, or LossNone while the ship and crew can carry on
+func CheckLoss(hull, fuel int, crew []CrewMember) LossCause {
+	if hull <= 0 {
+		return LossHullDestroyed
+	}
+	var alive []CrewMember
+	for _, member := range crew {
+		if member.Health > 0 {
+			alive = append(alive, member)
+		}
+	}
+	if len(crew) > 0 && len(alive) == 0 {
+		return LossCrewDead
+	}
+	// the crew mutiny once not one of them has any morale left
+	if len(alive) > 0 && !slices.ContainsFunc(alive, func(m CrewMember) bool { return m.Morale > 0 }) {
+		return LossMutiny
+	}
+	// an empty tank is checked last, a ship whose crew can't carry on is lost however much help is at hand
+	if fuel <= 0 {
+		return LossStranded
+	}
+	return LossNone
+}
+
+// Epilogue sums up a finished run, it is what the hall of fame keeps
+type Epilogue struct {
+	Captain           string        `json:"captain"`
+	ShipName          string        `json:"shipName"`
+	Difficulty        string        `json:"difficulty"`
+	Cause             LossCause     `json:"cause"`
+	Stardate          string        `json:"stardate"`
+	Ended             string        `json:"ended"` // real time the run ended
+	DistanceTravelled int           `json:"distanceTravelled"`
+	MissionsCompleted int           `json:"missionsCompleted"`
+	CreditsEarned     int           `json:"creditsEarned"`
+	SystemsVisited    int           `json:"systemsVisited"`
+	CrewLost          []string      `json:"crewLost,omitempty"`
+	PlayTime          TotalPlayTime `json:"playTime"`
+}
+
+// NewEpilogue sums up the run in a save
+func NewEpilogue(save *FullGameSave) Epilogue {
+	e := Epilogue{
+		Captain:           save.Player.PlayerName,
+		ShipName:          save.Ship.ShipName,
+		Difficulty:        save.GameMetadata.DifficultySettings.DifficultyLevel,
+		Cause:             save.GameMetadata.LossCause,
+		Stardate:          Stardate(save.GameMetadata.ClockHours),
+		Ended:             time.Now().Format(time.RFC3339),
+		DistanceTravelled: save.GameMetadata.Stats.DistanceTravelled,
+		CreditsEarned:     save.GameMetadata.Stats.CreditsEarned,
+		PlayTime:          save.GameMetadata.TotalPlayTime,
+	}
+	for _, mission := range save.Missions {
+		if mission.Status == MissionStatusCompleted {
+			e.MissionsCompleted++
+		}
+	}
+	for _, system := range save.GameMap.StarSystems {
+		if slices.ContainsFunc(system.Planets, func(p Planet) bool { return p.Visited }) {
+			e.SystemsVisited++
+		}
+	}
+	for _, member := range save.Crew {
+		if member.Health <= 0 {
+			e.CrewLost = append(e.CrewLost, member.Name)
+		}
+	}
+	return e
+}
+
+// Score ranks a run in the hall of fame, finished jobs count for the most
+func (e Epilogue) Score() int {
+	return 500*e.MissionsCompleted + 200*e.SystemsVisited + 10*e.DistanceTravelled + e.CreditsEarned/10 - 100*len(e.CrewLost)
+}
+
+// PlayTimeString returns how long the run was played for
+func (e Epilogue) PlayTimeString() string {
+	return fmt.Sprintf("%dh %02dm %02ds", e.PlayTime.Hours, e.PlayTime.Minutes, e.PlayTime.Seconds)
+}
+
+// LoadHallOfFame returns the best runs so far, best first, and none if no run has ended yet
+func LoadHallOfFame() ([]Epilogue, error) {
+	dataBytes, err := os.ReadFile(HallOfFamePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var runs []Epilogue
+	if err := json.Unmarshal(dataBytes, &runs); err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
+
+// RecordRun adds a finished run to the hall of fame
+// it returns the hall of fame as it now stands and the run's place in it, 0 if it didn't make the cut
+func RecordRun(run Epilogue) ([]Epilogue, int, error) {
+	runs, err := LoadHallOfFame()
+	if err != nil {
+		return nil, 0, err
+	}
+	runs = append(runs, run)
+	slices.SortStableFunc(runs, func(a, b Epilogue) int { return b.Score() - a.Score() })
+	runs = runs[:min(len(runs), HallOfFameSize)]
+
+	place := 0
+	for i := range runs {
+		if runs[i].Ended == run.Ended && runs[i].Captain == run.Captain && runs[i].ShipName == run.ShipName {
+			place = i + 1
+			break
+		}
+	}
+
+	dataBytes, err := json.MarshalIndent(runs, "", "  ")
+	if err != nil {
+		return runs, place, err
+	}
+	if err := os.MkdirAll(filepath.Dir(HallOfFamePath), 0755); err != nil {
+		return runs, place, err
+	}
+	return runs, place, os.WriteFile(HallOfFamePath, dataBytes, 0644)
+}
@@ -0,0 +1,38 @@
+package data
+
+import "testing"
+
+func TestCheckLoss(t *testing.T) {
+	healthy := CrewMember{Name: "Ada", Health: 100, Morale: 50}
+	dead := CrewMember{Name: "Bo", Health: 0, Morale: 50}
+	broken := CrewMember{Name: "Cy", Health: 100, Morale: 0}
+
+	tests := []struct {
+		name string
+		hull int
+		fuel int
+		crew []CrewMember
+		want LossCause
+	}{
+		{name: "all well", hull: 100, fuel: 50, crew: []CrewMember{healthy}, want: LossNone},
+		{name: "no crew hired", hull: 100, fuel: 50, want: LossNone},
+		{name: "hull destroyed", hull: 0, fuel: 50, crew: []CrewMember{healthy}, want: LossHullDestroyed},
+		{name: "hull below zero", hull: -5, fuel: 50, crew: []CrewMember{healthy}, want: LossHullDestroyed},
+		{name: "hull before fuel", hull: 0, fuel: 0, crew: []CrewMember{healthy}, want: LossHullDestroyed},
+		{name: "out of fuel", hull: 100, fuel: 0, crew: []CrewMember{healthy}, want: LossStranded},
+		{name: "crew before fuel", hull: 100, fuel: 0, crew: []CrewMember{dead}, want: LossCrewDead},
+		{name: "mutiny before fuel", hull: 100, fuel: 0, crew: []CrewMember{broken}, want: LossMutiny},
+		{name: "crew dead", hull: 100, fuel: 50, crew: []CrewMember{dead, dead}, want: LossCrewDead},
+		{name: "some crew left", hull: 100, fuel: 50, crew: []CrewMember{dead, healthy}, want: LossNone},
+		{name: "mutiny", hull: 100, fuel: 50, crew: []CrewMember{broken, broken}, want: LossMutiny},
+		{name: "one loyal hand", hull: 100, fuel: 50, crew: []CrewMember{broken, healthy}, want: LossNone},
+		{name: "the dead don't mutiny", hull: 100, fuel: 50, crew: []CrewMember{broken, dead}, want: LossMutiny},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CheckLoss(tt.hull, tt.fuel, tt.crew); got != tt.want {
+				t.Errorf("CheckLoss(%d, %d, crew) = %q, want %q", tt.hull, tt.fuel, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,346 @@
+package data
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+	"go.starlark.net/syntax"
+)
+
+//go:embed scripts/*.star
+var embeddedScripts embed.FS
+
+// limits every script call runs under, a script that hits one is stopped and reported
+const (
+	ScriptMaxSteps = 100_000
+	ScriptTimeout  = 50 * time.Millisecond
+)
+
+// ScriptResult collects what an event script asked for while it ran
+type ScriptResult struct {
+	Effects  map[string]int // effects to apply on top of the outcome's own
+	Dialogue []string       // lines added to the outcome text
+	Next     int            // follow-up event to chain into, 0 for none
+	Quests   []string       // quests to offer the player
+}
+
+// Scripts holds the globals of every loaded script file, keyed by file name
+var Scripts map[string]starlark.StringDict
+
+// script files added or replaced by mods, keyed by file name
+var modScripts map[string][]byte
+
+// scriptOptions keeps scripts to the plain dialect, no while loops or recursion
+var scriptOptions = &syntax.FileOptions{}
+
+// LoadScripts runs every embedded and modded script file once to collect the functions it defines
+func LoadScripts() error {
+	scripts := make(map[string]starlark.StringDict)
+
+	names, err := fs.Glob(embeddedScripts, "scripts/*.star")
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		src, err := embeddedScripts.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		if scripts[path.Base(name)], err = execScript(path.Base(name), src); err != nil {
+			return err
+		}
+	}
+	for name, src := range modScripts {
+		if scripts[name], err = execScript(name, src); err != nil {
+			return err
+		}
+	}
+
+	Scripts = scripts
+	return nil
+}
+
+// execScript runs a script file's top level under the usual limits and freezes what it defines
+func execScript(name string, src []byte) (starlark.StringDict, error) {
+	thread, stop := scriptThread(name)
+	defer stop()
+	globals, err := starlark.ExecFileOptions(scriptOptions, thread, name, src, nil)
+	if err != nil {
+		return nil, err
+	}
+	globals.Freeze()
+	return globals, nil
+}
+
+// scriptThread returns a thread with the step and time limits set, stop must be called once the script is done
+// the thread has no load function, so scripts can't reach anything outside the API they are given
+func scriptThread(name string) (*starlark.Thread, func()) {
+	thread := &starlark.Thread{
+		Name:  name,
+		Print: func(*starlark.Thread, string) {}, // there's no console to print to under the TUI
+	}
+	thread.SetMaxExecutionSteps(ScriptMaxSteps)
+	timer := time.AfterFunc(ScriptTimeout, func() { thread.Cancel("script took too long") })
+	return thread, func() { timer.Stop() }
+}
+
+// splitScriptRef splits a script reference such as "artifact.star:reveal_origin" into its file and function
+func splitScriptRef(ref string) (file, function string, err error) {
+	file, function, ok := strings.Cut(ref, ":")
+	if !ok || !strings.HasSuffix(file, ".star") || function == "" {
+		return "", "", fmt.Errorf("script %q should look like file.star:function", ref)
+	}
+	return file, function, nil
+}
+
+// findScript returns the function a script reference points at
+func findScript(ref string) (starlark.Callable, error) {
+	if Scripts == nil {
+		if err := LoadScripts(); err != nil {
+			return nil, err
+		}
+	}
+	file, function, err := splitScriptRef(ref)
+	if err != nil {
+		return nil, err
+	}
+	globals, ok := Scripts[file]
+	if !ok {
+		return nil, fmt.Errorf("script file %s doesn't exist", file)
+	}
+	fn, ok := globals[function].(starlark.Callable)
+	if !ok {
+		return nil, fmt.Errorf("%s doesn't define a function %s", file, function)
+	}
+	return fn, nil
+}
+
+// CheckScript reports a script reference that doesn't point at a loaded function
+func CheckScript(ref string) error {
+	_, err := findScript(ref)
+	return err
+}
+
+// RunEventScript calls an event choice's script, which may read the game state and ask for effects,
+// extra dialogue, a follow-up event or new quests
+func RunEventScript(ref string, save *FullGameSave) (ScriptResult, error) {
+	ctx := &scriptContext{save: save, canAct: true}
+	_, err := callScript(ref, ctx)
+	return ctx.result, err
+}
+
+// RunObjectiveScript calls a script objective's check, which reads the game state and returns whether the objective is met
+func RunObjectiveScript(ref string, m Mission, save *FullGameSave) (bool, error) {
+	ctx := &scriptContext{save: save, mission: &m}
+	value, err := callScript(ref, ctx)
+	if err != nil {
+		return false, err
+	}
+	met, ok := value.(starlark.Bool)
+	if !ok {
+		return false, fmt.Errorf("%s returned %s, objective scripts must return True or False", ref, value.Type())
+	}
+	return bool(met), nil
+}
+
+// callScript calls the referenced function with the game API as its only argument
+// a panic inside the interpreter is turned into an error so a broken script can't take the game down
+func callScript(ref string, ctx *scriptContext) (value starlark.Value, err error) {
+	fn, err := findScript(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	thread, stop := scriptThread(ref)
+	defer stop()
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%s: %v", ref, r)
+		}
+	}()
+
+	value, err = starlark.Call(thread, fn, starlark.Tuple{ctx.api()}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ref, err)
+	}
+	return value, nil
+}
+
+// scriptContext is the game state a script call can see and what it asked for
+type scriptContext struct {
+	save    *FullGameSave
+	mission *Mission // the mission an objective script is checking, nil for event scripts
+	canAct  bool     // objective scripts only read the game state
+	result  ScriptResult
+}
+
+// api builds the game object handed to a script
+// the state is a snapshot, scripts change the game only through the action functions
+func (c *scriptContext) api() *starlarkstruct.Struct {
+	save := c.save
+	planet := save.Ship.Location.GetFullPlanet(save.GameMap)
+
+	ship := starlarkstruct.FromStringDict(starlark.String("ship"), starlark.StringDict{
+		"name":        starlark.String(save.Ship.ShipName),
+		"fuel":        starlark.MakeInt(save.Ship.Fuel),
+		"max_fuel":    starlark.MakeInt(save.Ship.MaxFuel),
+		"hull":        starlark.MakeInt(save.Ship.HullIntegrity),
+		"max_hull":    starlark.MakeInt(save.Ship.MaxHullIntegrity),
+		"shields":     starlark.MakeInt(save.Ship.ShieldStrength),
+		"max_shields": starlark.MakeInt(save.Ship.MaxShieldStrength),
+		"engine":      starlark.MakeInt(save.Ship.EngineHealth),
+		"food":        starlark.MakeInt(save.Ship.Food),
+		"system":      starlark.String(save.Ship.Location.StarSystemName),
+		"planet":      starlark.String(save.Ship.Location.PlanetName),
+		"planet_type": starlark.String(planet.Type),
+	})
+
+	var crew []starlark.Value
+	for _, member := range save.Crew {
+		crew = append(crew, starlarkstruct.FromStringDict(starlark.String("crew_member"), starlark.StringDict{
+			"name":   starlark.String(member.Name),
+			"role":   starlark.String(member.Role),
+			"degree": starlark.MakeInt(member.Degree),
+			"health": starlark.MakeInt(member.Health),
+			"morale": starlark.MakeInt(member.Morale),
+		}))
+	}
+
+	var mission starlark.Value = starlark.None
+	if c.mission != nil {
+		mission = starlarkstruct.FromStringDict(starlark.String("mission"), starlark.StringDict{
+			"title":    starlark.String(c.mission.Title),
+			"quest_id": starlark.String(c.mission.QuestID),
+			"faction":  starlark.String(c.mission.Faction),
+		})
+	}
+
+	return starlarkstruct.FromStringDict(starlark.String("game"), starlark.StringDict{
+		"ship":    ship,
+		"crew":    starlark.NewList(crew),
+		"credits": starlark.MakeInt(save.Player.Credits),
+		"level":   starlark.MakeInt(save.Player.Level),
+		"clock":   starlark.MakeInt(save.GameMetadata.ClockHours),
+		"mission": mission,
+
+		"cargo":           starlark.NewBuiltin("cargo", c.cargo),
+		"standing":        starlark.NewBuiltin("standing", c.standing),
+		"has_role":        starlark.NewBuiltin("has_role", c.hasRole),
+		"quest_completed": starlark.NewBuiltin("quest_completed", c.questCompleted),
+
+		"effect":      starlark.NewBuiltin("effect", c.effect),
+		"say":         starlark.NewBuiltin("say", c.say),
+		"chain":       starlark.NewBuiltin("chain", c.chain),
+		"offer_quest": starlark.NewBuiltin("offer_quest", c.offerQuest),
+	})
+}
+
+// cargo(item) returns how many units of an item are in the hold
+func (c *scriptContext) cargo(_ *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var item string
+	if err := starlark.UnpackPositionalArgs(fn.Name(), args, kwargs, 1, &item); err != nil {
+		return nil, err
+	}
+	return starlark.MakeInt(CargoQuantity(c.save.Ship.Cargo, item)), nil
+}
+
+// standing(faction) returns the player's standing with a faction
+func (c *scriptContext) standing(_ *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var faction string
+	if err := starlark.UnpackPositionalArgs(fn.Name(), args, kwargs, 1, &faction); err != nil {
+		return nil, err
+	}
+	return starlark.MakeInt(Standing(c.save.Player.Reputation, faction)), nil
+}
+
+// has_role(role, degree=0) reports whether a living crew member has the role at the degree or above
+func (c *scriptContext) hasRole(_ *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var role string
+	var degree int
+	if err := starlark.UnpackArgs(fn.Name(), args, kwargs, "role", &role, "degree?", &degree); err != nil {
+		return nil, err
+	}
+	for _, member := range c.save.Crew {
+		if string(member.Role) == role && member.Health > 0 && member.Degree >= degree {
+			return starlark.True, nil
+		}
+	}
+	return starlark.False, nil
+}
+
+// quest_completed(id) reports whether a quest has been completed
+func (c *scriptContext) questCompleted(_ *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var id string
+	if err := starlark.UnpackPositionalArgs(fn.Name(), args, kwargs, 1, &id); err != nil {
+		return nil, err
+	}
+	return starlark.Bool(slices.Contains(c.save.Quests.Completed, id)), nil
+}
+
+// effect(key, amount) applies an effect once the event closes, keys are the same as in events.json
+func (c *scriptContext) effect(_ *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var key string
+	var amount int
+	if err := c.action(fn, args, kwargs, &key, &amount); err != nil {
+		return nil, err
+	}
+	if err := ValidateEffectKey(key); err != nil {
+		return nil, err
+	}
+	if c.result.Effects == nil {
+		c.result.Effects = make(map[string]int)
+	}
+	c.result.Effects[key] += amount
+	return starlark.None, nil
+}
+
+// say(line) adds a line to the outcome the player reads
+func (c *scriptContext) say(_ *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var line string
+	if err := c.action(fn, args, kwargs, &line); err != nil {
+		return nil, err
+	}
+	c.result.Dialogue = append(c.result.Dialogue, line)
+	return starlark.None, nil
+}
+
+// chain(event_id) leads into a follow-up event once this one closes
+func (c *scriptContext) chain(_ *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var id int
+	if err := c.action(fn, args, kwargs, &id); err != nil {
+		return nil, err
+	}
+	if FindEvent(id) == nil {
+		return nil, fmt.Errorf("%s: event %d doesn't exist", fn.Name(), id)
+	}
+	c.result.Next = id
+	return starlark.None, nil
+}
+
+// offer_quest(id) offers a quest from the quest graph once its prerequisites are met
+func (c *scriptContext) offerQuest(_ *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var id string
+	if err := c.action(fn, args, kwargs, &id); err != nil {
+		return nil, err
+	}
+	if FindQuest(id) == nil {
+		return nil, fmt.Errorf("%s: quest %s doesn't exist", fn.Name(), id)
+	}
+	c.result.Quests = append(c.result.Quests, id)
+	return starlark.None, nil
+}
+
+// action unpacks the arguments of a function that changes the game, which only event scripts may call
+func (c *scriptContext) action(fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple, vars ...any) error {
+	if !c.canAct {
+		return fmt.Errorf("%s: objective scripts can only read the game state", fn.Name())
+	}
+	return starlark.UnpackPositionalArgs(fn.Name(), args, kwargs, len(vars), vars...)
+}
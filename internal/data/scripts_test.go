@@ -0,0 +1,167 @@
+package data
+
+import (
+	"strings"
+	"testing"
+)
+
+// loadTestScript loads src as a modded test.star alongside the embedded scripts
+func loadTestScript(t *testing.T, src string) error {
+	t.Helper()
+	modScripts = map[string][]byte{"test.star": []byte(src)}
+	t.Cleanup(func() {
+		modScripts = nil
+		Scripts = nil
+	})
+	return LoadScripts()
+}
+
+func TestLoadScriptsSandbox(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		wantErr string // empty when the script should load
+	}{
+		{name: "plain function", src: "def check(game):\n    return game.credits > 0\n"},
+		{name: "while loop", src: "def spin(game):\n    while True:\n        pass\n", wantErr: "while"},
+		{name: "top level loop", src: "for i in range(3):\n    pass\n", wantErr: "for loop not within a function"},
+		{name: "load", src: "load(\"other.star\", \"x\")\n", wantErr: "load"},
+		{name: "runaway top level", src: "x = [i for i in range(1000000)]\n", wantErr: "too many steps"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := loadTestScript(t, tt.src)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Fatalf("LoadScripts() error = %v, want none", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Fatalf("LoadScripts() error = %v, want one mentioning %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCallScriptSandbox(t *testing.T) {
+	src := `
+def met(game):
+    return game.credits >= 100
+
+def not_bool(game):
+    return 1
+
+def acts(game):
+    game.effect("fuel", 5)
+    return True
+
+def runaway(game):
+    total = 0
+    for i in range(10000000):
+        total += i
+    return True
+
+def recurse(game):
+    return recurse(game)
+
+def fails(game):
+    return 1 // 0
+`
+	if err := loadTestScript(t, src); err != nil {
+		t.Fatalf("LoadScripts() error = %v", err)
+	}
+	save := &FullGameSave{Player: Player{Credits: 150}}
+
+	tests := []struct {
+		name    string
+		ref     string
+		want    bool
+		wantErr string
+	}{
+		{name: "objective met", ref: "test.star:met", want: true},
+		{name: "not a bool", ref: "test.star:not_bool", wantErr: "must return True or False"},
+		{name: "objective can't act", ref: "test.star:acts", wantErr: "can only read the game state"},
+		{name: "step limit", ref: "test.star:runaway", wantErr: "too many steps"},
+		{name: "recursion", ref: "test.star:recurse", wantErr: "called recursively"},
+		{name: "runtime error", ref: "test.star:fails", wantErr: "division by zero"},
+		{name: "missing function", ref: "test.star:nothing", wantErr: "doesn't define a function"},
+		{name: "missing file", ref: "nothing.star:met", wantErr: "doesn't exist"},
+		{name: "bad reference", ref: "met", wantErr: "should look like file.star:function"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RunObjectiveScript(tt.ref, Mission{}, save)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("RunObjectiveScript(%q) error = %v, want one mentioning %q", tt.ref, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RunObjectiveScript(%q) error = %v", tt.ref, err)
+			}
+			if got != tt.want {
+				t.Errorf("RunObjectiveScript(%q) = %v, want %v", tt.ref, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunEventScriptActions(t *testing.T) {
+	src := `
+def reward(game):
+    game.effect("fuel", 5)
+    game.effect("fuel", 5)
+    game.say("The tanks fill up.")
+
+def bad_effect(game):
+    game.effect("warp", 1)
+`
+	if err := loadTestScript(t, src); err != nil {
+		t.Fatalf("LoadScripts() error = %v", err)
+	}
+
+	result, err := RunEventScript("test.star:reward", &FullGameSave{})
+	if err != nil {
+		t.Fatalf("RunEventScript() error = %v", err)
+	}
+	if result.Effects["fuel"] != 10 {
+		t.Errorf("fuel effect = %d, want 10", result.Effects["fuel"])
+	}
+	if len(result.Dialogue) != 1 {
+		t.Errorf("dialogue = %v, want one line", result.Dialogue)
+	}
+
+	if _, err := RunEventScript("test.star:bad_effect", &FullGameSave{}); err == nil {
+		t.Error("RunEventScript() with an unknown effect key returned no error")
+	}
+}
+
+func TestResolveChoiceChain(t *testing.T) {
+	if err := loadTestScript(t, "def follow_up(game):\n    game.chain(1)\n"); err != nil {
+		t.Fatalf("LoadScripts() error = %v", err)
+	}
+	if err := LoadEvents(); err != nil {
+		t.Fatalf("LoadEvents() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		combat    string
+		wantNext  int
+		wantError bool
+	}{
+		{name: "chains on", wantNext: 1},
+		{name: "not after a fight", combat: "pirate_raider", wantNext: 0, wantError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			choice := Choice{Text: "Go", Outcome: "Gone.", Combat: tt.combat, Script: "test.star:follow_up"}
+			outcome := ResolveChoice(&FullGameSave{}, choice)
+			if outcome.Next != tt.wantNext {
+				t.Errorf("outcome leads to event %d, want %d", outcome.Next, tt.wantNext)
+			}
+			if (outcome.Error != "") != tt.wantError {
+				t.Errorf("outcome error = %q, want an error: %v", outcome.Error, tt.wantError)
+			}
+		})
+	}
+}
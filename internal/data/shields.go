@@ -0,0 +1,69 @@
+package data
+
+const (
+	// ShieldModuleName is the module that powers shield regeneration
+	ShieldModuleName = "Shield Emitter"
+
+	shieldRegenBase = 1 // shield points restored per cycle without a working emitter
+)
+
+// ModuleLevel returns the level of the named module, or 0 if it is missing or damaged
+func ModuleLevel(ship Ship, name string) int {
+	for _, module := range ship.Modules {
+		if module.Name == name && module.Status != "damaged" {
+			return module.Level
+		}
+	}
+	return 0
+}
+
+// AbsorbDamage applies incoming damage to the shields first and the hull with whatever gets through
+// it returns how much the shields absorbed and how much reached the hull
+func AbsorbDamage(ship *Ship, damage int) (absorbed int, hullDamage int) {
+	if damage <= 0 {
+		return 0, 0
+	}
+	absorbed = min(damage, ship.ShieldStrength)
+	ship.ShieldStrength -= absorbed
+
+	hullDamage = damage - absorbed
+	ship.HullIntegrity = max(ship.HullIntegrity-hullDamage, 0)
+	return absorbed, hullDamage
+}
+
+// ShieldRegenRate returns how many shield points are restored per regeneration cycle
+// a working shield emitter adds its level, shield research its bonus, and each Engineer assigned to shield maintenance adds their degree
+func ShieldRegenRate(ship Ship, crew []CrewMember) int {
+	rate := shieldRegenBase + ModuleLevel(ship, ShieldModuleName) + ship.Research.Bonus().ShieldRegen
+	for _, member := range AssignedToTask(crew, CrewTaskShields) {
+		if member.Role == CrewRoleEngineer {
+			rate += member.Degree
+		}
+	}
+	return rate
+}
+
+// RegenerateShields restores shields for the given number of cycles and returns how much was restored
+func RegenerateShields(ship *Ship, crew []CrewMember, cycles int) int {
+	if cycles <= 0 || ship.ShieldStrength >= ship.MaxShieldStrength {
+		return 0
+	}
+	before := ship.ShieldStrength
+	ship.ShieldStrength = min(ship.ShieldStrength+ShieldRegenRate(*ship, crew)*cycles, ship.MaxShieldStrength)
+	return ship.ShieldStrength - before
+}
+
+// ensureShieldModule gives older saves the shield emitter that new ships start with
+func ensureShieldModule(ship *Ship) {
+	for _, module := range ship.Modules {
+		if module.Name == ShieldModuleName {
+			return
+		}
+	}
+	ship.Modules = append(ship.Modules, Module{
+		ModuleId: generateRandomID("MOD_SHD_"),
+		Name:     ShieldModuleName,
+		Level:    1,
+		Status:   "operational",
+	})
+}
@@ -0,0 +1,268 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	_ "embed"
+)
+
+//go:embed species.json
+var embeddedSpecies []byte
+
+// XPFirstContact is the experience awarded the first time the crew meets a species
+const XPFirstContact = 60
+
+// Temperament decides how a species takes each negotiation approach
+type Temperament string
+
+const (
+	TemperamentProud   Temperament = "proud"   // values courtesy, bristles at shows of strength
+	TemperamentMartial Temperament = "martial" // respects strength, takes gifts as bribes
+	TemperamentCurious Temperament = "curious" // wants to learn above all else
+)
+
+// Label returns the temperament with its first letter capitalised, e.g. "Proud"
+func (t Temperament) Label() string {
+	if t == "" {
+		return ""
+	}
+	return strings.ToUpper(string(t[:1])) + string(t[1:])
+}
+
+// TradeGood is an item a species' market sells or buys, and its base price
+type TradeGood struct {
+	Name  string `json:"name"`
+	Price int    `json:"price"`
+}
+
+// SpeciesMemory is something the player has done that a species remembers at the negotiating table
+type SpeciesMemory struct {
+	Conditions []QuestCondition `json:"conditions"` // the memory counts when all of these hold
+	Modifier   int              `json:"modifier"`   // % added to the chance every approach succeeds
+	Text       string           `json:"text"`
+}
+
+// Species is an alien species the crew can make contact with
+// each species speaks for its faction, which holds its home system
+type Species struct {
+	ID          string          `json:"id"`
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Faction     string          `json:"faction"`
+	Temperament Temperament     `json:"temperament"`
+	Language    string          `json:"language"`
+	Envoy       string          `json:"envoy"`    // who speaks for the species at negotiations
+	Portrait    string          `json:"portrait"` // glyph shown beside the envoy's name
+	HomeSystem  StarSystem      `json:"homeSystem"`
+	TradeGoods  []TradeGood     `json:"tradeGoods"` // what the species' market sells
+	Wants       []TradeGood     `json:"wants"`      // what the species' market buys
+	Memories    []SpeciesMemory `json:"memories,omitempty"`
+}
+
+// Contact is the crew's history with a species
+type Contact struct {
+	Species    string             `json:"species"`
+	MetAt      int                `json:"metAt"`             // game clock hour of first contact
+	Talks      int                `json:"talks,omitempty"`   // negotiations concluded
+	Outcome    NegotiationOutcome `json:"outcome,omitempty"` // result of the most recent negotiation
+	MarketOpen bool               `json:"marketOpen,omitempty"`
+}
+
+var AllSpecies []Species
+
+// LoadSpecies loads the alien species from the embedded species.json
+func LoadSpecies() error {
+	var species []Species
+	if err := json.NewDecoder(bytes.NewReader(embeddedSpecies)).Decode(&species); err != nil {
+		return err
+	}
+	var errs []error
+	for _, s := range species {
+		if err := s.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	AllSpecies = species
+	return errors.Join(errs...)
+}
+
+// Validate checks a species for authoring mistakes
+func (s Species) Validate() error {
+	var errs []error
+	fail := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf("species %q: %s", s.ID, fmt.Sprintf(format, args...)))
+	}
+	if s.ID == "" || s.Name == "" {
+		fail("needs an id and a name")
+	}
+	if s.Faction == "" {
+		fail("needs a faction")
+	}
+	if _, ok := temperamentModifiers[s.Temperament]; !ok {
+		fail("unknown temperament %q", s.Temperament)
+	}
+	if s.HomeSystem.Name == "" || len(s.HomeSystem.Planets) == 0 {
+		fail("needs a home system with at least one planet")
+	}
+	for _, good := range slices.Concat(s.TradeGoods, s.Wants) {
+		if good.Name == "" || good.Price <= 0 {
+			fail("trade goods need a name and a price")
+		}
+	}
+	for _, memory := range s.Memories {
+		for _, condition := range memory.Conditions {
+			if err := condition.Validate(); err != nil {
+				fail("memory %q: %v", memory.Text, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// FindSpecies returns the species with the given id, or nil if none exists
+// the species are loaded on first use
+func FindSpecies(id string) *Species {
+	if len(AllSpecies) == 0 {
+		if err := LoadSpecies(); err != nil {
+			return nil
+		}
+	}
+	for i := range AllSpecies {
+		if AllSpecies[i].ID == id {
+			return &AllSpecies[i]
+		}
+	}
+	return nil
+}
+
+// SpeciesOfFaction returns the species a faction speaks for, or nil if it is a human faction
+func SpeciesOfFaction(faction string) *Species {
+	for i := range AllSpecies {
+		if AllSpecies[i].Faction == faction {
+			return &AllSpecies[i]
+		}
+	}
+	return nil
+}
+
+// SpeciesHomeTo returns the species whose home system is the given star system, or nil if none lives there
+func SpeciesHomeTo(system string) *Species {
+	for i := range AllSpecies {
+		if AllSpecies[i].HomeSystem.Name == system {
+			return &AllSpecies[i]
+		}
+	}
+	return nil
+}
+
+// FindContact returns the crew's history with a species, or nil if they have never met
+func FindContact(save *FullGameSave, species string) *Contact {
+	for i := range save.Contacts {
+		if save.Contacts[i].Species == species {
+			return &save.Contacts[i]
+		}
+	}
+	return nil
+}
+
+// MakeContact records the first meeting with a species, it reports false if the crew has met them before
+func MakeContact(save *FullGameSave, species Species) bool {
+	if FindContact(save, species.ID) != nil {
+		return false
+	}
+	save.Contacts = append(save.Contacts, Contact{Species: species.ID, MetAt: save.GameMetadata.ClockHours})
+	WriteLog(save, LogDiplomacy, "First contact with the %s.", species.Name)
+	return true
+}
+
+// MarketOpen reports whether a species trades with the player
+func MarketOpen(save *FullGameSave, species string) bool {
+	contact := FindContact(save, species)
+	return contact != nil && contact.MarketOpen
+}
+
+// SellPrice returns what a species' market charges for one of its goods
+func SellPrice(save *FullGameSave, species Species, good TradeGood) int {
+	return AdjustPrice(good.Price, PriceModifier(save.Player.Reputation, species.Faction))
+}
+
+// BuyPrice returns what a species' market pays for one of the goods it wants
+func BuyPrice(save *FullGameSave, species Species, good TradeGood) int {
+	return AdjustPrice(good.Price, RewardModifier(save.Player.Reputation, species.Faction))
+}
+
+// canTrade reports why the player can't use a species' market right now, nil when they can
+func canTrade(save *FullGameSave, species Species) error {
+	if !MarketOpen(save, species.ID) {
+		return fmt.Errorf("the %s haven't opened their market to you", species.Name)
+	}
+	if save.Ship.Location.StarSystemName != species.HomeSystem.Name {
+		return fmt.Errorf("the %s only trade in the %s system", species.Name, species.HomeSystem.Name)
+	}
+	return nil
+}
+
+// BuyTradeGood buys one of a species' goods into the cargo hold and returns the price paid
+func BuyTradeGood(save *FullGameSave, species Species, name string) (int, error) {
+	if err := canTrade(save, species); err != nil {
+		return 0, err
+	}
+	i := slices.IndexFunc(species.TradeGoods, func(g TradeGood) bool { return g.Name == name })
+	if i < 0 {
+		return 0, fmt.Errorf("the %s don't sell %s", species.Name, name)
+	}
+	price := SellPrice(save, species, species.TradeGoods[i])
+	switch {
+	case save.Player.Credits < price:
+		return 0, fmt.Errorf("not enough credits for the %s", name)
+	case save.Ship.Cargo.UsedCapacity >= save.Ship.Cargo.Capacity:
+		return 0, fmt.Errorf("no room in the cargo hold")
+	}
+
+	save.Player.Credits -= price
+	AddCargoItem(&save.Ship.Cargo, name, 1)
+	WriteLog(save, LogPurchase, "Bought %s from the %s for %d credits.", name, species.Name, price)
+	return price, nil
+}
+
+// SellTradeGood sells one unit of cargo a species wants and returns the price paid
+func SellTradeGood(save *FullGameSave, species Species, name string) (int, error) {
+	if err := canTrade(save, species); err != nil {
+		return 0, err
+	}
+	i := slices.IndexFunc(species.Wants, func(g TradeGood) bool { return g.Name == name })
+	if i < 0 {
+		return 0, fmt.Errorf("the %s have no use for %s", species.Name, name)
+	}
+	if CargoQuantity(save.Ship.Cargo, name) == 0 {
+		return 0, fmt.Errorf("there is no %s in the cargo hold", name)
+	}
+
+	price := BuyPrice(save, species, species.Wants[i])
+	RemoveCargoItem(&save.Ship.Cargo, name, 1)
+	save.Player.Credits += price
+	save.GameMetadata.Stats.Earn(price)
+	WriteLog(save, LogPurchase, "Sold %s to the %s for %d credits.", name, species.Name, price)
+	return price, nil
+}
+
+// ensureSpeciesHomeworlds adds the home systems of the alien species to galaxies that don't have them yet
+func ensureSpeciesHomeworlds(gameMap *GameMap) {
+	if len(AllSpecies) == 0 {
+		if err := LoadSpecies(); err != nil {
+			return
+		}
+	}
+	for _, species := range AllSpecies {
+		if !slices.ContainsFunc(gameMap.StarSystems, func(s StarSystem) bool { return s.Name == species.HomeSystem.Name }) {
+			system := species.HomeSystem
+			system.Planets = slices.Clone(system.Planets)
+			gameMap.StarSystems = append(gameMap.StarSystems, system)
+		}
+	}
+}
@@ -0,0 +1,339 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	_ "embed"
+)
+
+//go:embed stations.json
+var embeddedStations []byte
+
+// StationPlanetType is the planet type the map uses for a space station
+const StationPlanetType = "Space Station"
+
+// station tuning
+const (
+	MedbayCostPerPoint = 4 // credits per point of health the medbay restores
+)
+
+// StationService is something a station offers the ships that dock with it
+type StationService string
+
+const (
+	ServiceRefuel   StationService = "refuel"
+	ServiceRepair   StationService = "repair"
+	ServiceShipyard StationService = "shipyard"
+	ServiceHiring   StationService = "hiring"
+	ServiceMarket   StationService = "market"
+	ServiceMedbay   StationService = "medbay"
+)
+
+// AllServices lists the services in the order a station shows them
+var AllServices = []StationService{ServiceHiring, ServiceShipyard, ServiceRefuel, ServiceRepair, ServiceMarket, ServiceMedbay}
+
+// defaultServices are what a station on the map offers when it isn't in stations.json
+var defaultServices = []StationService{ServiceRefuel, ServiceRepair, ServiceHiring}
+
+// StockItem is a good a station's market sells, its base price and how many the station holds each day
+type StockItem struct {
+	Name  string `json:"name"`
+	Price int    `json:"price"`
+	Stock int    `json:"stock"`
+}
+
+// Station is a space station the ship can dock with
+// each one sits on a Space Station planet of the map and belongs to a faction
+type Station struct {
+	ID           string           `json:"id"`
+	Name         string           `json:"name"`
+	Description  string           `json:"description"`
+	StarSystem   string           `json:"starSystem"`
+	Planet       string           `json:"planet"`                 // the Space Station planet the station is, added to the map if it is missing
+	Coordinates  Coordinates      `json:"coordinates"`            // where the planet goes when it has to be added
+	Faction      string           `json:"faction,omitempty"`      // owner of the station, the faction holding its system when empty
+	Services     []StationService `json:"services"`               // what the station offers
+	FuelPrice    int              `json:"fuelPrice,omitempty"`    // credits per unit of fuel before perks
+	PricePercent int              `json:"pricePercent,omitempty"` // % of the base price charged for repairs, upgrades and hires
+	Recruits     []CrewRole       `json:"recruits,omitempty"`     // roles that sign on here, any when empty
+	Market       []StockItem      `json:"market,omitempty"`       // what the station's market sells
+	Wants        []TradeGood      `json:"wants,omitempty"`        // what the station's market buys
+	Bulletins    []string         `json:"bulletins,omitempty"`    // notices pinned to the bulletin board
+}
+
+// StationState is what has happened at a station since the game began
+type StationState struct {
+	Station  string         `json:"station"`
+	StockDay int            `json:"stockDay"`        // game clock day Sold was counted on, the market restocks every day
+	Sold     map[string]int `json:"sold,omitempty"`  // units of each good the player has bought that day
+	Board    []Mission      `json:"board,omitempty"` // missions posted on the bulletin board
+}
+
+var AllStations []Station
+
+// LoadStations loads the space stations from the embedded stations.json
+func LoadStations() error {
+	var stations []Station
+	if err := json.NewDecoder(bytes.NewReader(embeddedStations)).Decode(&stations); err != nil {
+		return err
+	}
+	var errs []error
+	for _, s := range stations {
+		if err := s.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	AllStations = stations
+	return errors.Join(errs...)
+}
+
+// Validate checks a station for authoring mistakes
+func (s Station) Validate() error {
+	var errs []error
+	fail := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf("station %q: %s", s.ID, fmt.Sprintf(format, args...)))
+	}
+	if s.ID == "" || s.Name == "" {
+		fail("needs an id and a name")
+	}
+	if s.StarSystem == "" || s.Planet == "" {
+		fail("needs a star system and a planet")
+	}
+	for _, service := range s.Services {
+		if !slices.Contains(AllServices, service) {
+			fail("unknown service %q", service)
+		}
+	}
+	if s.Offers(ServiceMarket) && len(s.Market) == 0 && len(s.Wants) == 0 {
+		fail("has a market with nothing to trade")
+	}
+	for _, item := range s.Market {
+		if item.Name == "" || item.Price <= 0 || item.Stock <= 0 {
+			fail("market goods need a name, a price and some stock")
+		}
+	}
+	for _, good := range s.Wants {
+		if good.Name == "" || good.Price <= 0 {
+			fail("wanted goods need a name and a price")
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Offers reports whether the station provides a service
+func (s Station) Offers(service StationService) bool {
+	return slices.Contains(s.Services, service)
+}
+
+// Fuel returns what the station charges the player per unit of fuel before the price modifier
+func (s Station) Fuel(player Player) int {
+	if s.FuelPrice == 0 {
+		return FuelPrice(player)
+	}
+	return max(s.FuelPrice-PerkRank(player, PerkFrugalFueler), 1)
+}
+
+// StationAt returns the station at a location, or nil if there is no station there
+// stations on the map that aren't in stations.json get the basic services
+func StationAt(gameMap GameMap, loc Location) *Station {
+	planet := loc.GetFullPlanet(gameMap)
+	if planet.Type != StationPlanetType {
+		return nil
+	}
+	if len(AllStations) == 0 {
+		if err := LoadStations(); err != nil {
+			return nil
+		}
+	}
+	station := Station{
+		ID:         loc.StarSystemName + "/" + loc.PlanetName,
+		Name:       loc.PlanetName,
+		StarSystem: loc.StarSystemName,
+		Planet:     loc.PlanetName,
+		Services:   defaultServices,
+	}
+	if i := slices.IndexFunc(AllStations, func(s Station) bool {
+		return s.StarSystem == loc.StarSystemName && s.Planet == loc.PlanetName
+	}); i >= 0 {
+		station = AllStations[i]
+	}
+	if station.Faction == "" {
+		station.Faction = ControllingFaction(gameMap, loc)
+	}
+	return &station
+}
+
+// StationPriceModifier returns the percentage of the base price a station charges the player today
+// it follows the player's standing with the owner, the day's market drift and the station's own prices
+func StationPriceModifier(save *FullGameSave, station Station) int {
+	percent := PriceModifier(save.Player.Reputation, station.Faction) *
+		MarketDrift(save.Ship.Location, Day(save.GameMetadata.ClockHours)) / 100
+	if station.PricePercent > 0 {
+		percent = percent * station.PricePercent / 100
+	}
+	return percent
+}
+
+// stationState returns what has happened at a station, restocking its market when a new day has started
+func stationState(save *FullGameSave, station Station) *StationState {
+	day := Day(save.GameMetadata.ClockHours)
+	i := slices.IndexFunc(save.Stations, func(s StationState) bool { return s.Station == station.ID })
+	if i < 0 {
+		save.Stations = append(save.Stations, StationState{Station: station.ID, StockDay: day})
+		i = len(save.Stations) - 1
+	}
+	state := &save.Stations[i]
+	if state.StockDay != day {
+		state.StockDay = day
+		state.Sold = nil
+	}
+	return state
+}
+
+// StockLeft returns how many units of a good a station has left to sell today
+func StockLeft(save *FullGameSave, station Station, name string) int {
+	i := slices.IndexFunc(station.Market, func(item StockItem) bool { return item.Name == name })
+	if i < 0 {
+		return 0
+	}
+	sold := 0
+	day := Day(save.GameMetadata.ClockHours)
+	for _, state := range save.Stations {
+		if state.Station == station.ID && state.StockDay == day {
+			sold = state.Sold[name]
+		}
+	}
+	return max(station.Market[i].Stock-sold, 0)
+}
+
+// StationSellPrice returns what a station's market charges for one of its goods
+func StationSellPrice(save *FullGameSave, station Station, item StockItem) int {
+	return AdjustPrice(item.Price, StationPriceModifier(save, station))
+}
+
+// StationBuyPrice returns what a station's market pays for one of the goods it wants
+// better standing with the owner and a good market day both pay more
+func StationBuyPrice(save *FullGameSave, station Station, good TradeGood) int {
+	return AdjustPrice(good.Price, RewardModifier(save.Player.Reputation, station.Faction)*
+		MarketDrift(save.Ship.Location, Day(save.GameMetadata.ClockHours))/100)
+}
+
+// BuyStationGood buys one unit of a station's goods into the cargo hold and returns the price paid
+func BuyStationGood(save *FullGameSave, station Station, name string) (int, error) {
+	if !station.Offers(ServiceMarket) {
+		return 0, fmt.Errorf("%s has no market", station.Name)
+	}
+	i := slices.IndexFunc(station.Market, func(item StockItem) bool { return item.Name == name })
+	if i < 0 {
+		return 0, fmt.Errorf("%s doesn't sell %s", station.Name, name)
+	}
+	price := StationSellPrice(save, station, station.Market[i])
+	switch {
+	case StockLeft(save, station, name) == 0:
+		return 0, fmt.Errorf("%s is sold out of %s until tomorrow", station.Name, name)
+	case save.Player.Credits < price:
+		return 0, fmt.Errorf("not enough credits for the %s", name)
+	case save.Ship.Cargo.UsedCapacity >= save.Ship.Cargo.Capacity:
+		return 0, fmt.Errorf("no room in the cargo hold")
+	}
+
+	state := stationState(save, station)
+	if state.Sold == nil {
+		state.Sold = map[string]int{}
+	}
+	state.Sold[name]++
+	save.Player.Credits -= price
+	AddCargoItem(&save.Ship.Cargo, name, 1)
+	WriteLog(save, LogPurchase, "Bought %s at %s for %d credits.", name, station.Name, price)
+	return price, nil
+}
+
+// SellStationGood sells one unit of cargo a station wants and returns the price paid
+func SellStationGood(save *FullGameSave, station Station, name string) (int, error) {
+	if !station.Offers(ServiceMarket) {
+		return 0, fmt.Errorf("%s has no market", station.Name)
+	}
+	i := slices.IndexFunc(station.Wants, func(g TradeGood) bool { return g.Name == name })
+	if i < 0 {
+		return 0, fmt.Errorf("%s has no use for %s", station.Name, name)
+	}
+	if CargoQuantity(save.Ship.Cargo, name) == 0 {
+		return 0, fmt.Errorf("there is no %s in the cargo hold", name)
+	}
+
+	price := StationBuyPrice(save, station, station.Wants[i])
+	RemoveCargoItem(&save.Ship.Cargo, name, 1)
+	save.Player.Credits += price
+	save.GameMetadata.Stats.Earn(price)
+	WriteLog(save, LogPurchase, "Sold %s at %s for %d credits.", name, station.Name, price)
+	return price, nil
+}
+
+// TreatmentCost returns what a station's medbay charges to bring every injured crew member back to full health
+func TreatmentCost(save *FullGameSave, station Station) int {
+	points := 0
+	for _, member := range save.Crew {
+		if member.Health > 0 {
+			points += 100 - member.Health
+		}
+	}
+	return AdjustPrice(points*MedbayCostPerPoint, StationPriceModifier(save, station))
+}
+
+// TreatCrew heals every injured crew member in a station's medbay and returns what it cost
+func TreatCrew(save *FullGameSave, station Station) (int, error) {
+	if !station.Offers(ServiceMedbay) {
+		return 0, fmt.Errorf("%s has no medbay", station.Name)
+	}
+	cost := TreatmentCost(save, station)
+	switch {
+	case cost == 0:
+		return 0, fmt.Errorf("nobody aboard needs treatment")
+	case save.Player.Credits < cost:
+		return 0, fmt.Errorf("treatment costs %d credits", cost)
+	}
+
+	save.Player.Credits -= cost
+	for i := range save.Crew {
+		if save.Crew[i].Health > 0 {
+			save.Crew[i].Health = 100
+		}
+	}
+	WriteLog(save, LogCrew, "The crew were treated in the medbay at %s for %d credits.", station.Name, cost)
+	return cost, nil
+}
+
+// ensureStations adds the stations in stations.json to the map where their star system has no such planet yet
+// a station's faction also claims its planet, so it is honoured wherever the map decides who is in control
+func ensureStations(gameMap *GameMap) {
+	if len(AllStations) == 0 {
+		if err := LoadStations(); err != nil {
+			return
+		}
+	}
+	for _, station := range AllStations {
+		for i := range gameMap.StarSystems {
+			system := &gameMap.StarSystems[i]
+			if system.Name != station.StarSystem {
+				continue
+			}
+			j := slices.IndexFunc(system.Planets, func(p Planet) bool { return p.Name == station.Planet })
+			if j < 0 {
+				system.Planets = append(system.Planets, Planet{
+					Name:         station.Planet,
+					Type:         StationPlanetType,
+					Coordinates:  station.Coordinates,
+					Requirements: []CrewRequirement{{Role: string(CrewRolePilot), Degree: 1, Count: 1}},
+				})
+				j = len(system.Planets) - 1
+			}
+			if system.Planets[j].Faction == "" {
+				system.Planets[j].Faction = station.Faction
+			}
+		}
+	}
+}
@@ -0,0 +1,273 @@
+package data
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// stranded tuning
+const (
+	BeaconMinHours      = 6               // soonest a distress beacon is answered
+	BeaconMaxHours      = 18              // latest a distress beacon is answered
+	BeaconRescueFuel    = 25              // fuel a rescuer transfers to the ship
+	BeaconPirates       = "pirate_raider" // enemy ship that answers the beacon when it isn't a rescuer
+	beaconRescueChance  = 50              // % chance the beacon is answered by a rescuer before standing
+	pirateSpacePenalty  = 30              // % less chance of a rescue in space the Pirate Clan holds
+	TowBaseCost         = 150             // credits a tow costs before the distance
+	TowCostPerDistance  = 10              // credits a tow costs for every unit of distance to the station
+	TowReputationCost   = 10              // standing lost with the station's owner when they tow the ship in as a favour
+	TowFuel             = 5               // fuel a tow crew leaves in the tanks
+	JettisonFuelPerUnit = 2               // fuel recovered from each unit of cargo fed to the reactor
+	fuelCellItem        = "Fuel Cell"     // cargo that burns best in the reactor
+	fuelCellFuel        = 25              // fuel recovered from a fuel cell
+	rescueFaction       = "GalacticUnion" // faction that runs the rescue service and tows ships in stationless space
+	pirateFaction       = "PirateClan"    // faction whose space rescuers avoid
+)
+
+// StrandedState is how a ship out of fuel is getting on with its recovery
+// it is cleared once the ship has fuel again
+type StrandedState struct {
+	BeaconAnswersAt int  `json:"beaconAnswersAt,omitempty"` // clock hour the beacon is answered, 0 until it is lit
+	BeaconSpent     bool `json:"beaconSpent,omitempty"`     // the beacon has been answered and can't be used again
+}
+
+// RecoveryOption is a way for a stranded ship to get fuel again
+type RecoveryOption int
+
+const (
+	RecoverBeacon RecoveryOption = iota
+	RecoverTowCredits
+	RecoverTowReputation
+	RecoverJettison
+)
+
+// AllRecoveryOptions lists the recovery options in the order they are offered
+var AllRecoveryOptions = []RecoveryOption{RecoverBeacon, RecoverTowCredits, RecoverTowReputation, RecoverJettison}
+
+func (o RecoveryOption) String() string {
+	return [...]string{"Broadcast a distress beacon", "Pay for a tow", "Call in a favour for a tow", "Jettison cargo for fuel"}[o]
+}
+
+// Strand marks the ship as stranded and reports whether it has only just run dry
+func Strand(save *FullGameSave) bool {
+	if save.Stranded != nil {
+		return false
+	}
+	save.Stranded = &StrandedState{}
+	return true
+}
+
+// BeaconLit reports whether a distress beacon is broadcasting and still waiting for an answer
+func BeaconLit(save *FullGameSave) bool {
+	return save.Stranded != nil && save.Stranded.BeaconAnswersAt > 0 && !save.Stranded.BeaconSpent
+}
+
+// BeaconHoursLeft returns how long until the beacon is answered
+func BeaconHoursLeft(save *FullGameSave) int {
+	if !BeaconLit(save) {
+		return 0
+	}
+	return max(save.Stranded.BeaconAnswersAt-save.GameMetadata.ClockHours, 0)
+}
+
+// CanUseRecovery returns why a recovery option isn't open to the ship, or nil if it is
+func CanUseRecovery(save *FullGameSave, option RecoveryOption) error {
+	if save.Stranded == nil {
+		return fmt.Errorf("the ship isn't stranded")
+	}
+	switch option {
+	case RecoverBeacon:
+		if save.Stranded.BeaconSpent {
+			return fmt.Errorf("the beacon has already been answered")
+		}
+	case RecoverTowCredits:
+		_, cost, ok := TowOffer(save)
+		if !ok {
+			return fmt.Errorf("no station in range will send a tow")
+		}
+		if save.Player.Credits < cost {
+			return fmt.Errorf("a tow costs %d credits", cost)
+		}
+	case RecoverTowReputation:
+		station, _, ok := TowOffer(save)
+		if !ok {
+			return fmt.Errorf("no station in range will send a tow")
+		}
+		faction := TowFaction(station)
+		if TierFor(Standing(save.Player.Reputation, faction)) < TierNeutral {
+			return fmt.Errorf("the %s won't do you any favours", FactionName(faction))
+		}
+	case RecoverJettison:
+		if len(save.Ship.Cargo.Items) == 0 {
+			return fmt.Errorf("the cargo hold is empty")
+		}
+		if SpareCargo(save) == 0 {
+			return fmt.Errorf("everything in the hold is due for delivery")
+		}
+	}
+	return nil
+}
+
+// CanRecover reports whether a stranded ship still has any way of getting fuel
+// a beacon waiting for an answer or a station at hand with fuel the player can afford both count
+func CanRecover(save *FullGameSave) bool {
+	if BeaconLit(save) || StationFuelInReach(save) {
+		return true
+	}
+	for _, option := range AllRecoveryOptions {
+		if CanUseRecovery(save, option) == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// StationFuelInReach reports whether the ship sits at a station that will sell it fuel the player can afford
+func StationFuelInReach(save *FullGameSave) bool {
+	station := StationAt(save.GameMap, save.Ship.Location)
+	if station == nil || !station.Offers(ServiceRefuel) || !CanDock(save.Player.Reputation, station.Faction) {
+		return false
+	}
+	return save.Player.Credits >= AdjustPrice(station.Fuel(save.Player), StationPriceModifier(save, *station))
+}
+
+// BroadcastBeacon lights the distress beacon and returns how many hours until it is answered
+func BroadcastBeacon(save *FullGameSave) int {
+	hours := BeaconMinHours + rand.Intn(BeaconMaxHours-BeaconMinHours+1)
+	save.Stranded.BeaconAnswersAt = save.GameMetadata.ClockHours + hours
+	return hours
+}
+
+// RescueChance returns the % chance the beacon is answered by a rescuer rather than pirates
+// the Union's rescue service comes quicker for friends, and rarely into pirate space
+func RescueChance(save *FullGameSave) int {
+	chance := beaconRescueChance + Standing(save.Player.Reputation, rescueFaction)/2
+	if ControllingFaction(save.GameMap, save.Ship.Location) == pirateFaction {
+		chance -= pirateSpacePenalty
+	}
+	return max(min(chance, 90), 10)
+}
+
+// AnswerBeacon settles who answers the beacon and reports whether it was a rescuer
+// a rescuer fills the tanks a little, pirates are left for the caller to fight
+func AnswerBeacon(save *FullGameSave) bool {
+	save.Stranded.BeaconSpent = true
+	if rand.Intn(100) >= RescueChance(save) {
+		return false
+	}
+	save.Ship.Fuel = min(save.Ship.Fuel+BeaconRescueFuel, save.Ship.MaxFuel)
+	return true
+}
+
+// TowOffer returns the nearest station that will tow the ship in and refuel it, and what the tow costs in credits
+func TowOffer(save *FullGameSave) (Station, int, bool) {
+	from := save.Ship.Location
+	ls := NewLocationService(save.GameMap)
+	var nearest *Station
+	best := 0
+	for _, system := range save.GameMap.StarSystems {
+		for _, planet := range system.Planets {
+			if planet.Type != StationPlanetType {
+				continue
+			}
+			station := StationAt(save.GameMap, NewLocationFromPlanet(system, planet))
+			if station == nil || !station.Offers(ServiceRefuel) || !CanDock(save.Player.Reputation, station.Faction) {
+				continue
+			}
+			distance := ls.CalculateDistance(from.Coordinates, planet.Coordinates, from.StarSystemName, system.Name)
+			if nearest == nil || distance < best {
+				nearest, best = station, distance
+			}
+		}
+	}
+	if nearest == nil {
+		return Station{}, 0, false
+	}
+	return *nearest, TowBaseCost + TowCostPerDistance*best, true
+}
+
+// TowFaction returns the faction that tows the ship to a station as a favour
+func TowFaction(station Station) string {
+	if station.Faction == "" {
+		return rescueFaction
+	}
+	return station.Faction
+}
+
+// Tow hauls the ship to the nearest station, paid for in credits or in standing with the station's owner
+// it returns the station and how many hours the tow took
+func Tow(save *FullGameSave, byReputation bool) (Station, int, error) {
+	option := RecoverTowCredits
+	if byReputation {
+		option = RecoverTowReputation
+	}
+	if err := CanUseRecovery(save, option); err != nil {
+		return Station{}, 0, err
+	}
+	station, cost, _ := TowOffer(save)
+	if byReputation {
+		ChangeReputation(&save.Player.Reputation, TowFaction(station), -TowReputationCost)
+	} else {
+		save.Player.Credits -= cost
+	}
+
+	to := Location{StarSystemName: station.StarSystem, PlanetName: station.Planet}
+	to.Coordinates = to.GetFullPlanet(save.GameMap).Coordinates
+	distance := NewLocationService(save.GameMap).CalculateDistance(save.Ship.Location.Coordinates, to.Coordinates, save.Ship.Location.StarSystemName, to.StarSystemName)
+	save.Ship.Location = to
+	save.Ship.Fuel = min(save.Ship.Fuel+TowFuel, save.Ship.MaxFuel)
+	DiscoverLocation(&save.GameMap, to)
+	return station, 4 + distance/5, nil
+}
+
+// MissionCargo returns how much of each item the active missions still have to deliver
+func MissionCargo(missions []Mission) map[string]int {
+	needed := make(map[string]int)
+	for _, mission := range missions {
+		if !MissionActive(mission) {
+			continue
+		}
+		for _, objective := range mission.Objectives {
+			if objective.Type == ObjectiveDeliver && !objective.Done {
+				needed[objective.Item] += max(objective.Quantity, 1)
+			}
+		}
+	}
+	return needed
+}
+
+// SpareCargo returns how many units in the hold aren't needed for a delivery and can be jettisoned
+func SpareCargo(save *FullGameSave) int {
+	needed := MissionCargo(save.Missions)
+	units := 0
+	for _, item := range save.Ship.Cargo.Items {
+		units += max(item.Quantity-needed[item.Name], 0)
+	}
+	return units
+}
+
+// JettisonCargo feeds the cargo hold to the reactor and returns the units lost and the fuel that fitted in the tank
+// cargo the active missions still have to deliver is kept, fuel cells are worth far more than anything else in the hold
+func JettisonCargo(save *FullGameSave) (int, int) {
+	needed := MissionCargo(save.Missions)
+	units, fuel := 0, 0
+	var kept []CargoItem
+	for _, item := range save.Ship.Cargo.Items {
+		spare := max(item.Quantity-needed[item.Name], 0)
+		if spare < item.Quantity {
+			item.Quantity -= spare
+			kept = append(kept, item)
+		}
+		units += spare
+		if item.Name == fuelCellItem {
+			fuel += fuelCellFuel * spare
+		} else {
+			fuel += JettisonFuelPerUnit * spare
+		}
+	}
+	save.Ship.Cargo.Items = kept
+	RecalculateCargo(&save.Ship.Cargo)
+	before := save.Ship.Fuel
+	save.Ship.Fuel = min(save.Ship.Fuel+fuel, save.Ship.MaxFuel)
+	return units, save.Ship.Fuel - before
+}
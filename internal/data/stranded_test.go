@@ -0,0 +1,130 @@
+package data
+
+import (
+	"slices"
+	"testing"
+)
+
+// newStrandedSave returns a save with an empty tank at Rock, a short hop from the station at Port
+// the beacon has already been answered, there are no credits and the hold is empty, so nothing can help yet
+func newStrandedSave() *FullGameSave {
+	return &FullGameSave{
+		GameMap: GameMap{StarSystems: []StarSystem{{
+			Name: "Sol",
+			Planets: []Planet{
+				{Name: "Rock", Coordinates: Coordinates{X: 0, Y: 0, Z: 0}},
+				{Name: "Port", Type: StationPlanetType, Coordinates: Coordinates{X: 3, Y: 4, Z: 0}},
+			},
+		}}},
+		Ship: Ship{
+			MaxFuel:  100,
+			Location: Location{StarSystemName: "Sol", PlanetName: "Rock"},
+		},
+		Player:   Player{Reputation: Reputation{EnemyFactions: map[string]int{rescueFaction: -20}}},
+		Stranded: &StrandedState{BeaconSpent: true},
+	}
+}
+
+func TestCanRecover(t *testing.T) {
+	ore := []CargoItem{{Name: "Ore", Quantity: 2}}
+	deliverOre := []Objective{{Type: ObjectiveDeliver, Item: "Ore", Quantity: 2}}
+
+	// each row changes the stranded save only where it says so
+	tests := []struct {
+		name       string
+		notYet     bool // the ship hasn't been stranded
+		beacon     *StrandedState
+		credits    int
+		friendly   bool // the rescue faction owes the player a favour
+		noStation  bool
+		docked     bool
+		cargo      []CargoItem
+		deliver    []Objective
+		delivering MissionStatus
+		want       bool
+	}{
+		{name: "out of options", want: false},
+		{name: "not stranded yet", notYet: true, want: false},
+		{name: "beacon unused", beacon: &StrandedState{}, want: true},
+		{name: "beacon waiting for an answer", beacon: &StrandedState{BeaconAnswersAt: 12}, want: true},
+		{name: "can pay for a tow", credits: 10_000, want: true},
+		{name: "can't pay for a tow", credits: TowBaseCost - 1, want: false},
+		{name: "owed a favour", friendly: true, want: true},
+		{name: "no station to tow to", credits: 10_000, friendly: true, noStation: true, want: false},
+		{name: "spare cargo to burn", cargo: ore, want: true},
+		{name: "only mission cargo aboard", cargo: ore, deliver: deliverOre, delivering: MissionStatusInProgress, want: false},
+		{name: "delivered cargo is spare", cargo: ore, deliver: deliverOre, delivering: MissionStatusCompleted, want: true},
+		{name: "docked with fuel to buy", docked: true, credits: 20, want: true},
+		{name: "docked without the credits for fuel", docked: true, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			save := newStrandedSave()
+			switch {
+			case tt.notYet:
+				save.Stranded = nil
+			case tt.beacon != nil:
+				save.Stranded = tt.beacon
+			}
+			save.Player.Credits = tt.credits
+			if tt.friendly {
+				save.Player.Reputation = Reputation{}
+			}
+			if tt.noStation {
+				save.GameMap.StarSystems[0].Planets = save.GameMap.StarSystems[0].Planets[:1]
+			}
+			if tt.docked {
+				save.Ship.Location.PlanetName = "Port"
+			}
+			save.Ship.Cargo.Items = slices.Clone(tt.cargo)
+			if tt.deliver != nil {
+				save.Missions = []Mission{{Status: tt.delivering, Objectives: tt.deliver}}
+			}
+
+			if got := CanRecover(save); got != tt.want {
+				t.Errorf("CanRecover() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJettisonCargo(t *testing.T) {
+	tests := []struct {
+		name      string
+		cargo     []CargoItem
+		deliver   []Objective
+		wantUnits int
+		wantFuel  int
+		wantKept  []CargoItem
+	}{
+		{name: "empty hold"},
+		{name: "everything goes", cargo: []CargoItem{{Name: "Ore", Quantity: 3}, {Name: fuelCellItem, Quantity: 1}},
+			wantUnits: 4, wantFuel: 3*JettisonFuelPerUnit + fuelCellFuel},
+		{name: "mission cargo kept", cargo: []CargoItem{{Name: "Ore", Quantity: 3}, {Name: "Medicine", Quantity: 1}},
+			deliver:   []Objective{{Type: ObjectiveDeliver, Item: "Ore", Quantity: 2}, {Type: ObjectiveDeliver, Item: "Medicine"}},
+			wantUnits: 1, wantFuel: JettisonFuelPerUnit, wantKept: []CargoItem{{Name: "Ore", Quantity: 2}, {Name: "Medicine", Quantity: 1}}},
+		{name: "done deliveries don't count", cargo: []CargoItem{{Name: "Ore", Quantity: 3}},
+			deliver:   []Objective{{Type: ObjectiveDeliver, Item: "Ore", Quantity: 2, Done: true}},
+			wantUnits: 3, wantFuel: 3 * JettisonFuelPerUnit},
+		{name: "fuel capped at the tank", cargo: []CargoItem{{Name: fuelCellItem, Quantity: 10}},
+			wantUnits: 10, wantFuel: 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			save := newStrandedSave()
+			save.Ship.Cargo.Items = slices.Clone(tt.cargo)
+			save.Missions = []Mission{{Status: MissionStatusInProgress, Objectives: tt.deliver}}
+
+			units, fuel := JettisonCargo(save)
+			if units != tt.wantUnits || fuel != tt.wantFuel {
+				t.Errorf("JettisonCargo() = %d units, %d fuel, want %d units, %d fuel", units, fuel, tt.wantUnits, tt.wantFuel)
+			}
+			if !slices.Equal(save.Ship.Cargo.Items, tt.wantKept) {
+				t.Errorf("hold after jettison = %v, want %v", save.Ship.Cargo.Items, tt.wantKept)
+			}
+			if save.Ship.Fuel != tt.wantFuel {
+				t.Errorf("fuel in the tank = %d, want %d", save.Ship.Fuel, tt.wantFuel)
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package data
+
+// base ship stats before any upgrades are applied
+const (
+	BaseMaxFuel           = 100
+	BaseMaxHullIntegrity  = 100
+	BaseMaxShieldStrength = 50
+	BaseCargoCapacity     = 100
+	BaseWeaponPower       = 5
+
+	defaultUpgradeMaxLevel = 10
+)
+
+// UpgradeType identifies one of the ship's upgrade tracks
+// the order matches the list shown in the space station's upgrade tab
+type UpgradeType int
+
+const (
+	UpgradeEngine UpgradeType = iota
+	UpgradeWeaponSystems
+	UpgradeCargoExpansion
+	UpgradeHullPlating
+	UpgradeShieldGenerator
+	UpgradeSpecimenVault
+)
+
+// AllUpgradeTypes lists every upgrade track in display order
+var AllUpgradeTypes = []UpgradeType{
+	UpgradeEngine,
+	UpgradeWeaponSystems,
+	UpgradeCargoExpansion,
+	UpgradeHullPlating,
+	UpgradeShieldGenerator,
+	UpgradeSpecimenVault,
+}
+
+func (u UpgradeType) String() string {
+	switch u {
+	case UpgradeEngine:
+		return "Engine"
+	case UpgradeWeaponSystems:
+		return "Weapon Systems"
+	case UpgradeCargoExpansion:
+		return "Cargo Expansion"
+	case UpgradeHullPlating:
+		return "Hull Plating"
+	case UpgradeShieldGenerator:
+		return "Shield Generator"
+	case UpgradeSpecimenVault:
+		return "Specimen Vault"
+	default:
+		return ""
+	}
+}
+
+// FindUpgradeType returns the upgrade track with the given name
+func FindUpgradeType(name string) (UpgradeType, bool) {
+	for _, t := range AllUpgradeTypes {
+		if t.String() == name {
+			return t, true
+		}
+	}
+	return 0, false
+}
+
+// UpgradeEffect is how much a single level of an upgrade adds to each stat
+type UpgradeEffect struct {
+	MaxFuel            int
+	MaxHullIntegrity   int
+	MaxShieldStrength  int
+	CargoCapacity      int
+	WeaponPower        int
+	CollectionCapacity int
+}
+
+// upgradeEffects holds the per-level bonus of each upgrade track
+var upgradeEffects = map[UpgradeType]UpgradeEffect{
+	UpgradeEngine:          {MaxFuel: 10},
+	UpgradeWeaponSystems:   {WeaponPower: 5},
+	UpgradeCargoExpansion:  {CargoCapacity: 25},
+	UpgradeHullPlating:     {MaxHullIntegrity: 15},
+	UpgradeShieldGenerator: {MaxShieldStrength: 10},
+	UpgradeSpecimenVault:   {CollectionCapacity: 25},
+}
+
+// upgradeBaseCosts is the price of the first level of each upgrade track
+var upgradeBaseCosts = map[UpgradeType]int{
+	UpgradeEngine:          100,
+	UpgradeWeaponSystems:   200,
+	UpgradeCargoExpansion:  300,
+	UpgradeHullPlating:     250,
+	UpgradeShieldGenerator: 250,
+	UpgradeSpecimenVault:   200,
+}
+
+// upgradeFreeLevels is the level every new ship starts with, these levels grant no bonus
+var upgradeFreeLevels = map[UpgradeType]int{
+	UpgradeEngine: 1,
+}
+
+// ShipStats are the ship's stats derived from its upgrades
+type ShipStats struct {
+	MaxFuel            int
+	MaxHullIntegrity   int
+	MaxShieldStrength  int
+	CargoCapacity      int
+	WeaponPower        int
+	CollectionCapacity int
+}
+
+// Level returns a pointer to the upgrade level for the given track
+func (u *Upgrades) Level(t UpgradeType) *UpgradeLevel {
+	switch t {
+	case UpgradeEngine:
+		return &u.Engine
+	case UpgradeWeaponSystems:
+		return &u.WeaponSystems
+	case UpgradeCargoExpansion:
+		return &u.CargoExpansion
+	case UpgradeHullPlating:
+		return &u.HullPlating
+	case UpgradeShieldGenerator:
+		return &u.ShieldGenerator
+	case UpgradeSpecimenVault:
+		return &u.SpecimenVault
+	default:
+		return nil
+	}
+}
+
+// UpgradeCost returns the price of raising the upgrade from currentLevel to currentLevel+1
+// each level costs more than the last: base * nextLevel * (nextLevel + 1) / 2
+func UpgradeCost(t UpgradeType, currentLevel int) int {
+	next := currentLevel + 1
+	return upgradeBaseCosts[t] * next * (next + 1) / 2
+}
+
+// CalculateShipStats works out the ship's stats for the given upgrade levels
+func CalculateShipStats(u Upgrades) ShipStats {
+	stats := ShipStats{
+		MaxFuel:            BaseMaxFuel,
+		MaxHullIntegrity:   BaseMaxHullIntegrity,
+		MaxShieldStrength:  BaseMaxShieldStrength,
+		CargoCapacity:      BaseCargoCapacity,
+		WeaponPower:        BaseWeaponPower,
+		CollectionCapacity: BaseCollectionCapacity,
+	}
+	for _, t := range AllUpgradeTypes {
+		levels := u.Level(t).CurrentLevel - upgradeFreeLevels[t]
+		if levels <= 0 {
+			continue
+		}
+		effect := upgradeEffects[t]
+		stats.MaxFuel += effect.MaxFuel * levels
+		stats.MaxHullIntegrity += effect.MaxHullIntegrity * levels
+		stats.MaxShieldStrength += effect.MaxShieldStrength * levels
+		stats.CargoCapacity += effect.CargoCapacity * levels
+		stats.WeaponPower += effect.WeaponPower * levels
+		stats.CollectionCapacity += effect.CollectionCapacity * levels
+	}
+	return stats
+}
+
+// Stats works out the ship's stats from its upgrade levels and the research it has finished
+func (s Ship) Stats() ShipStats {
+	stats := CalculateShipStats(s.Upgrades)
+	research := s.Research.Bonus()
+	stats.MaxShieldStrength += research.MaxShields
+	stats.CargoCapacity += research.CargoCapacity
+	stats.CollectionCapacity += research.CollectionCapacity
+	return stats
+}
+
+// PreviewUpgrade returns the ship's stats before and after buying the next level of an upgrade
+func PreviewUpgrade(ship Ship, t UpgradeType) (before ShipStats, after ShipStats) {
+	before = ship.Stats()
+	ship.Upgrades.Level(t).CurrentLevel++ // ship is a copy, so this doesn't touch the caller's upgrades
+	after = ship.Stats()
+	return before, after
+}
+
+// ApplyUpgradeEffects writes the stats derived from upgrades and research onto the ship
+// current values are clamped so they never exceed their new maximums
+func ApplyUpgradeEffects(ship *Ship) {
+	stats := ship.Stats()
+
+	ship.MaxFuel = stats.MaxFuel
+	ship.MaxHullIntegrity = stats.MaxHullIntegrity
+	ship.MaxShieldStrength = stats.MaxShieldStrength
+	ship.Cargo.Capacity = stats.CargoCapacity
+
+	ship.Fuel = min(ship.Fuel, ship.MaxFuel)
+	ship.HullIntegrity = min(ship.HullIntegrity, ship.MaxHullIntegrity)
+	ship.ShieldStrength = min(ship.ShieldStrength, ship.MaxShieldStrength)
+}
+
+// ensureUpgradeDefaults fills in the max level for upgrade tracks missing from older saves
+func ensureUpgradeDefaults(u *Upgrades) {
+	for _, t := range AllUpgradeTypes {
+		level := u.Level(t)
+		if level.MaxLevel == 0 {
+			level.MaxLevel = defaultUpgradeMaxLevel
+		}
+	}
+}
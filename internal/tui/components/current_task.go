@@ -2,6 +2,7 @@ package components
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/dominik-merdzik/project-starbyte/internal/data"
@@ -31,9 +32,38 @@ func (c CurrentTaskComponent) Render(task *data.Mission) string {
 	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("63"))
 	labelStyle := lipgloss.NewStyle().Bold(true)
 
-	content := fmt.Sprintf("%s\n\n%s",
+	content := fmt.Sprintf("%s\n\n%s\n\n%s",
 		titleStyle.Render("Tracking Mission: "+task.Title),
 		labelStyle.Render("Status:")+" "+task.Status.String(),
+		ObjectiveChecklist(*task),
 	)
+	if c.GameSave != nil {
+		if left, ok := data.TimeLeft(*task, c.GameSave.GameMetadata.ClockHours); ok {
+			content += fmt.Sprintf("\n\n%s SD %s (%dh left)", labelStyle.Render("Deadline:"), data.Stardate(task.Deadline), left)
+		}
+	}
 	return boxStyle.Render(content)
 }
+
+// ObjectiveChecklist renders a mission's objectives, ticking off the ones already met
+// the objective the mission is waiting on is highlighted
+func ObjectiveChecklist(m data.Mission) string {
+	doneStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
+	currentStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("215")).Bold(true)
+	pendingStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+
+	current := data.CurrentObjective(&m)
+	var lines []string
+	for i, objective := range m.Objectives {
+		text := objective.Describe(m)
+		switch {
+		case objective.Done:
+			lines = append(lines, doneStyle.Render("[x] "+text))
+		case current == &m.Objectives[i] && m.Status == data.MissionStatusInProgress:
+			lines = append(lines, currentStyle.Render("[ ] "+text))
+		default:
+			lines = append(lines, pendingStyle.Render("[ ] "+text))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -1,45 +1,156 @@
 package components
 
 import (
-	"fmt"
+	"strings"
+	"time"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/dominik-merdzik/project-starbyte/internal/data"
 )
 
+// typewriter reveal speed
+const (
+	typewriterInterval = 30 * time.Millisecond
+	typewriterStep     = 2 // characters revealed every interval
+)
+
+// DialogueComponent plays a dialogue tree one node at a time, typing each line out
 type DialogueComponent struct {
-	Lines       []string
-	CurrentLine int
+	Tree         data.DialogueTree
+	Node         *data.DialogueNode
+	Choices      []data.DialogueChoice // choices on the current node the player is able to pick
+	ChoiceCursor int
+	Revealed     int // characters of the current line shown so far
+	Finished     bool
+
+	// GameSave is checked against choice conditions, it may be nil
+	GameSave *data.FullGameSave
+
+	history []*data.DialogueNode // nodes already passed, for going back
+	reveal  int                  // counts node changes so stale ticks from an earlier line are dropped
+}
+
+// DialogueTickMsg reveals more of the current line
+type DialogueTickMsg struct {
+	reveal int
+}
+
+// NewDialogueComponent starts a dialogue tree at its first node
+func NewDialogueComponent(tree data.DialogueTree, gameSave *data.FullGameSave) DialogueComponent {
+	d := DialogueComponent{Tree: tree, GameSave: gameSave}
+	d.enter(tree.First())
+	return d
+}
+
+// Start begins typing out the current line
+func (d *DialogueComponent) Start() tea.Cmd {
+	return d.tick()
+}
+
+// Update reveals more of the current line on every tick until all of it is shown
+func (d *DialogueComponent) Update(msg tea.Msg) tea.Cmd {
+	tick, ok := msg.(DialogueTickMsg)
+	if !ok || tick.reveal != d.reveal || !d.Revealing() {
+		return nil
+	}
+	d.Revealed += typewriterStep
+	if d.Revealing() {
+		return d.tick()
+	}
+	return nil
+}
+
+// Revealing reports whether the current line is still being typed out
+func (d DialogueComponent) Revealing() bool {
+	return d.Node != nil && d.Revealed < len([]rune(d.Node.Text))
 }
 
-// NewDialogueComponentFromMission creates a new DialogueComponent from a mission's dialogue
-func NewDialogueComponentFromMission(lines []string) DialogueComponent {
-	return DialogueComponent{
-		Lines:       lines,
-		CurrentLine: 0,
+// Next shows the rest of the line if it's still being typed, otherwise moves on to the next node
+// a node with choices waits for one to be picked with Choose
+func (d *DialogueComponent) Next() tea.Cmd {
+	if d.Node == nil {
+		d.Finished = true
+		return nil
 	}
+	if d.Revealing() {
+		d.Revealed = len([]rune(d.Node.Text))
+		return nil
+	}
+	if len(d.Choices) > 0 {
+		return nil
+	}
+	d.history = append(d.history, d.Node)
+	d.enter(d.Tree.Find(d.Node.Next))
+	return d.tick()
+}
+
+// Previous goes back to the line before, a choice already made can't be taken back
+func (d *DialogueComponent) Previous() tea.Cmd {
+	if len(d.history) == 0 {
+		return nil
+	}
+	previous := d.history[len(d.history)-1]
+	if len(previous.Choices) > 0 {
+		return nil
+	}
+	d.history = d.history[:len(d.history)-1]
+	d.enter(previous)
+	return d.tick()
+}
+
+// Choose picks the choice under the cursor and follows it, the caller applies its effects and branch
+func (d *DialogueComponent) Choose() (*data.DialogueChoice, tea.Cmd) {
+	if !d.AwaitingChoice() {
+		return nil, nil
+	}
+	choice := d.Choices[d.ChoiceCursor]
+	d.history = append(d.history, d.Node)
+	d.enter(d.Tree.Find(choice.Next))
+	return &choice, d.tick()
+}
+
+// AwaitingChoice reports whether the current line is fully shown and waiting for a choice
+func (d DialogueComponent) AwaitingChoice() bool {
+	return len(d.Choices) > 0 && !d.Revealing()
+}
+
+// MoveChoice moves the choice cursor up or down, staying within the list
+func (d *DialogueComponent) MoveChoice(delta int) {
+	d.ChoiceCursor = max(0, min(d.ChoiceCursor+delta, len(d.Choices)-1))
 }
 
-// next advances the dialogue to the next line, if available
-func (d *DialogueComponent) Next() {
-	if d.CurrentLine < len(d.Lines) {
-		d.CurrentLine++
+// enter moves the dialogue onto a node, a missing node ends it
+func (d *DialogueComponent) enter(node *data.DialogueNode) {
+	d.Node = node
+	d.Revealed = 0
+	d.ChoiceCursor = 0
+	d.Choices = nil
+	d.reveal++
+	if node == nil {
+		d.Finished = true
+		return
 	}
+	d.Choices = node.AvailableChoices(d.GameSave)
 }
 
-func (d *DialogueComponent) Previous() {
-	if d.CurrentLine > 0 {
-		d.CurrentLine--
+// tick schedules the next step of the typewriter reveal
+func (d DialogueComponent) tick() tea.Cmd {
+	if !d.Revealing() {
+		return nil
 	}
+	reveal := d.reveal
+	return tea.Tick(typewriterInterval, func(time.Time) tea.Msg { return DialogueTickMsg{reveal: reveal} })
 }
 
 // View renders the dialogue component
 // Width is an optional parameter
 func (d DialogueComponent) View(width ...int) string {
-	if len(d.Lines) == 0 || d.CurrentLine >= len(d.Lines) {
+	if d.Node == nil {
 		return "End of dialogue."
 	}
 
-	// Set default width to 120 if not specified
+	// Set default width to 100 if not specified
 	dialogueWidth := 100
 	if len(width) > 0 && width[0] > 0 {
 		dialogueWidth = width[0]
@@ -50,11 +161,31 @@ func (d DialogueComponent) View(width ...int) string {
 		Padding(1, 2).
 		BorderStyle(lipgloss.RoundedBorder()).
 		BorderForeground(lipgloss.Color("63"))
+	speakerStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Bold(true)
+
+	var content strings.Builder
+	if d.Node.Speaker != "" {
+		portrait := d.Node.Portrait
+		if portrait == "" {
+			portrait = data.DefaultPortrait
+		}
+		content.WriteString(speakerStyle.Render(portrait+" "+d.Node.Speaker) + "\n\n")
+	}
 
-	content := d.Lines[d.CurrentLine]
+	text := []rune(d.Node.Text)
+	content.WriteString(string(text[:min(d.Revealed, len(text))]))
 
-	// Add a progress indicator
-	progress := fmt.Sprintf("\n\n[%d/%d]", d.CurrentLine+1, len(d.Lines))
+	if d.AwaitingChoice() {
+		hoverStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("215")).Bold(true)
+		content.WriteString("\n")
+		for i, choice := range d.Choices {
+			if i == d.ChoiceCursor {
+				content.WriteString("\n" + hoverStyle.Render("> "+choice.Text))
+			} else {
+				content.WriteString("\n  " + choice.Text)
+			}
+		}
+	}
 
-	return dialogueStyle.Render(content + progress)
+	return dialogueStyle.Render(content.String())
 }
@@ -2,6 +2,7 @@ package components
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -13,7 +14,17 @@ type EventModel struct {
 	event      *data.Event
 	currentIdx int
 	selected   bool
+	outcome    data.Outcome // what happened after the choice was made
 	Active     bool
+
+	// Dialogue plays the event's lines before the choices are shown
+	Dialogue DialogueComponent
+
+	// GameSave is read for the crew skill checks
+	GameSave *data.FullGameSave
+
+	// ShowEffects reveals what each choice will do before it is picked (Captain's Insight perk)
+	ShowEffects bool
 }
 
 // Message to tell game.go to exit event
@@ -21,21 +32,49 @@ type EventFinishedMsg struct{}
 
 // Update ship values
 type ApplyEffectsMsg struct {
+	Event   string // title of the event, for the captain's log
+	Outcome string // what happened, for the captain's log
 	Effects map[string]int
+	Combat  string   // enemy ship to fight once the event is closed
+	Next    int      // follow-up event to start once the event is closed
+	Quests  []string // quests a script offered
 }
 
-func NewEventModel(event *data.Event) *EventModel {
+func NewEventModel(event *data.Event, gameSave *data.FullGameSave) *EventModel {
 	return &EventModel{
 		event:      event,
 		currentIdx: 0,
 		selected:   false,
 		Active:     true,
+		GameSave:   gameSave,
+		Dialogue:   NewDialogueComponent(event.Conversation(), gameSave),
 	}
 }
 
+// Init starts typing out the event's dialogue
+func (m *EventModel) Init() tea.Cmd {
+	return m.Dialogue.Start()
+}
+
 func (m *EventModel) Update(msg tea.Msg) (*EventModel, tea.Cmd) {
 	switch msg := msg.(type) {
+	case DialogueTickMsg:
+		return m, m.Dialogue.Update(msg)
 	case tea.KeyMsg:
+		// the choices only come up once the dialogue is over
+		if !m.Dialogue.Finished {
+			switch msg.String() {
+			case "enter":
+				return m, m.Dialogue.Next()
+			case "backspace":
+				return m, m.Dialogue.Previous()
+			case " ":
+				m.Dialogue.Finished = true
+			case "q":
+				return m, func() tea.Msg { return EventFinishedMsg{} }
+			}
+			return m, nil
+		}
 		switch msg.String() {
 		case "up", "k":
 			if !m.selected && m.currentIdx > 0 {
@@ -48,8 +87,10 @@ func (m *EventModel) Update(msg tea.Msg) (*EventModel, tea.Cmd) {
 		case "enter":
 			if !m.selected {
 				m.selected = true
+				m.outcome = data.ResolveChoice(m.GameSave, m.event.Choices[m.currentIdx])
+				outcome, title := m.outcome, m.event.Title
 				return m, func() tea.Msg {
-					return ApplyEffectsMsg{Effects: m.event.Choices[m.currentIdx].Effects}
+					return ApplyEffectsMsg{Event: title, Outcome: outcome.Text, Effects: outcome.Effects, Combat: outcome.Combat, Next: outcome.Next, Quests: outcome.Quests}
 				}
 			} else {
 				return m, func() tea.Msg { return EventFinishedMsg{} }
@@ -71,12 +112,21 @@ func (m *EventModel) View() string {
 	var content strings.Builder
 	content.WriteString(fmt.Sprintf("%s\n\n", m.event.Title))
 
+	if !m.Dialogue.Finished {
+		content.WriteString(m.Dialogue.View(76))
+		content.WriteString("\n\n[Enter] Continue • [Backspace] Back • [Space] Skip")
+		return style.Render(content.String())
+	}
+
 	for _, line := range m.event.Dialogue {
 		content.WriteString(line + "\n")
 	}
 
 	if m.selected {
-		content.WriteString(fmt.Sprintf("\nOutcome: %s", m.event.Choices[m.currentIdx].Outcome))
+		content.WriteString(fmt.Sprintf("\nOutcome: %s", m.outcome.Text))
+		if m.outcome.Error != "" {
+			content.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("160")).Render("\n\nScript error: " + m.outcome.Error))
+		}
 		content.WriteString("\n\nPress [Enter] to continue.")
 	} else {
 		content.WriteString("\nWhat will you do?\n")
@@ -85,9 +135,51 @@ func (m *EventModel) View() string {
 			if i == m.currentIdx {
 				cursor = "> "
 			}
-			content.WriteString(fmt.Sprintf("%s%s\n", cursor, choice.Text))
+			text := choice.Text
+			if check := choice.SkillCheck; check != nil {
+				text += fmt.Sprintf(" [%s %d%%]", check.Role, data.SkillCheckChance(m.GameSave.Crew, *check))
+			}
+			if m.ShowEffects {
+				text += lipgloss.NewStyle().Foreground(lipgloss.Color("244")).Render(describeEffects(choice))
+			}
+			content.WriteString(fmt.Sprintf("%s%s\n", cursor, text))
 		}
 	}
 
 	return style.Render(content.String())
 }
+
+// describeEffects summarises what a choice can lead to, e.g. " (fuel -10, hull -20)"
+// choices with several possible outcomes list each of them
+func describeEffects(choice data.Choice) string {
+	var outcomes []string
+	for _, outcome := range data.ChoiceOutcomes(choice) {
+		outcomes = append(outcomes, describeOutcome(outcome))
+	}
+	if choice.SkillCheck != nil {
+		outcomes = append(outcomes, "failed check: "+describeOutcome(choice.SkillCheck.Failure))
+	}
+	return " (" + strings.Join(outcomes, " | ") + ")"
+}
+
+// describeOutcome lists an outcome's effects, e.g. "fuel -10, hull -20"
+func describeOutcome(outcome data.Outcome) string {
+	keys := make([]string, 0, len(outcome.Effects))
+	for key := range outcome.Effects {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var parts []string
+	for _, key := range keys {
+		name := strings.TrimPrefix(strings.TrimPrefix(key, data.ReputationEffectPrefix), data.ItemEffectPrefix)
+		parts = append(parts, fmt.Sprintf("%s %+d", name, outcome.Effects[key]))
+	}
+	if outcome.Combat != "" {
+		parts = append(parts, "combat")
+	}
+	if len(parts) == 0 {
+		return "no effect"
+	}
+	return strings.Join(parts, ", ")
+}
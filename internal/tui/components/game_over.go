@@ -2,29 +2,88 @@ package components
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/dominik-merdzik/project-starbyte/internal/data"
 )
 
-// This struct is only necessary if we want this component to control game over logic
+// hallOfFameShown is how many of the best runs the game over screen lists
+const hallOfFameShown = 3
+
+// GameOverComponent shows the epilogue of a finished run and where it placed in the hall of fame
 type GameOverComponent struct {
-	// TODO: Show other stats we care about
-	Ship data.Ship
-	//DeathMessage string // We could pass in a death message here to specify the cause of death
+	Epilogue   data.Epilogue
+	HallOfFame []data.Epilogue
+	Place      int // the run's place in the hall of fame, 0 if it didn't make it
 }
 
-func NewGameOverComponent(ship data.Ship) GameOverComponent {
+func NewGameOverComponent(epilogue data.Epilogue, hallOfFame []data.Epilogue, place int) GameOverComponent {
 	return GameOverComponent{
-		Ship: ship,
+		Epilogue:   epilogue,
+		HallOfFame: hallOfFame,
+		Place:      place,
 	}
 }
 
 func (g GameOverComponent) View() string {
-	title := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("63")).Render("GAME OVER")
-	detailText := "The crew of the " + g.Ship.ShipName + " have perished in the depths of space.\n\nThank you for playing Starbyte.\n\n Press [Q] to quit."
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("63"))
+	labelStyle := lipgloss.NewStyle().Bold(true)
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+	highlightStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("215")).Bold(true)
+
+	e := g.Epilogue
+	title := titleStyle.Render("GAME OVER • " + strings.ToUpper(e.Cause.String()))
+	epitaph := e.Cause.Epitaph(e.ShipName)
+
+	// ----- Run statistics, in two columns -----
+	stat := func(label string, value any) string {
+		return fmt.Sprintf("%s %v", labelStyle.Render(label), value)
+	}
+	left := strings.Join([]string{
+		stat("Distance travelled:", e.DistanceTravelled),
+		stat("Missions completed:", e.MissionsCompleted),
+		stat("Credits earned:", fmt.Sprintf("%d¢", e.CreditsEarned)),
+	}, "\n")
+	right := strings.Join([]string{
+		stat("Systems visited:", e.SystemsVisited),
+		stat("Crew lost:", len(e.CrewLost)),
+		stat("Play time:", e.PlayTimeString()),
+	}, "\n")
+	stats := lipgloss.JoinHorizontal(lipgloss.Top,
+		lipgloss.NewStyle().Width(34).Render(left),
+		lipgloss.NewStyle().Width(34).Render(right),
+	)
 
-	// TODO: List crew member names
+	lost := "No one was lost under Captain " + e.Captain + "'s command."
+	if len(e.CrewLost) > 0 {
+		lost = "In memory of " + strings.Join(e.CrewLost, ", ") + "."
+	}
+
+	// ----- Hall of fame -----
+	var hall strings.Builder
+	hall.WriteString(titleStyle.Render("HALL OF FAME") + "\n")
+	for i, run := range g.HallOfFame[:min(len(g.HallOfFame), hallOfFameShown)] {
+		line := fmt.Sprintf("%d. Capt. %s of the %s • %d pts • %s", i+1, run.Captain, run.ShipName, run.Score(), run.Cause)
+		if i+1 == g.Place {
+			line = highlightStyle.Render(line + " ← this run")
+		}
+		hall.WriteString(line + "\n")
+	}
+	switch {
+	case g.Place > hallOfFameShown:
+		hall.WriteString(highlightStyle.Render(fmt.Sprintf("This run placed #%d with %d pts.", g.Place, e.Score())))
+	case g.Place == 0:
+		hall.WriteString(mutedStyle.Render(fmt.Sprintf("This run scored %d pts and didn't make the hall of fame.", e.Score())))
+	}
 
-	return fmt.Sprintf("%s\n\n%s\n\n", title, detailText)
+	return fmt.Sprintf("%s\n\n%s\n%s\n\n%s\n\n%s\n\n%s\n%s",
+		title,
+		epitaph,
+		mutedStyle.Render(fmt.Sprintf("Stardate %s • %s difficulty", e.Stardate, e.Difficulty)),
+		stats,
+		lost,
+		strings.TrimRight(hall.String(), "\n"),
+		"\nThank you for playing Starbyte. Press [Q] to quit.",
+	)
 }
@@ -3,6 +3,7 @@ package components
 import (
 	"fmt"
 
+	"github.com/charmbracelet/bubbles/progress"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/dominik-merdzik/project-starbyte/internal/data"
 )
@@ -16,16 +17,31 @@ type YutaComponent struct {
 	SuggestRefuel  bool
 	SuggestRepair  bool
 	SuggestCredits bool
+	SuggestPerk    bool
+
+	Level     int
+	XP        int // experience earned within the current level
+	XPToLevel int // experience needed to reach the next level
+	Perks     int
 }
 
 // creates a new instance of the Yuta model
-func NewYutaComponent(ship data.Ship, playerName string, credits int, version string) YutaComponent {
+func NewYutaComponent(ship data.Ship, player data.Player, credits int, version string) YutaComponent {
+	levelStart := data.XPForLevel(player.Level)
 	return YutaComponent{
 		Ship:       ship,
-		PlayerName: playerName,
+		PlayerName: player.PlayerName,
 		ShipName:   ship.ShipName,
 		Version:    version,
 
+		Level:     player.Level,
+		XP:        player.ExperiencePoints - levelStart,
+		XPToLevel: data.XPForLevel(player.Level+1) - levelStart,
+		Perks:     len(player.Perks),
+
+		// Unspent perk picks come first
+		SuggestPerk: player.PerkPicks > 0,
+
 		// If fuel 20% or less, suggest refuel
 		SuggestRefuel: ship.Fuel <= 20,
 
@@ -41,7 +57,9 @@ func (m YutaComponent) View() string {
 	var assistantText string
 
 	// Prioritized suggestions
-	if m.SuggestRefuel {
+	if m.SuggestPerk {
+		assistantText = fmt.Sprintf("%s, you've earned a new perk!\n\nPress [P] to choose one.", m.PlayerName)
+	} else if m.SuggestRefuel {
 		assistantText = fmt.Sprintf("%s, I recommend refueling the %s.\n\nFortunately, fuel prices are below market value at the nearest Station.", m.PlayerName, m.ShipName)
 	} else if m.SuggestRepair {
 		assistantText = fmt.Sprintf("%s, I recommend repairing the %s's hull.\n\nTechnicians are available at the Station.", m.PlayerName, m.ShipName)
@@ -52,6 +70,15 @@ func (m YutaComponent) View() string {
 	}
 	assistant := lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Render("^_^") // Make a cute lil robot with rounded borders
 
+	// Captain's level sits next to the robot
+	percent := 0.0
+	if m.XPToLevel > 0 {
+		percent = float64(m.XP) / float64(m.XPToLevel)
+	}
+	xpBar := progress.New(progress.WithScaledGradient("#F00065", "#008FE9"), progress.WithWidth(16), progress.WithoutPercentage()).ViewAs(percent)
+	levelText := fmt.Sprintf("Lv %d  %d/%d\n%s\nPerks: %d", m.Level, m.XP, m.XPToLevel, xpBar, m.Perks)
+	assistant = lipgloss.JoinHorizontal(lipgloss.Top, assistant, lipgloss.NewStyle().PaddingLeft(1).Render(levelText))
+
 	// TODO crew morale system
 	//moraleText := "The crew are in high spirits."
 
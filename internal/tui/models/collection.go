@@ -27,12 +27,30 @@ const (
 
 var maxBlurbLines = max(1, fixedCardHeight-4)
 
+// AnalyzeItemMsg signals game.go to have a scientist analyze an item
+type AnalyzeItemMsg struct {
+	Name string
+}
+
+// SellItemMsg signals game.go to sell an item to the station's collectors
+type SellItemMsg struct {
+	Name string
+}
+
+// DonateItemMsg signals game.go to donate an item to the local faction
+type DonateItemMsg struct {
+	Name string
+}
+
 type CollectionModel struct {
 	GameSave         *data.FullGameSave
 	width            int
 	notesCurrentPage int
 	itemsCurrentPage int
 	focusedSection   focusableSection
+	itemCursor       int // selected item, counted across every page
+
+	Message string // outcome of the last action, set by game.go
 }
 
 // NewCollectionModel initializes pagination state
@@ -50,11 +68,37 @@ func (m CollectionModel) Init() tea.Cmd {
 	return nil
 }
 
+// items returns the items held, sorted by tier and then name as they are shown
+func (m CollectionModel) items() []data.CollectionItem {
+	var items []data.CollectionItem
+	for _, item := range m.GameSave.Collection.Items {
+		if item.Quantity > 0 {
+			items = append(items, item)
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Tier != items[j].Tier {
+			return items[i].Tier < items[j].Tier
+		}
+		return items[i].Name < items[j].Name
+	})
+	return items
+}
+
+// selectedItem returns the item under the cursor
+func (m CollectionModel) selectedItem() (data.CollectionItem, bool) {
+	items := m.items()
+	if len(items) == 0 {
+		return data.CollectionItem{}, false
+	}
+	return items[min(m.itemCursor, len(items)-1)], true
+}
+
 func (m *CollectionModel) SetWidth(w int) {
 	m.width = w
 }
 
-// update remains simple for now, potentially add sorting/filtering later
+// Update pages through the notes and items, and turns the keys on the selected item into actions for game.go
 func (m CollectionModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if m.GameSave == nil {
 		return m, nil
@@ -68,12 +112,8 @@ func (m CollectionModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			totalNoteCards++
 		}
 	}
-	totalItemCards := 0
-	for _, item := range collection.Items {
-		if item.Quantity > 0 {
-			totalItemCards++
-		}
-	}
+	totalItemCards := len(m.items())
+	m.itemCursor = min(m.itemCursor, max(totalItemCards-1, 0))
 
 	totalNotePages := 1
 	if totalNoteCards > 0 {
@@ -84,37 +124,55 @@ func (m CollectionModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		totalItemPages = int(math.Ceil(float64(totalItemCards) / float64(cardsPerPage)))
 	}
 
-	switch msg := msg.(type) {
-	case tea.KeyMsg:
-		switch msg.String() {
-		case "up", "k":
-			if m.focusedSection == focusItems {
-				m.focusedSection = focusNotes
-			}
-		case "down", "j":
-			if m.focusedSection == focusNotes && totalItemCards > 0 {
-				m.focusedSection = focusItems
-			}
-		case "left", "h":
-			if m.focusedSection == focusNotes {
-				if m.notesCurrentPage > 1 {
-					m.notesCurrentPage--
-				}
-			} else if m.focusedSection == focusItems {
-				if m.itemsCurrentPage > 1 {
-					m.itemsCurrentPage--
-				}
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	// -----------------------------
+	// Item Actions
+	// -----------------------------
+	if item, ok := m.selectedItem(); ok && m.focusedSection == focusItems {
+		switch key.String() {
+		case "a":
+			return m, func() tea.Msg { return AnalyzeItemMsg{Name: item.Name} }
+		case "s":
+			return m, func() tea.Msg { return SellItemMsg{Name: item.Name} }
+		case "d":
+			return m, func() tea.Msg { return DonateItemMsg{Name: item.Name} }
+		}
+	}
+
+	// -----------------------------
+	// Browsing
+	// -----------------------------
+	switch key.String() {
+	case "up", "k":
+		if m.focusedSection == focusItems {
+			m.focusedSection = focusNotes
+		}
+	case "down", "j":
+		if m.focusedSection == focusNotes && totalItemCards > 0 {
+			m.focusedSection = focusItems
+		}
+	case "left", "h":
+		if m.focusedSection == focusNotes {
+			if m.notesCurrentPage > 1 {
+				m.notesCurrentPage--
 			}
-		case "right", "l":
-			if m.focusedSection == focusNotes {
-				if m.notesCurrentPage < totalNotePages {
-					m.notesCurrentPage++
-				}
-			} else if m.focusedSection == focusItems {
-				if m.itemsCurrentPage < totalItemPages {
-					m.itemsCurrentPage++
-				}
+		} else if m.focusedSection == focusItems {
+			// the item cursor walks through the cards, turning the page at either end
+			m.itemCursor = max(m.itemCursor-1, 0)
+			m.itemsCurrentPage = m.itemCursor/cardsPerPage + 1
+		}
+	case "right", "l":
+		if m.focusedSection == focusNotes {
+			if m.notesCurrentPage < totalNotePages {
+				m.notesCurrentPage++
 			}
+		} else if m.focusedSection == focusItems {
+			m.itemCursor = min(m.itemCursor+1, max(totalItemCards-1, 0))
+			m.itemsCurrentPage = min(m.itemCursor/cardsPerPage+1, totalItemPages)
 		}
 	}
 	return m, nil
@@ -174,6 +232,7 @@ func (m CollectionModel) View() string {
 		Width(cardWidth).
 		Height(fixedCardHeight).
 		BorderForeground(lipgloss.Color("240"))
+	selectedCardStyle := cardStyle.Copy().BorderForeground(lipgloss.Color("214"))
 	cardDetailStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("248"))
 	cardBlurbRenderStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("244")).Width(cardWidth - 2)
 	paginationStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("242"))
@@ -199,25 +258,21 @@ func (m CollectionModel) View() string {
 	}
 
 	var allItemCardStrings []string
-	sort.SliceStable(collection.Items, func(i, j int) bool {
-		if collection.Items[i].Tier != collection.Items[j].Tier {
-			return collection.Items[i].Tier < collection.Items[j].Tier
-		}
-		return collection.Items[i].Name < collection.Items[j].Name
-	})
-	for _, item := range collection.Items {
-		if item.Quantity > 0 {
-			tierColor := getTierColor(item.Tier)
-			dynamicCardTitleStyle := lipgloss.NewStyle().Bold(true).Foreground(tierColor)
-			title := dynamicCardTitleStyle.Render(item.Name)
-			details := cardDetailStyle.Render(fmt.Sprintf("Tier: %d | Qty: %d", item.Tier, item.Quantity))
-			renderedFullDesc := cardBlurbRenderStyle.Render(item.Description)
-			truncatedDesc := truncateLines(renderedFullDesc, maxBlurbLines)
-
-			content := lipgloss.JoinVertical(lipgloss.Left, title, details, truncatedDesc)
-			renderedCard := cardStyle.Render(content)
-			allItemCardStrings = append(allItemCardStrings, renderedCard)
+	items := m.items()
+	for i, item := range items {
+		tierColor := getTierColor(item.Tier)
+		dynamicCardTitleStyle := lipgloss.NewStyle().Bold(true).Foreground(tierColor)
+		title := dynamicCardTitleStyle.Render(item.Name)
+		details := cardDetailStyle.Render(fmt.Sprintf("Tier: %d | Qty: %d", item.Tier, item.Quantity))
+		renderedFullDesc := cardBlurbRenderStyle.Render(item.Description)
+		truncatedDesc := truncateLines(renderedFullDesc, maxBlurbLines)
+
+		content := lipgloss.JoinVertical(lipgloss.Left, title, details, truncatedDesc)
+		style := cardStyle
+		if m.focusedSection == focusItems && i == min(m.itemCursor, len(items)-1) {
+			style = selectedCardStyle
 		}
+		allItemCardStrings = append(allItemCardStrings, style.Render(content))
 	}
 
 	totalNoteCards := len(allNoteCardStrings)
@@ -245,14 +300,7 @@ func (m CollectionModel) View() string {
 	var mainBuilder strings.Builder
 	mainBuilder.WriteString(mainTitleStyle.Render("PLAYER Collection") + "\n")
 
-	var calculatedUsedCapacity int
-	for _, note := range collection.ResearchNotes {
-		calculatedUsedCapacity += note.Quantity
-	}
-	for _, item := range collection.Items {
-		calculatedUsedCapacity += item.Quantity
-	}
-	currentUsedCapacity := calculatedUsedCapacity
+	currentUsedCapacity := data.CollectionUsed(collection)
 	maxCapacity := collection.MaxCapacity
 	capacityStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("248"))
 	if maxCapacity > 0 {
@@ -321,6 +369,20 @@ func (m CollectionModel) View() string {
 		sections = append(sections, paginationStyle.Render("  (No items)"))
 	}
 
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Italic(true)
+	if item, ok := m.selectedItem(); ok && m.focusedSection == focusItems {
+		faction := data.ControllingFaction(m.GameSave.GameMap, m.GameSave.Ship.Location)
+		donate := "no faction here"
+		if faction != "" {
+			donate = fmt.Sprintf("+%d %s", data.DonationStanding(item), data.FactionName(faction))
+		}
+		sections = append(sections, "", hintStyle.Render(fmt.Sprintf("[a] analyze  [s] sell (%d credits at stations)  [d] donate (%s)",
+			data.CollectorPrice(item, m.GameSave.Player.Reputation, faction), donate)))
+	}
+	if m.Message != "" {
+		sections = append(sections, "", lipgloss.NewStyle().Foreground(lipgloss.Color("215")).Render(m.Message))
+	}
+
 	if len(sections) > 0 {
 		mainBuilder.WriteString(lipgloss.JoinVertical(lipgloss.Left, sections...))
 	} else {
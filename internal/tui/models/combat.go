@@ -0,0 +1,161 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/progress"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dominik-merdzik/project-starbyte/internal/data"
+)
+
+// combatLogLines is how many of the most recent log lines are shown
+const combatLogLines = 6
+
+// CombatModel runs a turn-based fight against a single enemy ship
+type CombatModel struct {
+	Encounter *data.CombatEncounter
+	Ship      data.Ship
+	Crew      []data.CrewMember
+	Cursor    int
+}
+
+// CombatTurnMsg signals game.go that the ship took damage or burned fuel during a turn
+type CombatTurnMsg struct {
+	Ship data.Ship
+}
+
+// CombatFinishedMsg signals game.go that the fight is over and the player has dismissed the result
+type CombatFinishedMsg struct {
+	Encounter *data.CombatEncounter
+	Ship      data.Ship
+}
+
+// NewCombatModel starts a fight between the player's ship and the given enemy
+func NewCombatModel(enemy data.EnemyShip, ship data.Ship, crew []data.CrewMember) CombatModel {
+	return CombatModel{
+		Encounter: data.NewCombatEncounter(enemy),
+		Ship:      ship,
+		Crew:      crew,
+		Cursor:    0,
+	}
+}
+
+func (c CombatModel) Init() tea.Cmd {
+	return nil
+}
+
+func (c CombatModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		// once the fight is decided any confirm key hands control back to the game
+		if c.Encounter.Outcome != data.CombatOngoing {
+			if msg.String() == "enter" || msg.String() == " " {
+				encounter, ship := c.Encounter, c.Ship
+				return c, func() tea.Msg {
+					return CombatFinishedMsg{Encounter: encounter, Ship: ship}
+				}
+			}
+			return c, nil
+		}
+
+		switch msg.String() {
+		case "up", "k":
+			if c.Cursor > 0 {
+				c.Cursor--
+			}
+		case "down", "j":
+			if c.Cursor < len(data.AllCombatActions)-1 {
+				c.Cursor++
+			}
+		case "enter":
+			c.Encounter.ResolveTurn(data.AllCombatActions[c.Cursor], &c.Ship, c.Crew)
+			ship := c.Ship
+			return c, func() tea.Msg {
+				return CombatTurnMsg{Ship: ship}
+			}
+		}
+	}
+	return c, nil
+}
+
+func (c CombatModel) View() string {
+	panelStyle := lipgloss.NewStyle().
+		Width(54).
+		Height(7).
+		Padding(0, 1).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("63"))
+	enemyPanelStyle := panelStyle.BorderForeground(lipgloss.Color("196"))
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("63"))
+	enemyTitleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("196"))
+	hoverStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("215")).Bold(true)
+	defaultStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("217"))
+	logStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("248"))
+	outcomeStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("229"))
+
+	bar := func(current, max int) string {
+		percent := 0.0
+		if max > 0 {
+			percent = float64(current) / float64(max)
+		}
+		return progress.New(progress.WithScaledGradient("#F00065", "#008FE9"), progress.WithWidth(30)).ViewAs(percent)
+	}
+
+	encounter := c.Encounter
+	stats := data.PlayerCombatStats(c.Ship, c.Crew)
+
+	// ----- Player panel -----
+	playerContent := fmt.Sprintf("%s\nHull    %s %d/%d\nShields %s %d/%d\nAttack %d • Accuracy %d%% • Evasion %d",
+		titleStyle.Render(c.Ship.ShipName),
+		bar(c.Ship.HullIntegrity, c.Ship.MaxHullIntegrity), c.Ship.HullIntegrity, c.Ship.MaxHullIntegrity,
+		bar(c.Ship.ShieldStrength, c.Ship.MaxShieldStrength), c.Ship.ShieldStrength, c.Ship.MaxShieldStrength,
+		stats.Attack, stats.Accuracy, stats.Evasion,
+	)
+	playerPanel := panelStyle.Render(playerContent)
+
+	// ----- Enemy panel -----
+	enemyContent := fmt.Sprintf("%s\nHull    %s %d/%d\nShields %s %d/%d\n%s",
+		enemyTitleStyle.Render(encounter.Enemy.Name),
+		bar(encounter.EnemyHull, encounter.Enemy.Hull), encounter.EnemyHull, encounter.Enemy.Hull,
+		bar(encounter.EnemyShields, encounter.Enemy.Shields), encounter.EnemyShields, encounter.Enemy.Shields,
+		logStyle.Render(encounter.Enemy.Description),
+	)
+	enemyPanel := enemyPanelStyle.Render(enemyContent)
+
+	// ----- Actions -----
+	var actions strings.Builder
+	if encounter.Outcome == data.CombatOngoing {
+		actions.WriteString(titleStyle.Render(fmt.Sprintf("Turn %d - Your orders?", encounter.Turn)) + "\n")
+		for i, action := range data.AllCombatActions {
+			if i == c.Cursor {
+				actions.WriteString(hoverStyle.Render("> "+action.String()) + "\n")
+			} else {
+				actions.WriteString(defaultStyle.Render("  "+action.String()) + "\n")
+			}
+		}
+	} else {
+		actions.WriteString(outcomeStyle.Render(strings.ToUpper(encounter.Outcome.String())) + "\n\n")
+		if encounter.Outcome == data.CombatVictory {
+			actions.WriteString(fmt.Sprintf("Loot: %d¢", encounter.Enemy.Loot.Credits))
+			for _, item := range encounter.Enemy.Loot.Items {
+				actions.WriteString(fmt.Sprintf(", %dx %s", item.Quantity, item.Name))
+			}
+			actions.WriteString("\n")
+		}
+		actions.WriteString("\nPress [Enter] to continue.")
+	}
+	actionsPanel := lipgloss.NewStyle().Width(30).Padding(1, 2).Render(actions.String())
+
+	// ----- Combat log -----
+	logLines := encounter.Log
+	if len(logLines) > combatLogLines {
+		logLines = logLines[len(logLines)-combatLogLines:]
+	}
+	logPanel := lipgloss.NewStyle().Width(82).Padding(1, 2).Render(logStyle.Render(strings.Join(logLines, "\n")))
+
+	topRow := lipgloss.JoinHorizontal(lipgloss.Top, playerPanel, enemyPanel)
+	bottomRow := lipgloss.JoinHorizontal(lipgloss.Top, actionsPanel, logPanel)
+	return lipgloss.JoinVertical(lipgloss.Left, topRow, bottomRow)
+}
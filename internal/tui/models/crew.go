@@ -28,17 +28,20 @@ type CrewMember struct {
 }
 
 // CrewUpdateMsg signals that a crew upgrade has occurred and the model should update
-type CrewUpdateMsg struct{}
+type CrewUpdateMsg struct {
+	Hours int // hours the work took on the game clock
+}
 
 // CrewModel contains all crew on board the player's ship and handles modal states
 type CrewModel struct {
 	CrewMembers         []CrewMember
 	Cursor              int
 	PopupActive         bool               // whether a modal is open
-	PopupState          string             // "main", "research", or "receipt"
+	PopupState          string             // "main", "research", "assign", or "receipt"
 	PopupOptions        []string           // options in the main modal
 	PopupCursor         int                // cursor for main modal selection
 	ResearchPopupCursor int                // cursor for research notes selection
+	AssignPopupCursor   int                // cursor for task assignment selection
 	ResearchUseCount    int                // how many research notes to use
 	ReceiptMessage      string             // message to show after applying research
 	GameSave            *data.FullGameSave // Updating crew list after hiring new member
@@ -105,7 +108,7 @@ func (c CrewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						c.PopupCursor--
 					}
 				case "down", "j":
-					if c.PopupCursor < len(c.GameSave.Crew)-1 {
+					if c.PopupCursor < len(c.PopupOptions)-1 {
 						c.PopupCursor++
 					}
 				case "enter":
@@ -115,6 +118,9 @@ func (c CrewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						c.PopupState = "research"
 						c.ResearchPopupCursor = 0
 						c.ResearchUseCount = 1
+					} else if selectedOption == "Assign Task" {
+						c.PopupState = "assign"
+						c.AssignPopupCursor = 0
 					} else if selectedOption == "Back" {
 						// close the modal
 						c.PopupActive = false
@@ -209,6 +215,31 @@ func (c CrewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						c.ReceiptMessage = fmt.Sprintf("Degree %d → %d", initialDegree, c.GameSave.Crew[c.Cursor].Degree)
 					}
 					c.PopupState = "receipt"
+					return c, func() tea.Msg { return CrewUpdateMsg{Hours: data.ResearchHours * useCount} }
+				case "b":
+					c.PopupState = "main"
+				}
+			case "assign":
+				// the last entry in the list clears the assignment
+				switch msg.String() {
+				case "up", "k":
+					if c.AssignPopupCursor > 0 {
+						c.AssignPopupCursor--
+					}
+				case "down", "j":
+					if c.AssignPopupCursor < len(data.AllCrewTasks) {
+						c.AssignPopupCursor++
+					}
+				case "enter":
+					var task data.CrewTask
+					if c.AssignPopupCursor < len(data.AllCrewTasks) {
+						task = data.AllCrewTasks[c.AssignPopupCursor]
+					}
+					member := &c.GameSave.Crew[c.Cursor]
+					data.AssignCrewTask(member, task)
+					c.ReceiptMessage = fmt.Sprintf("%s: %s", member.Name, task)
+					c.PopupState = "receipt"
+					return c, func() tea.Msg { return CrewUpdateMsg{} }
 				case "b":
 					c.PopupState = "main"
 				}
@@ -229,7 +260,7 @@ func (c CrewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// open the modal for the currently selected crew member
 				c.PopupActive = true
 				c.PopupState = "main"
-				c.PopupOptions = []string{"Do Research", "Back"}
+				c.PopupOptions = []string{"Do Research", "Assign Task", "Back"}
 				c.PopupCursor = 0
 			}
 		}
@@ -292,6 +323,20 @@ func (c CrewModel) renderCrewModal() string {
 				}
 			}
 		}
+	} else if c.PopupState == "assign" {
+		modalContent.WriteString(lipgloss.NewStyle().Bold(true).Render("Assign Task") + "\n\n")
+		options := make([]string, 0, len(data.AllCrewTasks)+1)
+		for _, task := range data.AllCrewTasks {
+			options = append(options, task.String())
+		}
+		options = append(options, "Unassign")
+		for i, option := range options {
+			if i == c.AssignPopupCursor {
+				modalContent.WriteString(fmt.Sprintf("> %s\n", lipgloss.NewStyle().Foreground(lipgloss.Color("215")).Render(option)))
+			} else {
+				modalContent.WriteString(fmt.Sprintf("  %s\n", option))
+			}
+		}
 	}
 	return style.Render(modalContent.String())
 }
@@ -344,7 +389,8 @@ func (c CrewModel) View() string {
 		crewDetails.WriteString(labelStyle.Render("Experience: ") + fmt.Sprintf("%d", crew.Experience) + "\n")
 		crewDetails.WriteString(labelStyle.Render("Master Work Level: ") + fmt.Sprintf("%d", crew.MasterWorkLevel) + "\n")
 		crewDetails.WriteString(labelStyle.Render("Morale: ") + fmt.Sprintf("%d", crew.Morale) + "\n")
-		crewDetails.WriteString(labelStyle.Render("Health: ") + fmt.Sprintf("%d", crew.Health) + "\n\n")
+		crewDetails.WriteString(labelStyle.Render("Health: ") + fmt.Sprintf("%d", crew.Health) + "\n")
+		crewDetails.WriteString(labelStyle.Render("Task: ") + crew.CurrentTask().String() + "\n\n")
 
 		// aggregate Buffs
 		crewDetails.WriteString(labelStyle.Render("Buffs:") + "\n")
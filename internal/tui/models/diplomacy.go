@@ -0,0 +1,168 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dominik-merdzik/project-starbyte/internal/data"
+)
+
+// negotiationLogLines is how many of the most recent log lines are shown
+const negotiationLogLines = 6
+
+// DiplomacyModel runs a round of talks with an alien species
+type DiplomacyModel struct {
+	Negotiation *data.Negotiation
+	GameSave    *data.FullGameSave
+	Cursor      int
+	Message     string // why the last approach couldn't be taken, set by game.go
+}
+
+// NegotiateMsg signals game.go to play out a round of talks with the chosen approach
+type NegotiateMsg struct {
+	Approach data.NegotiationApproach
+}
+
+// NegotiationFinishedMsg signals game.go that the talks are over and the player has dismissed the result
+type NegotiationFinishedMsg struct {
+	Negotiation *data.Negotiation
+}
+
+// NewDiplomacyModel opens talks with a species
+func NewDiplomacyModel(gameSave *data.FullGameSave, species data.Species) DiplomacyModel {
+	return DiplomacyModel{
+		Negotiation: data.NewNegotiation(gameSave, species),
+		GameSave:    gameSave,
+	}
+}
+
+func (d DiplomacyModel) Init() tea.Cmd {
+	return nil
+}
+
+func (d DiplomacyModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		// once the talks are over any confirm key hands control back to the game
+		if d.Negotiation.Outcome != data.NegotiationOngoing {
+			if msg.String() == "enter" || msg.String() == " " {
+				negotiation := d.Negotiation
+				return d, func() tea.Msg {
+					return NegotiationFinishedMsg{Negotiation: negotiation}
+				}
+			}
+			return d, nil
+		}
+
+		switch msg.String() {
+		case "up", "k":
+			if d.Cursor > 0 {
+				d.Cursor--
+			}
+		case "down", "j":
+			if d.Cursor < len(data.AllApproaches)-1 {
+				d.Cursor++
+			}
+		case "enter":
+			approach := data.AllApproaches[d.Cursor]
+			return d, func() tea.Msg {
+				return NegotiateMsg{Approach: approach}
+			}
+		}
+	}
+	return d, nil
+}
+
+func (d DiplomacyModel) View() string {
+	panelStyle := lipgloss.NewStyle().
+		Width(54).
+		Height(8).
+		Padding(0, 1).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("63"))
+	speciesPanelStyle := panelStyle.BorderForeground(lipgloss.Color("141"))
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("63"))
+	speciesTitleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("141"))
+	hoverStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("215")).Bold(true)
+	defaultStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("217"))
+	blockedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	logStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("248"))
+	outcomeStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("229"))
+
+	n := d.Negotiation
+	species := n.Species
+
+	// ----- Species panel -----
+	heading := species.Name
+	if n.FirstContact {
+		heading += " • First contact"
+	}
+	speciesContent := fmt.Sprintf("%s\n%s %s\nTemperament: %s\nLanguage: %s",
+		speciesTitleStyle.Render(heading),
+		species.Portrait, species.Envoy,
+		species.Temperament.Label(),
+		logStyle.Render(species.Language),
+	)
+	speciesPanel := speciesPanelStyle.Render(speciesContent)
+
+	// ----- Talks panel -----
+	round := fmt.Sprintf("Round %d of %d", min(n.Round, data.NegotiationRounds), data.NegotiationRounds)
+	talksContent := fmt.Sprintf("%s\n%s\nRapport: %+d\n", titleStyle.Render("Negotiation"), round, n.Rapport)
+	if len(n.Memories) > 0 {
+		talksContent += "They remember:\n"
+		for _, memory := range n.Memories {
+			talksContent += logStyle.Render("• "+memory) + "\n"
+		}
+	}
+	talksPanel := panelStyle.Render(talksContent)
+
+	// ----- Approaches -----
+	var approaches strings.Builder
+	if n.Outcome == data.NegotiationOngoing {
+		approaches.WriteString(titleStyle.Render("Your approach?") + "\n")
+		for i, approach := range data.AllApproaches {
+			line := approach.String()
+			if approach != data.ApproachBreakOff {
+				line = fmt.Sprintf("%-30s %3d%%", line, n.Chance(d.GameSave, approach))
+			}
+			switch {
+			case i == d.Cursor:
+				approaches.WriteString(hoverStyle.Render("> "+line) + "\n")
+			case n.CanUse(d.GameSave, approach) != nil:
+				approaches.WriteString(blockedStyle.Render("  "+line) + "\n")
+			default:
+				approaches.WriteString(defaultStyle.Render("  "+line) + "\n")
+			}
+		}
+		switch data.AllApproaches[d.Cursor] {
+		case data.ApproachGift:
+			approaches.WriteString(logStyle.Render(fmt.Sprintf("\nCosts %d¢", data.GiftCost)))
+		case data.ApproachShare:
+			approaches.WriteString(logStyle.Render("\nUses your lowest research note"))
+		}
+	} else {
+		approaches.WriteString(outcomeStyle.Render(strings.ToUpper(n.Outcome.String())) + "\n\n")
+		approaches.WriteString(fmt.Sprintf("Standing with the %s: %+d\n", data.FactionName(species.Faction), n.StandingChange()))
+		if n.Outcome == data.NegotiationAccord {
+			approaches.WriteString(fmt.Sprintf("Their market in %s is open to you.\n", species.HomeSystem.Name))
+		}
+		approaches.WriteString("\nPress [Enter] to continue.")
+	}
+	if d.Message != "" {
+		approaches.WriteString("\n" + d.Message)
+	}
+	approachesPanel := lipgloss.NewStyle().Width(44).Padding(1, 2).Render(approaches.String())
+
+	// ----- Talks log -----
+	logLines := n.Log
+	if len(logLines) > negotiationLogLines {
+		logLines = logLines[len(logLines)-negotiationLogLines:]
+	}
+	logPanel := lipgloss.NewStyle().Width(68).Padding(1, 2).Render(logStyle.Render(strings.Join(logLines, "\n")))
+
+	topRow := lipgloss.JoinHorizontal(lipgloss.Top, speciesPanel, talksPanel)
+	bottomRow := lipgloss.JoinHorizontal(lipgloss.Top, approachesPanel, logPanel)
+	return lipgloss.JoinVertical(lipgloss.Left, topRow, bottomRow)
+}
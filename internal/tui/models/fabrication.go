@@ -0,0 +1,140 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dominik-merdzik/project-starbyte/internal/data"
+)
+
+// FabricateMsg signals game.go to make a fabrication recipe
+type FabricateMsg struct {
+	Recipe data.FabricationRecipe
+}
+
+// FabricationModel lists the recipes the ship's fabricator can make
+type FabricationModel struct {
+	GameSave *data.FullGameSave
+	Cursor   int
+	Message  string // outcome of the last recipe, set by game.go
+}
+
+func NewFabricationModel(gameSave *data.FullGameSave) FabricationModel {
+	return FabricationModel{GameSave: gameSave}
+}
+
+func (f FabricationModel) Init() tea.Cmd {
+	return nil
+}
+
+func (f FabricationModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			if f.Cursor > 0 {
+				f.Cursor--
+			}
+		case "down", "j":
+			if f.Cursor < len(data.FabricationRecipes)-1 {
+				f.Cursor++
+			}
+		case "enter":
+			if len(data.FabricationRecipes) == 0 {
+				return f, nil
+			}
+			recipe := data.FabricationRecipes[f.Cursor]
+			return f, func() tea.Msg { return FabricateMsg{Recipe: recipe} }
+		}
+	}
+	return f, nil
+}
+
+// outputLabel describes what a recipe makes, e.g. "+20 fuel"
+func outputLabel(output data.FabricationOutput) string {
+	switch output.Kind {
+	case data.OutputCargo:
+		return fmt.Sprintf("%d %s", output.Quantity, output.Item)
+	case data.OutputModule, data.OutputUpgrade:
+		return fmt.Sprintf("+%d %s level", output.Quantity, output.Item)
+	default:
+		return fmt.Sprintf("+%d %s", output.Quantity, output.Kind)
+	}
+}
+
+func (f FabricationModel) View() string {
+	if f.GameSave == nil {
+		return "No fabrication data."
+	}
+
+	panelStyle := lipgloss.NewStyle().
+		Width(110).
+		Padding(1, 2).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("63"))
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("63"))
+	hoverStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("215")).Bold(true)
+	defaultStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("217"))
+	blockedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	readyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("46"))
+	descriptionStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+
+	var content strings.Builder
+	level := data.ModuleLevel(f.GameSave.Ship, data.FabricatorModuleName)
+	header := fmt.Sprintf("Fabrication  •  %s level %d", data.FabricatorModuleName, level)
+	if level == 0 {
+		header = fmt.Sprintf("Fabrication  •  %s offline", data.FabricatorModuleName)
+	}
+	content.WriteString(titleStyle.Render(header) + "\n\n")
+
+	if len(data.FabricationRecipes) == 0 {
+		content.WriteString(blockedStyle.Render("No recipes known.") + "\n")
+		return panelStyle.Render(content.String())
+	}
+
+	for i, recipe := range data.FabricationRecipes {
+		line := fmt.Sprintf("%-22s → %s", recipe.Name, outputLabel(recipe.Output))
+		style := defaultStyle
+		if data.CanFabricate(f.GameSave, recipe) != nil {
+			style = blockedStyle
+		}
+		if i == f.Cursor {
+			content.WriteString(hoverStyle.Render("> "+line) + "\n")
+		} else {
+			content.WriteString(style.Render("  "+line) + "\n")
+		}
+	}
+
+	// what the selected recipe takes
+	recipe := data.FabricationRecipes[f.Cursor]
+	content.WriteString("\n" + descriptionStyle.Render(recipe.Description) + "\n")
+
+	var needs []string
+	for _, input := range recipe.Inputs {
+		need := fmt.Sprintf("%s %d/%d", input.Item, input.Held(f.GameSave), input.Quantity)
+		if input.Held(f.GameSave) >= input.Quantity {
+			needs = append(needs, readyStyle.Render(need))
+		} else {
+			needs = append(needs, blockedStyle.Render(need))
+		}
+	}
+	crew := fmt.Sprintf("Degree %d %s", recipe.Degree, recipe.Role)
+	if fabricator := recipe.Fabricator(f.GameSave.Crew); fabricator != nil {
+		crew = readyStyle.Render(crew + " (" + fabricator.Name + ")")
+	} else {
+		crew = blockedStyle.Render(crew)
+	}
+	content.WriteString(fmt.Sprintf("Needs: %s\nCrew: %s  •  %d hours\n", strings.Join(needs, ", "), crew, recipe.Hours))
+	if err := data.CanFabricate(f.GameSave, recipe); err != nil {
+		content.WriteString(blockedStyle.Render("Can't fabricate: "+err.Error()) + "\n")
+	}
+
+	content.WriteString("\n[Enter] Fabricate  [Esc] Back")
+	if f.Message != "" {
+		content.WriteString("\n\n" + f.Message)
+	}
+
+	return panelStyle.Render(content.String())
+}
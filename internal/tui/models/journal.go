@@ -10,6 +10,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/dominik-merdzik/project-starbyte/internal/data"
+	"github.com/dominik-merdzik/project-starbyte/internal/tui/components"
 )
 
 // TrackMissionMsg is used to signal that a mission is being tracked
@@ -22,9 +23,15 @@ type StartMissionMsg struct {
 	Mission data.Mission
 }
 
+// AbandonMissionMsg signals game.go that the player gave up on a mission
+type AbandonMissionMsg struct {
+	Mission data.Mission
+}
+
 // convertDataMission converts a data.Mission into a model.Mission
 func convertDataMission(dm data.Mission) data.Mission {
 	return data.Mission{
+		Id:           dm.Id,
 		Title:        dm.Title,
 		Description:  dm.Description,
 		Step:         dm.Step,
@@ -35,24 +42,63 @@ func convertDataMission(dm data.Mission) data.Mission {
 		Received:     dm.Received,
 		Category:     dm.Category,
 		Dialogue:     dm.Dialogue,
+		Conversation: dm.Conversation,
+		Enemy:        dm.Enemy,
+		Faction:      dm.Faction,
+		QuestID:      dm.QuestID,
+		Branch:       dm.Branch,
+		Briefed:      dm.Briefed,
+		Objectives:   dm.Objectives,
+		TimeLimit:    dm.TimeLimit,
+		Deadline:     dm.Deadline,
+		Reason:       dm.Reason,
+		AcceptedAt:   dm.AcceptedAt,
+		EndedAt:      dm.EndedAt,
+		Danger:       dm.Danger,
 	}
 }
 
 // currentList returns the missions to be displayed based on search mode,
-// filtering out any missions with status "complete" or "completed"
+// failed and abandoned missions only show up in the history
 func (j JournalModel) currentList() []data.Mission {
-	var baseList []data.Mission
+	baseList := j.Missions
 	if j.SearchQuery != "" {
-		if len(j.FilteredMissions) > 0 {
-			baseList = j.FilteredMissions
-		} else {
-			baseList = []data.Mission{}
+		baseList = j.FilteredMissions
+	}
+
+	var list []data.Mission
+	for _, m := range baseList {
+		if inHistory(m) == j.ShowHistory {
+			list = append(list, m)
 		}
-	} else {
-		baseList = j.Missions
 	}
+	return list
+}
+
+// inHistory reports whether a mission belongs in the history section
+func inHistory(m data.Mission) bool {
+	return m.Status == data.MissionStatusFailed || m.Status == data.MissionStatusAbandoned
+}
+
+// deadlineText describes how long is left on a mission's deadline
+func (j JournalModel) deadlineText(m data.Mission) string {
+	if j.GameSave == nil || !data.MissionActive(m) {
+		return ""
+	}
+	left, ok := data.TimeLeft(m, j.GameSave.GameMetadata.ClockHours)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("SD %s (%dh left)", data.Stardate(m.Deadline), left)
+}
 
-	return baseList
+// stardateText describes when a mission was accepted and when it ended
+func stardateText(m data.Mission) string {
+	text := "SD " + data.Stardate(m.AcceptedAt)
+	if !data.MissionActive(m) && m.EndedAt > 0 {
+		text += " → SD " + data.Stardate(m.EndedAt)
+	}
+	return text
 }
 
 // JournalModel represents the mission journal
@@ -70,6 +116,7 @@ type JournalModel struct {
 	DetailCursor  int
 	DetailOptions []string
 	GameSave      *data.FullGameSave
+	ShowHistory   bool // list failed and abandoned missions instead of the active ones
 }
 
 // loads the full game save, extracts the missions, and converts them for display
@@ -151,7 +198,7 @@ func (j JournalModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				case "Start Mission":
 					mission := j.getSelectedMission()
 					j.DetailView = false
-					if mission.Status == data.MissionStatusNotStarted {
+					if mission.Status == data.MissionStatusNotStarted || mission.Status == data.MissionStatusInProgress {
 						// First return a command to exit the journal view entirely
 						return j, tea.Batch(
 							func() tea.Msg {
@@ -166,9 +213,12 @@ func (j JournalModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					}
 				case "Abandon":
 					mission := j.getSelectedMission()
-					mission.Status = data.MissionStatusAbandoned
-					j.updateMission(mission)
 					j.DetailView = false
+					if data.MissionActive(mission) {
+						return j, func() tea.Msg {
+							return AbandonMissionMsg{Mission: mission}
+						}
+					}
 				}
 			case "esc":
 				j.DetailView = false
@@ -250,9 +300,9 @@ func (j JournalModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				j.Cursor = 0
 			}
 		case "enter":
-			// If this mission is already completed, do nothing
+			// If this mission is already completed or in the history, do nothing
 			selectedMission := j.getSelectedMission()
-			if selectedMission.Status == data.MissionStatusCompleted {
+			if selectedMission.Status == data.MissionStatusCompleted || inHistory(selectedMission) {
 				break
 			}
 			// Else enter detail view for this mission
@@ -266,6 +316,10 @@ func (j JournalModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			j.FilteredMissions = nil
 			j.Page = 0
 			j.Cursor = 0
+		case "h":
+			j.ShowHistory = !j.ShowHistory
+			j.Page = 0
+			j.Cursor = 0
 		}
 	}
 	return j, nil
@@ -299,10 +353,10 @@ func (j JournalModel) getSelectedMission() data.Mission {
 	return missionsOnPage[j.Cursor]
 }
 
-// updateMission updates the mission in j.Missions by matching the title.
+// updateMission updates the matching mission in j.Missions.
 func (j *JournalModel) updateMission(updated data.Mission) {
 	for i, m := range j.Missions {
-		if m.Title == updated.Title {
+		if m.SameAs(updated) {
 			j.Missions[i] = updated
 			break
 		}
@@ -348,7 +402,7 @@ func (j JournalModel) View() string {
 		// right panel detailed mission information
 		titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("63"))
 		labelStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("12"))
-		details := fmt.Sprintf("%s\n\n%s\n%s\n%s\n%s\n%s\n%s\n%s",
+		details := fmt.Sprintf("%s\n\n%s\n%s\n%s\n%s\n%s\n%s\n%s\n\n%s\n%s",
 			titleStyle.Render(selectedMission.Title),
 			labelStyle.Render("Description:")+" "+selectedMission.Description,
 			labelStyle.Render("Status:")+" "+selectedMission.Status.String(),
@@ -357,7 +411,12 @@ func (j JournalModel) View() string {
 			labelStyle.Render("Requirements:")+" "+selectedMission.Requirements,
 			labelStyle.Render("Received:")+" "+selectedMission.Received,
 			labelStyle.Render("Category:")+" "+selectedMission.Category,
+			labelStyle.Render("Objectives:"),
+			components.ObjectiveChecklist(selectedMission),
 		)
+		if deadline := j.deadlineText(selectedMission); deadline != "" {
+			details += "\n\n" + labelStyle.Render("Deadline:") + " " + deadline
+		}
 		rightPanel := lipgloss.NewStyle().
 			Width(60 - 4).
 			Height(18).
@@ -370,16 +429,7 @@ func (j JournalModel) View() string {
 	}
 
 	// normal list view
-	var currentList []data.Mission
-	if j.SearchQuery != "" {
-		if len(j.FilteredMissions) > 0 {
-			currentList = j.FilteredMissions
-		} else {
-			currentList = []data.Mission{}
-		}
-	} else {
-		currentList = j.Missions
-	}
+	currentList := j.currentList()
 	totalItems := len(currentList)
 	startIndex := j.Page * j.PageSize
 	if startIndex > totalItems {
@@ -410,6 +460,9 @@ func (j JournalModel) View() string {
 	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Italic(true)
 
 	var missionList strings.Builder
+	if j.ShowHistory {
+		missionList.WriteString(subtitleStyle.Render("History: failed and abandoned missions") + "\n\n")
+	}
 	if j.SearchQuery != "" {
 		missionList.WriteString("Search: " + j.SearchQuery + "\n\n")
 	}
@@ -426,7 +479,7 @@ func (j JournalModel) View() string {
 		missionList.WriteString(fmt.Sprintf("   %s\n", subtitleStyle.Render(mission.Category)))
 	}
 	pageInfo := fmt.Sprintf("Page %d of %d", j.Page+1, totalPages)
-	hints := "[/] search  [n] next page  [N] previous page"
+	hints := "[/] search  [n] next page  [N] previous page  [h] history"
 	missionList.WriteString("\n" + pageInfo + "\n" + hintStyle.Render(hints))
 	leftPanel := leftStyle.Render(missionList.String())
 
@@ -451,6 +504,13 @@ func (j JournalModel) View() string {
 			labelStyle.Render("Requirements:")+" "+selectedMission.Requirements,
 			labelStyle.Render("Received:")+" "+selectedMission.Received,
 		)
+		details += "\n" + labelStyle.Render("Logged:") + " " + stardateText(selectedMission)
+		if deadline := j.deadlineText(selectedMission); deadline != "" {
+			details += "\n" + labelStyle.Render("Deadline:") + " " + deadline
+		}
+		if selectedMission.Reason != "" {
+			details += "\n" + labelStyle.Render("Reason:") + " " + selectedMission.Reason
+		}
 	} else {
 		details = "No missions found."
 	}
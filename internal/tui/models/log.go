@@ -0,0 +1,266 @@
+package model
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dominik-merdzik/project-starbyte/internal/data"
+)
+
+// WriteNoteMsg signals game.go to add the player's note to the captain's log
+type WriteNoteMsg struct {
+	Text string
+}
+
+// ExportLogMsg signals game.go to export the captain's log to Markdown
+type ExportLogMsg struct{}
+
+// LogModel shows the captain's log, newest entries first
+type LogModel struct {
+	GameSave *data.FullGameSave
+	Cursor   int
+	Page     int
+	PageSize int
+
+	Filter      data.LogEntryType // entry type shown, empty shows them all
+	SearchMode  bool
+	SearchQuery string
+
+	Writing bool // the player is writing a note
+	Note    textinput.Model
+}
+
+func NewLogModel(gameSave *data.FullGameSave) LogModel {
+	note := textinput.New()
+	note.Placeholder = "Write a note for the log"
+	note.CharLimit = 200
+	note.Width = 90
+	return LogModel{
+		GameSave: gameSave,
+		PageSize: 12,
+		Note:     note,
+	}
+}
+
+func (l LogModel) Init() tea.Cmd {
+	return nil
+}
+
+// Typing reports whether the log is taking text, so game.go leaves the keys alone
+func (l LogModel) Typing() bool {
+	return l.Writing || l.SearchMode
+}
+
+// entries returns the log entries that pass the filter and search
+func (l LogModel) entries() []data.LogEntry {
+	if l.GameSave == nil {
+		return nil
+	}
+	return data.FilterLog(l.GameSave.Log, l.Filter, l.SearchQuery)
+}
+
+func (l LogModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		// keep the cursor of the note blinking
+		var cmd tea.Cmd
+		if l.Writing {
+			l.Note, cmd = l.Note.Update(msg)
+		}
+		return l, cmd
+	}
+
+	// -----------------------------
+	// Note Writing
+	// -----------------------------
+	if l.Writing {
+		switch key.String() {
+		case "esc":
+			l.Writing = false
+			l.Note.Blur()
+			return l, nil
+		case "enter":
+			text := strings.TrimSpace(l.Note.Value())
+			l.Writing = false
+			l.Note.Blur()
+			l.Note.Reset()
+			if text == "" {
+				return l, nil
+			}
+			l.Filter, l.SearchQuery, l.Page, l.Cursor = "", "", 0, 0
+			return l, func() tea.Msg { return WriteNoteMsg{Text: text} }
+		}
+		var cmd tea.Cmd
+		l.Note, cmd = l.Note.Update(key)
+		return l, cmd
+	}
+
+	// -----------------------------
+	// Search Mode Handling
+	// -----------------------------
+	if l.SearchMode {
+		switch key.String() {
+		case "backspace":
+			if len(l.SearchQuery) > 0 {
+				l.SearchQuery = l.SearchQuery[:len(l.SearchQuery)-1]
+			}
+		case "enter", "esc":
+			l.SearchMode = false
+		default:
+			if key.Type == tea.KeyRunes || key.Type == tea.KeySpace {
+				l.SearchQuery += string(key.Runes)
+			}
+		}
+		l.Page = 0
+		l.Cursor = 0
+		return l, nil
+	}
+
+	// -----------------------------
+	// Normal Log Handling
+	// -----------------------------
+	total := len(l.entries())
+	onPage := min(total-l.Page*l.PageSize, l.PageSize)
+	switch key.String() {
+	case "up", "k":
+		if l.Cursor > 0 {
+			l.Cursor--
+		}
+	case "down", "j":
+		if l.Cursor < onPage-1 {
+			l.Cursor++
+		}
+	case "n":
+		if (l.Page+1)*l.PageSize < total {
+			l.Page++
+			l.Cursor = 0
+		}
+	case "N":
+		if l.Page > 0 {
+			l.Page--
+			l.Cursor = 0
+		}
+	case "f":
+		// cycle through the entry types, then back to all of them
+		if i := slices.Index(data.LogEntryTypes, l.Filter); i+1 < len(data.LogEntryTypes) {
+			l.Filter = data.LogEntryTypes[i+1]
+		} else {
+			l.Filter = ""
+		}
+		l.Page = 0
+		l.Cursor = 0
+	case "/":
+		l.SearchMode = true
+		l.SearchQuery = ""
+		l.Page = 0
+		l.Cursor = 0
+	case "w":
+		l.Writing = true
+		return l, l.Note.Focus()
+	case "e":
+		return l, func() tea.Msg { return ExportLogMsg{} }
+	}
+	return l, nil
+}
+
+// logTypeColor picks the colour an entry type is tagged with
+func logTypeColor(entryType data.LogEntryType) lipgloss.Color {
+	switch entryType {
+	case data.LogArrival:
+		return "39"
+	case data.LogEvent:
+		return "215"
+	case data.LogCrew:
+		return "42"
+	case data.LogPurchase:
+		return "220"
+	case data.LogMission:
+		return "63"
+	case data.LogDiscovery:
+		return "170"
+	case data.LogCollection:
+		return "214"
+	case data.LogFabrication:
+		return "109"
+	case data.LogDiplomacy:
+		return "141"
+	default:
+		return "247"
+	}
+}
+
+func (l LogModel) View() string {
+	if l.GameSave == nil {
+		return "No log data."
+	}
+
+	panelStyle := lipgloss.NewStyle().
+		Width(120 - 4).
+		Height(18).
+		Padding(1).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("63"))
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("63"))
+	dateStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+	hoverStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("215"))
+	hintStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Italic(true)
+
+	entries := l.entries()
+	start := min(l.Page*l.PageSize, len(entries))
+	end := min(start+l.PageSize, len(entries))
+	totalPages := 1
+	if len(entries) > 0 {
+		totalPages = int(math.Ceil(float64(len(entries)) / float64(l.PageSize)))
+	}
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("Captain's Log") + "  " + dateStyle.Render("Filter: "+l.Filter.Label()))
+	if l.SearchMode || l.SearchQuery != "" {
+		b.WriteString(dateStyle.Render("  Search: " + l.SearchQuery))
+		if l.SearchMode {
+			b.WriteString("_")
+		}
+	}
+	b.WriteString("\n\n")
+
+	if l.Writing {
+		b.WriteString(l.Note.View() + "\n\n")
+	}
+
+	if len(entries) == 0 {
+		b.WriteString(hintStyle.Render("No entries yet.") + "\n")
+	}
+	for i, entry := range entries[start:end] {
+		tag := lipgloss.NewStyle().Foreground(logTypeColor(entry.Type)).Width(10).Render(entry.Type.Label())
+		text := entry.Text
+		if runes := []rune(text); len(runes) > 80 {
+			text = string(runes[:77]) + "..."
+		}
+		if i == l.Cursor {
+			text = hoverStyle.Render("> " + text)
+		} else {
+			text = "  " + text
+		}
+		b.WriteString(fmt.Sprintf("%s %s %s\n", dateStyle.Render("SD "+data.Stardate(entry.Hour)), tag, text))
+	}
+
+	// the full text of the selected entry, long ones are cut short in the list
+	if l.Cursor < end-start {
+		if selected := entries[start+l.Cursor]; len([]rune(selected.Text)) > 80 {
+			b.WriteString("\n" + lipgloss.NewStyle().Width(110).Render(selected.Text) + "\n")
+		}
+	}
+
+	hints := "[w] write note  [f] filter  [/] search  [e] export  [n] next page  [N] previous page"
+	if l.Writing {
+		hints = "[Enter] save note  [Esc] cancel"
+	}
+	b.WriteString(fmt.Sprintf("\nPage %d of %d\n%s", l.Page+1, totalPages, hintStyle.Render(hints)))
+
+	return panelStyle.Render(b.String())
+}
@@ -66,6 +66,9 @@ const (
 	ViewTravelConfirm
 )
 
+// ScanMsg signals game.go to scan the ship's surroundings
+type ScanMsg struct{}
+
 // TravelUpdateMsg signals game.go to update the ship's location and fuel.
 // Used when travelling to a planet.
 type TravelUpdateMsg struct {
@@ -87,8 +90,8 @@ func (m MapModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				requiresFTL := ftlRequiredSystems[systemToSelect.Name]
 				isLocked := !m.Ship.HasFTLDrive && requiresFTL
 
-				if isLocked {
-					// if the system is locked, do nothing
+				if isLocked || !m.GameMap.SystemCharted(systemToSelect) {
+					// if the system is locked or hasn't been scanned yet, do nothing
 					return m, nil
 				}
 
@@ -108,6 +111,13 @@ func (m MapModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		switch key {
+		case "s":
+			// scan the surroundings, not while a trip is being confirmed
+			if m.ActiveView != ViewTravelConfirm {
+				return m, func() tea.Msg { return ScanMsg{} }
+			}
+			return m, nil
+
 		// horizontal navigation
 		case "l", "right":
 			// if focus is on Left panel, try to select/move Center
@@ -190,6 +200,7 @@ func (m MapModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					currentLocation.StarSystemName, destinationLocation.StarSystemName,
 					m.Ship.EngineHealth, m.GameSave.Ship.Fuel,
 				)
+				estimatedFuelOnArrival = data.FuelAfterResearch(m.GameSave.Ship, estimatedFuelOnArrival)
 				isFuelInsufficient := estimatedFuelOnArrival < 0
 
 				if m.ConfirmCursor == 0 && !isFuelInsufficient { // confirm selected and fuel OK
@@ -226,6 +237,9 @@ func (m MapModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				} else {
 					return m, nil // invalid planet selection
 				}
+				if !m.GameMap.PlanetCharted(destinationPlanet) {
+					return m, nil // can't plot a course to something that hasn't been charted
+				}
 
 				destinationLocation := data.Location{
 					StarSystemName: m.SelectedSystem.Name,
@@ -322,6 +336,10 @@ func (m MapModel) renderStarSystemList() string {
 		// check accessibility
 		requiresFTL := ftlRequiredSystems[system.Name]
 		isLocked := !m.Ship.HasFTLDrive && requiresFTL
+		if !m.GameMap.SystemCharted(system) {
+			titleText = "Uncharted system"
+			isLocked = true
+		}
 
 		// determine if the cursor is here or if the system is selected (even if panel focus moved)
 		cursorIsHere := (m.ActiveView == ViewStarSystems || (m.ActiveView == ViewPlanets && m.ActivePanel == PanelLeft)) && i == m.SystemCursor
@@ -345,8 +363,10 @@ func (m MapModel) renderStarSystemList() string {
 	}
 
 	hintText := lipgloss.NewStyle().Italic(true).Foreground(lipgloss.Color("215")).Render("SELECT A STAR SYSTEM >>")
+	scanHint := lipgloss.NewStyle().Italic(true).Foreground(lipgloss.Color("240")).
+		Render(fmt.Sprintf("[s] Scan, range %d (%dh)", data.ScanRange(m.GameSave), data.ScanHours))
 
-	return panelStyle.Render(hintText + "\n\n" + sb.String())
+	return panelStyle.Render(hintText + "\n\n" + sb.String() + "\n" + scanHint)
 }
 
 // renderPlanetList renders the planet list (Center Panel)
@@ -398,11 +418,22 @@ func (m MapModel) renderPlanetList() string {
 		return panelStyle.Render("") // keep it empty for now
 	}
 
-	// list planets for the selected system
+	// list planets for the selected system, marking anomalies and bodies not yet visited
+	unchartedStyle := defaultStyle.Faint(true)
+	markStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("170"))
 	for i, planet := range m.SelectedSystem.Planets {
 		titleText := planet.Name
 		style := defaultStyle
 		prefix := "  " // default: two spaces
+		switch {
+		case !m.GameMap.PlanetCharted(planet):
+			titleText = "Uncharted body"
+			style = unchartedStyle
+		case planet.IsAnomaly():
+			titleText += " " + markStyle.Render("⚠")
+		case !planet.Visited:
+			titleText += " " + markStyle.Render("✦")
+		}
 
 		// highlight and show arrow only if center panel is active and cursor is on this planet
 		if m.ActiveView == ViewPlanets && m.ActivePanel == PanelCenter && i == m.PlanetCursor {
@@ -414,8 +445,9 @@ func (m MapModel) renderPlanetList() string {
 	}
 
 	hintText := lipgloss.NewStyle().Italic(true).Foreground(lipgloss.Color("215")).Render("SELECT A PLANET >>")
+	legend := lipgloss.NewStyle().Italic(true).Foreground(lipgloss.Color("240")).Render("✦ undiscovered  ⚠ anomaly")
 
-	return panelStyle.Render(hintText + "\n\n" + sb.String())
+	return panelStyle.Render(hintText + "\n\n" + sb.String() + "\n" + legend)
 }
 
 // renderPlanetDetails renders planet details (Right Panel)
@@ -465,6 +497,10 @@ func (m MapModel) renderPlanetDetails() string {
 		return panelStyle.Render(errorStyle.Render("Error: Invalid planet index."))
 	}
 	planet := m.SelectedSystem.Planets[m.PlanetCursor]
+	if !m.GameMap.PlanetCharted(planet) {
+		return panelStyle.Render(labelStyle.Render("Uncharted") + "\n\n" +
+			valueStyle.Render("Something is out there. Scan from closer in to chart it."))
+	}
 
 	// calculate travel details
 	currentLocation := m.Ship.Location
@@ -488,6 +524,7 @@ func (m MapModel) renderPlanetDetails() string {
 			currentLocation.StarSystemName, destinationLocation.StarSystemName,
 			m.Ship.EngineHealth, m.GameSave.Ship.Fuel,
 		)
+		estimatedFuelOnArrival = data.FuelAfterResearch(m.GameSave.Ship, estimatedFuelOnArrival)
 		isFuelInsufficient = estimatedFuelOnArrival < 0
 	} else { // if already here
 		travelDuration = 0
@@ -501,8 +538,24 @@ func (m MapModel) renderPlanetDetails() string {
 	// basic planet info
 	b.WriteString(labelStyle.Render("Planet: ") + valueStyle.Render(planet.Name) + "\n")
 	b.WriteString(labelStyle.Render("Type: ") + valueStyle.Render(planet.Type) + "\n")
+	faction := planet.Faction
+	if faction == "" {
+		faction = m.SelectedSystem.Faction
+	}
+	if faction != "" {
+		tier := data.TierFor(data.Standing(m.GameSave.Player.Reputation, faction))
+		b.WriteString(labelStyle.Render("Controlled by: ") + valueStyle.Render(fmt.Sprintf("%s (%s)", data.FactionName(faction), tier)) + "\n")
+	}
 	b.WriteString(labelStyle.Render("Coordinates: ") + valueStyle.Render(
-		fmt.Sprintf("(%d, %d, %d)", planet.Coordinates.X, planet.Coordinates.Y, planet.Coordinates.Z)) + "\n\n")
+		fmt.Sprintf("(%d, %d, %d)", planet.Coordinates.X, planet.Coordinates.Y, planet.Coordinates.Z)) + "\n")
+	switch {
+	case planet.IsAnomaly():
+		b.WriteString(labelStyle.Render("Fades: ") + valueStyle.Render("SD "+data.Stardate(planet.ExpiresAt)) + "\n\n")
+	case planet.Visited:
+		b.WriteString(labelStyle.Render("Surveyed: ") + valueStyle.Render("Yes") + "\n\n")
+	default:
+		b.WriteString(labelStyle.Render("Surveyed: ") + valueStyle.Render("No, visit to discover") + "\n\n")
+	}
 
 	// travel time / location status
 	if isAlreadyHere {
@@ -608,6 +661,7 @@ func (m MapModel) renderTravelConfirm() string {
 		currentLocation.StarSystemName, destinationLocation.StarSystemName,
 		m.Ship.EngineHealth, m.GameSave.Ship.Fuel,
 	)
+	estimatedFuelOnArrival = data.FuelAfterResearch(m.GameSave.Ship, estimatedFuelOnArrival)
 	isFuelInsufficient := estimatedFuelOnArrival < 0
 	// end calculation
 
@@ -0,0 +1,95 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dominik-merdzik/project-starbyte/internal/data"
+)
+
+// PerkModel lets the player spend perk picks earned by levelling up
+type PerkModel struct {
+	GameSave *data.FullGameSave
+	Cursor   int
+	Message  string // how the last pick went, set by game.go
+}
+
+// PerkChosenMsg signals game.go that the player picked a perk
+type PerkChosenMsg struct {
+	PerkID string
+}
+
+func NewPerkModel(gameSave *data.FullGameSave) PerkModel {
+	return PerkModel{
+		GameSave: gameSave,
+		Cursor:   0,
+	}
+}
+
+func (p PerkModel) Init() tea.Cmd {
+	return nil
+}
+
+func (p PerkModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			if p.Cursor > 0 {
+				p.Cursor--
+			}
+		case "down", "j":
+			if p.Cursor < len(data.AllPerks)-1 {
+				p.Cursor++
+			}
+		case "enter":
+			perk := data.AllPerks[p.Cursor]
+			return p, func() tea.Msg {
+				return PerkChosenMsg{PerkID: perk.ID}
+			}
+		}
+	}
+	return p, nil
+}
+
+func (p PerkModel) View() string {
+	panelStyle := lipgloss.NewStyle().
+		Width(110).
+		Padding(1, 2).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("63"))
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("63"))
+	hoverStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("215")).Bold(true)
+	defaultStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("217"))
+	maxedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	descriptionStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+
+	player := p.GameSave.Player
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render(fmt.Sprintf("Captain Perks  •  Level %d  •  %d pick(s) available", player.Level, player.PerkPicks)) + "\n\n")
+
+	for i, perk := range data.AllPerks {
+		rank := data.PerkRank(player, perk.ID)
+		line := fmt.Sprintf("%-18s Rank %d/%d", perk.Name, rank, perk.MaxRank)
+
+		style := defaultStyle
+		if rank >= perk.MaxRank {
+			style = maxedStyle
+		}
+		if i == p.Cursor {
+			content.WriteString(hoverStyle.Render("> "+line) + "  " + descriptionStyle.Render(perk.Description) + "\n")
+		} else {
+			content.WriteString(style.Render("  "+line) + "  " + descriptionStyle.Render(perk.Description) + "\n")
+		}
+	}
+
+	content.WriteString("\n[Enter] Choose perk  [Esc] Back")
+	if p.Message != "" {
+		content.WriteString("\n\n" + p.Message)
+	}
+
+	return panelStyle.Render(content.String())
+}
@@ -0,0 +1,286 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/progress"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dominik-merdzik/project-starbyte/internal/data"
+)
+
+// ReputationModel shows the player's standing with every faction they know about
+// factions that speak for an alien species also show the species and its market
+type ReputationModel struct {
+	GameSave   *data.FullGameSave
+	Cursor     int
+	goodCursor int    // trade good selected in the species' market
+	Message    string // outcome of the last trade or attempt at talks, set by game.go
+}
+
+// OpenTalksMsg signals game.go to open talks with a species
+type OpenTalksMsg struct {
+	Species string
+}
+
+// TradeGoodMsg signals game.go to buy or sell one unit of a trade good at a species' market
+type TradeGoodMsg struct {
+	Species string
+	Good    string
+	Buy     bool // buying from the species, otherwise selling to them
+}
+
+// marketGood is a line of a species' market, either a good it sells or one it buys
+type marketGood struct {
+	Good data.TradeGood
+	Buy  bool
+}
+
+// marketGoods lists what a species sells followed by what it buys
+func marketGoods(species data.Species) []marketGood {
+	var goods []marketGood
+	for _, good := range species.TradeGoods {
+		goods = append(goods, marketGood{Good: good, Buy: true})
+	}
+	for _, good := range species.Wants {
+		goods = append(goods, marketGood{Good: good})
+	}
+	return goods
+}
+
+func NewReputationModel(gameSave *data.FullGameSave) ReputationModel {
+	return ReputationModel{
+		GameSave: gameSave,
+		Cursor:   0,
+	}
+}
+
+func (r ReputationModel) Init() tea.Cmd {
+	return nil
+}
+
+func (r ReputationModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			if r.Cursor > 0 {
+				r.Cursor--
+				r.goodCursor = 0
+			}
+		case "down", "j":
+			if r.Cursor < len(r.factionIDs())-1 {
+				r.Cursor++
+				r.goodCursor = 0
+			}
+		}
+
+		species := r.selectedSpecies()
+		if species == nil {
+			return r, nil
+		}
+		goods := marketGoods(*species)
+		switch msg.String() {
+		case "left", "h":
+			if r.goodCursor > 0 {
+				r.goodCursor--
+			}
+		case "right", "l":
+			if r.goodCursor < len(goods)-1 {
+				r.goodCursor++
+			}
+		case "t":
+			id := species.ID
+			return r, func() tea.Msg { return OpenTalksMsg{Species: id} }
+		case "b", "s":
+			if len(goods) == 0 {
+				return r, nil
+			}
+			good := goods[r.goodCursor]
+			if good.Buy != (msg.String() == "b") {
+				return r, nil
+			}
+			id := species.ID
+			return r, func() tea.Msg { return TradeGoodMsg{Species: id, Good: good.Good.Name, Buy: good.Buy} }
+		}
+	}
+	return r, nil
+}
+
+// selectedSpecies returns the species the faction under the cursor speaks for, or nil for a human faction
+func (r ReputationModel) selectedSpecies() *data.Species {
+	ids := r.factionIDs()
+	if len(ids) == 0 {
+		return nil
+	}
+	return data.SpeciesOfFaction(ids[min(r.Cursor, len(ids)-1)])
+}
+
+// factionIDs lists the registry factions first, then any others the player has standing with
+// factions of species the crew hasn't met yet stay hidden
+func (r ReputationModel) factionIDs() []string {
+	var ids []string
+	seen := map[string]bool{}
+	for _, faction := range data.Factions {
+		seen[faction.ID] = true
+		if species := data.SpeciesOfFaction(faction.ID); species != nil && data.FindContact(r.GameSave, species.ID) == nil {
+			continue
+		}
+		ids = append(ids, faction.ID)
+	}
+
+	var extra []string
+	rep := r.GameSave.Player.Reputation
+	for _, standings := range []map[string]int{rep.AlliedFactions, rep.EnemyFactions} {
+		for id := range standings {
+			if !seen[id] {
+				extra = append(extra, id)
+				seen[id] = true
+			}
+		}
+	}
+	sort.Strings(extra)
+	return append(ids, extra...)
+}
+
+// tierColor picks the colour a tier is drawn in
+func tierColor(tier data.ReputationTier) lipgloss.Color {
+	return [...]lipgloss.Color{"196", "208", "247", "42", "33"}[tier]
+}
+
+func (r ReputationModel) View() string {
+	if r.GameSave == nil {
+		return "No reputation data."
+	}
+
+	listStyle := lipgloss.NewStyle().
+		Width(40).
+		Height(14).
+		Padding(1, 2).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("63"))
+	detailStyle := listStyle.Width(70)
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("63")).MarginBottom(1)
+	hoverStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("215")).Bold(true)
+	defaultStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("217"))
+	labelStyle := lipgloss.NewStyle().Bold(true)
+	descriptionStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("244")).Width(64)
+
+	rep := r.GameSave.Player.Reputation
+	ids := r.factionIDs()
+
+	// ----- Faction list -----
+	var list strings.Builder
+	list.WriteString(titleStyle.Render(fmt.Sprintf("Standings (%s)", r.GameSave.Player.Faction)) + "\n")
+	for i, id := range ids {
+		standing := data.Standing(rep, id)
+		tier := data.TierFor(standing)
+		line := fmt.Sprintf("%-22s %4d ", data.FactionName(id), standing)
+		tierText := lipgloss.NewStyle().Foreground(tierColor(tier)).Render(tier.String())
+		if i == r.Cursor {
+			list.WriteString(hoverStyle.Render("> "+line) + tierText + "\n")
+		} else {
+			list.WriteString(defaultStyle.Render("  "+line) + tierText + "\n")
+		}
+	}
+	leftPanel := listStyle.Render(list.String())
+
+	// ----- Selected faction -----
+	var details strings.Builder
+	if len(ids) > 0 {
+		id := ids[min(r.Cursor, len(ids)-1)]
+		standing := data.Standing(rep, id)
+		tier := data.TierFor(standing)
+
+		details.WriteString(titleStyle.Render(data.FactionName(id)) + "\n")
+		details.WriteString(fmt.Sprintf("%s %d (%s)\n",
+			labelStyle.Render("Standing:"), standing,
+			lipgloss.NewStyle().Foreground(tierColor(tier)).Render(tier.String())))
+
+		// standing runs from -100 to 100
+		bar := progress.New(progress.WithScaledGradient("#F00065", "#008FE9"), progress.WithWidth(50))
+		details.WriteString(bar.ViewAs(float64(standing+100)/200) + "\n\n")
+
+		var territory []string
+		for _, system := range r.GameSave.GameMap.StarSystems {
+			if system.Faction == id {
+				territory = append(territory, system.Name)
+			}
+		}
+		if len(territory) == 0 {
+			territory = []string{"None"}
+		}
+		details.WriteString(fmt.Sprintf("%s %s\n", labelStyle.Render("Territory:"), strings.Join(territory, ", ")))
+
+		docking := "Granted"
+		if !data.CanDock(rep, id) {
+			docking = "Refused"
+		}
+		details.WriteString(fmt.Sprintf("%s %s\n", labelStyle.Render("Docking rights:"), docking))
+		details.WriteString(fmt.Sprintf("%s %d%%\n", labelStyle.Render("Station prices:"), data.PriceModifier(rep, id)))
+		details.WriteString(fmt.Sprintf("%s %d%%\n", labelStyle.Render("Mission payouts:"), data.RewardModifier(rep, id)))
+
+		if faction := data.FindFaction(id); faction != nil {
+			details.WriteString(descriptionStyle.Render("\n" + faction.Description))
+		}
+		if species := data.SpeciesOfFaction(id); species != nil {
+			details.WriteString("\n\n" + r.speciesView(*species))
+		}
+	}
+	if r.Message != "" {
+		details.WriteString("\n\n" + r.Message)
+	}
+	rightPanel := detailStyle.Render(details.String())
+
+	return lipgloss.JoinHorizontal(lipgloss.Top, leftPanel, rightPanel)
+}
+
+// speciesView describes an alien species, the crew's history with it and its market
+func (r ReputationModel) speciesView(species data.Species) string {
+	labelStyle := lipgloss.NewStyle().Bold(true)
+	hoverStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("215")).Bold(true)
+	defaultStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("217"))
+	closedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	descriptionStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("244")).Width(64)
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("%s %s %s\n", labelStyle.Render("Species:"), species.Portrait, species.Name))
+	b.WriteString(descriptionStyle.Render(species.Description) + "\n")
+	b.WriteString(fmt.Sprintf("%s %s  %s %s\n", labelStyle.Render("Temperament:"), species.Temperament.Label(),
+		labelStyle.Render("Home:"), species.HomeSystem.Name))
+	b.WriteString(fmt.Sprintf("%s %s\n", labelStyle.Render("Language:"), species.Language))
+
+	contact := data.FindContact(r.GameSave, species.ID)
+	if contact != nil {
+		talks := "none concluded"
+		if contact.Talks > 0 {
+			talks = fmt.Sprintf("%d, last ended in %s", contact.Talks, contact.Outcome)
+		}
+		b.WriteString(fmt.Sprintf("%s met at hour %d, talks %s\n", labelStyle.Render("Contact:"), contact.MetAt, talks))
+	}
+
+	market := "Closed, reach an accord to trade"
+	if data.MarketOpen(r.GameSave, species.ID) {
+		market = "Open in " + species.HomeSystem.Name
+	}
+	b.WriteString(fmt.Sprintf("%s %s\n", labelStyle.Render("Market:"), market))
+	for i, good := range marketGoods(species) {
+		line := fmt.Sprintf("Sells %-18s %4d¢", good.Good.Name, data.SellPrice(r.GameSave, species, good.Good))
+		if !good.Buy {
+			line = fmt.Sprintf("Buys  %-18s %4d¢ (%d held)", good.Good.Name, data.BuyPrice(r.GameSave, species, good.Good),
+				data.CargoQuantity(r.GameSave.Ship.Cargo, good.Good.Name))
+		}
+		switch {
+		case !data.MarketOpen(r.GameSave, species.ID):
+			b.WriteString(closedStyle.Render("  "+line) + "\n")
+		case i == r.goodCursor:
+			b.WriteString(hoverStyle.Render("> "+line) + "\n")
+		default:
+			b.WriteString(defaultStyle.Render("  "+line) + "\n")
+		}
+	}
+	b.WriteString("\n[←/→] Select good  [B] Buy  [S] Sell  [T] Open talks")
+	return b.String()
+}
@@ -0,0 +1,187 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dominik-merdzik/project-starbyte/internal/data"
+)
+
+// StartResearchMsg signals game.go to spend the notes for a node and set the lab to work on it
+type StartResearchMsg struct {
+	ID string
+}
+
+// ResearchModel shows the research tree, what each node costs and how far the lab has got
+type ResearchModel struct {
+	GameSave *data.FullGameSave
+	Cursor   int
+	Message  string // outcome of the last action, set by game.go
+}
+
+// researchLine is a node as it is drawn in the tree, with the branch lines leading to it
+type researchLine struct {
+	Node   data.ResearchNode
+	Prefix string
+}
+
+func NewResearchModel(gameSave *data.FullGameSave) ResearchModel {
+	return ResearchModel{GameSave: gameSave}
+}
+
+func (r ResearchModel) Init() tea.Cmd {
+	return nil
+}
+
+func (r ResearchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	lines := researchLines()
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			if r.Cursor > 0 {
+				r.Cursor--
+			}
+		case "down", "j":
+			if r.Cursor < len(lines)-1 {
+				r.Cursor++
+			}
+		case "enter":
+			id := lines[r.Cursor].Node.ID
+			return r, func() tea.Msg { return StartResearchMsg{ID: id} }
+		}
+	}
+	return r, nil
+}
+
+// researchLines lays the tree out depth first, each node sits under the first node it requires
+func researchLines() []researchLine {
+	var lines []researchLine
+	var walk func(parent, indent string)
+	walk = func(parent, indent string) {
+		var children []data.ResearchNode
+		for _, node := range data.ResearchTree {
+			if (parent == "" && len(node.Requires) == 0) || (len(node.Requires) > 0 && node.Requires[0] == parent) {
+				children = append(children, node)
+			}
+		}
+		for i, node := range children {
+			branch, next := "├─ ", "│  "
+			if i == len(children)-1 {
+				branch, next = "└─ ", "   "
+			}
+			if parent == "" {
+				branch, next = "", ""
+			}
+			lines = append(lines, researchLine{Node: node, Prefix: indent + branch})
+			walk(node.ID, indent+next)
+		}
+	}
+	walk("", "")
+	return lines
+}
+
+func (r ResearchModel) View() string {
+	if r.GameSave == nil {
+		return "No research data."
+	}
+
+	panelStyle := lipgloss.NewStyle().
+		Width(110).
+		Padding(1, 2).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("63"))
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("63"))
+	hoverStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("215")).Bold(true)
+	doneStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("46"))
+	activeStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("39"))
+	availableStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("217"))
+	lockedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	treeStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("238"))
+	descriptionStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+
+	research := r.GameSave.Ship.Research
+	rate := data.ResearchRate(r.GameSave.Crew)
+
+	var tree strings.Builder
+	lines := researchLines()
+	for i, line := range lines {
+		node := line.Node
+		var mark string
+		var style lipgloss.Style
+		switch {
+		case research.Researched(node.ID):
+			mark, style = "✓ ", doneStyle
+		case research.Active == node.ID:
+			mark, style = "▶ ", activeStyle
+		case research.Available(node):
+			mark, style = "○ ", availableStyle
+		default:
+			mark, style = "· ", lockedStyle
+		}
+		if i == r.Cursor {
+			style = hoverStyle
+		}
+		tree.WriteString(treeStyle.Render(line.Prefix) + style.Render(mark+node.Name) + "\n")
+	}
+
+	// details of the node under the cursor
+	node := lines[min(r.Cursor, len(lines)-1)].Node
+	var details strings.Builder
+	details.WriteString(titleStyle.Render(node.Name) + "\n")
+	details.WriteString(lipgloss.NewStyle().Width(50).Foreground(lipgloss.Color("244")).Render(node.Description) + "\n\n")
+	details.WriteString("Bonus: " + node.Bonus.String() + "\n")
+
+	var costs []string
+	for _, cost := range node.Cost {
+		held := data.ResearchNotesHeld(r.GameSave.Collection, cost.Tier)
+		text := fmt.Sprintf("%d× tier %d (%d held)", cost.Quantity, cost.Tier, held)
+		if held >= cost.Quantity {
+			costs = append(costs, doneStyle.Render(text))
+		} else {
+			costs = append(costs, lockedStyle.Render(text))
+		}
+	}
+	details.WriteString("Cost: " + strings.Join(costs, ", ") + "\n")
+	details.WriteString(fmt.Sprintf("Work: %d degree-hours\n", node.Work))
+	if len(node.Requires) > 0 {
+		var names []string
+		for _, id := range node.Requires {
+			if required := data.FindResearchNode(id); required != nil {
+				names = append(names, required.Name)
+			}
+		}
+		details.WriteString("Requires: " + strings.Join(names, ", ") + "\n")
+	}
+
+	switch {
+	case research.Researched(node.ID):
+		details.WriteString("\n" + doneStyle.Render("Researched"))
+	case research.Active == node.ID:
+		details.WriteString("\n" + activeStyle.Render(fmt.Sprintf("In progress: %d / %d", research.Progress, node.Work)))
+		if rate > 0 {
+			hours := (node.Work - research.Progress + rate - 1) / rate
+			details.WriteString(descriptionStyle.Render(fmt.Sprintf(" (about %d hours)", hours)))
+		}
+	}
+
+	lab := fmt.Sprintf("Lab: %d degree-hours every hour", rate)
+	if rate == 0 {
+		lab = "Lab: idle, assign Scientists or Research Specialists to the Research Lab in Crew"
+	}
+
+	var content strings.Builder
+	content.WriteString(titleStyle.Render("Research") + "  " + descriptionStyle.Render(lab) + "\n\n")
+	content.WriteString(lipgloss.JoinHorizontal(lipgloss.Top,
+		lipgloss.NewStyle().Width(44).Render(tree.String()),
+		details.String(),
+	))
+	content.WriteString("\n\n[Enter] Start research  [Esc] Back")
+	if r.Message != "" {
+		content.WriteString("\n\n" + r.Message)
+	}
+
+	return panelStyle.Render(content.String())
+}
@@ -74,7 +74,7 @@ func (s ShipModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				s.Cursor--
 			}
 		case "down", "j":
-			if s.Cursor < 5 { // Number of selectable items.
+			if s.Cursor < 6 { // Number of selectable items.
 				s.Cursor++
 			}
 		case "a":
@@ -143,7 +143,7 @@ func (s ShipModel) View() string {
 		MarginTop(1)
 
 	// ----- Panel 1: Ship Status List -----
-	items := []string{"Hull Health", "Engine Health", "Engine Fuel", "FTL Drive Health", "FTL Drive Charge", "Food"}
+	items := []string{"Hull Health", "Shields", "Engine Health", "Engine Fuel", "FTL Drive Health", "FTL Drive Charge", "Food"}
 	var shipList strings.Builder
 	shipList.WriteString(titleStyle.Render("Ship Status") + "\n")
 	for i, item := range items {
@@ -172,34 +172,46 @@ func (s ShipModel) View() string {
 		description = "Overall structural integrity. Protects internal systems and crew from environmental hazards and combat damage. Reaching zero integrity results in ship destruction."
 
 	case 1:
+		detailTitle = "Shields"
+		if s.MaxShieldStrength > 0 {
+			progressValue = float64(s.ShieldStrength) / float64(s.MaxShieldStrength)
+		}
+		details.WriteString(fmt.Sprintf("%s %d / %d", labelStyle.Render("Strength:"), s.ShieldStrength, s.MaxShieldStrength))
+		if s.GameSave != nil {
+			details.WriteString(fmt.Sprintf("\n%s +%d per cycle", labelStyle.Render("Regen:"), data.ShieldRegenRate(s.GameSave.Ship, s.GameSave.Crew)))
+		}
+		// added Description:
+		description = "Deflector field that absorbs incoming damage before it reaches the hull. Recharges over time and during travel; a working Shield Emitter and Engineers assigned to shield maintenance speed it up."
+
+	case 2:
 		detailTitle = "Engine Health"
 		progressValue = float64(s.EngineHealth) / 100.0
 		details.WriteString(fmt.Sprintf("%s %d%%", labelStyle.Render("Status:"), s.EngineHealth))
 		// added Description:
-		description = "Condition of the main sublight engines. Affects maximum speed, maneuverability, and fuel consumption within star systems. Low health risks critical failure."
+		description = "Condition of the main sublight engines. Wears down with every trip and raises fuel consumption as it drops. Mechanics on engine maintenance slow the wear; repair it at a station or with damage control."
 
-	case 2:
+	case 3:
 		detailTitle = "Engine Fuel"
 		progressValue = float64(s.EngineFuel) / float64(s.MaxFuel)
 		details.WriteString(fmt.Sprintf("%s %d / %d", labelStyle.Render("Level:"), s.EngineFuel, s.MaxFuel))
 		// added Description:
 		description = "Propellant reserves for sublight travel. Essential for moving between planets, stations, and jump points. Depletion will leave the ship stranded."
 
-	case 3:
+	case 4:
 		detailTitle = "FTL Drive Health"
 		progressValue = float64(s.FTLDriveHealth) / 100.0
 		details.WriteString(fmt.Sprintf("%s %d%%", labelStyle.Render("Status:"), s.FTLDriveHealth))
 		// added Description:
 		description = "Integrity of the Faster-Than-Light drive system. Required for interstellar jumps. Damage increases charge time, jump inaccuracy, or may prevent jumps entirely."
 
-	case 4:
+	case 5:
 		detailTitle = "FTL Drive Charge"
 		progressValue = float64(s.FTLDriveCharge) / 100.0
 		details.WriteString(fmt.Sprintf("%s %d%%", labelStyle.Render("Charge:"), s.FTLDriveCharge))
 		// added Description:
 		description = "Current energy level accumulated for the next FTL jump. Must reach 100% to initiate warp. Charging speed depends on reactor output and drive health."
 
-	case 5:
+	case 6:
 		detailTitle = "Food Supply"
 		// assuming max food is 200 for progress bar, adjust if needed
 		if s.Food > 200 {
@@ -255,14 +267,14 @@ func (s ShipModel) View() string {
 	// Modules Box (Simplified to count)
 	modulesTitle := boxTitleStyle.Render("Modules")
 	modulesTextContent := fmt.Sprintf("%d Installed", len(s.Modules))
-	activeCount := 0
+	damagedCount := 0
 	for _, mod := range s.Modules {
-		if mod.Status == "Active" {
-			activeCount++
+		if mod.Status == "damaged" {
+			damagedCount++
 		}
 	}
 	if len(s.Modules) > 0 {
-		modulesTextContent += fmt.Sprintf("\n%d Active", activeCount)
+		modulesTextContent += fmt.Sprintf("\n%d Damaged", damagedCount)
 	}
 	modulesText := boxTextStyle.Render(modulesTextContent)
 	modulesBoxContent := lipgloss.NewStyle().Height(5).Render(lipgloss.JoinVertical(lipgloss.Left, modulesTitle, modulesText))
@@ -270,10 +282,13 @@ func (s ShipModel) View() string {
 
 	// Upgrades Box
 	upgradesTitle := boxTitleStyle.Render("Upgrades")
-	upgradesText := boxTextStyle.Render(fmt.Sprintf("ENG: %d/%d\nWPN: %d/%d\nCRG: %d/%d",
+	upgradesText := boxTextStyle.Render(fmt.Sprintf("ENG: %d/%d  HUL: %d/%d\nWPN: %d/%d  SHD: %d/%d\nCRG: %d/%d\nWeapon Power: %d",
 		s.Upgrades.Engine.CurrentLevel, s.Upgrades.Engine.MaxLevel,
+		s.Upgrades.HullPlating.CurrentLevel, s.Upgrades.HullPlating.MaxLevel,
 		s.Upgrades.WeaponSystems.CurrentLevel, s.Upgrades.WeaponSystems.MaxLevel,
+		s.Upgrades.ShieldGenerator.CurrentLevel, s.Upgrades.ShieldGenerator.MaxLevel,
 		s.Upgrades.CargoExpansion.CurrentLevel, s.Upgrades.CargoExpansion.MaxLevel,
+		data.CalculateShipStats(s.Upgrades).WeaponPower,
 	))
 	upgradesBoxContent := lipgloss.NewStyle().Height(5).Render(lipgloss.JoinVertical(lipgloss.Left, upgradesTitle, upgradesText))
 	upgradesBox := detailBoxStyle.Render(upgradesBoxContent)
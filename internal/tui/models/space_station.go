@@ -10,7 +10,20 @@ import (
 	"github.com/dominik-merdzik/project-starbyte/internal/data"
 )
 
+// station tabs, one for the bulletin board and one for each service a station offers
+var serviceTabs = map[data.StationService]struct{ name, content string }{
+	data.ServiceHiring:   {"Hire Crew", "Hire new crew members."},
+	data.ServiceShipyard: {"Shipyard", "Upgrade your ship."},
+	data.ServiceRefuel:   {"Refuel", "Refuel before leaving. [Enter]"},
+	data.ServiceRepair:   {"Repair", "Repair your ship. [Enter]"},
+	data.ServiceMarket:   {"Market", "Buy and sell goods."},
+	data.ServiceMedbay:   {"Medbay", "Treat injured crew."},
+}
+
+// SpaceStationModel is the station the ship is docked at, its tabs follow the services the station offers
 type SpaceStationModel struct {
+	Station    data.Station
+	GameSave   *data.FullGameSave // read by the market and medbay, which game.go runs against the save
 	Ship       data.Ship
 	Tabs       []string
 	TabContent []string
@@ -20,13 +33,14 @@ type SpaceStationModel struct {
 	refuelMode    bool
 	refuelConfirm bool
 	desiredFuel   int
-	fuelPrice     int
+	FuelPrice     int // credits per unit before the reputation modifier
 
 	// Fields for repair flow
-	repairMode    bool
-	repairConfirm bool
-	repairAmount  int
-	repairPrice   int
+	repairMode     bool // choosing which part to repair
+	repairSelected bool // choosing how much to repair
+	repairConfirm  bool
+	repairCursor   int
+	repairAmount   int
 
 	// Fields for upgrade
 	upgradeCursor  int // Tracks which upgrade is selected
@@ -35,47 +49,81 @@ type SpaceStationModel struct {
 	// Fields for crew member
 	GeneratedRecruits []data.CrewMember // Array of procedurally generated options
 	RecruitCursor     int               // Tracks selected crew member
+	RecruitDay        int               // Game clock day the recruits were generated on
 	showingCrewDetail bool              // True when crew member popup open
 	confirmHire       bool
 
 	// Fields for missions
-	MissionTemplates        []data.MissionTemplate
-	StarSystems             []data.StarSystem
-	GeneratedMissions       []data.Mission
+	GeneratedMissions       []data.Mission // the station's board, set by game.go each time the ship docks
 	MissionCursor           int
 	showingMissionDetail    bool
 	confirmingMissionAccept bool
 	receiptMessage          string
 
+	// Fields for the market
+	marketCursor int
+
 	// General fields
-	Credits      int
-	ErrorMessage string // Stores feedback
+	Credits       int
+	CrewCount     int    // crew currently on board
+	CrewCapacity  int    // crew the ship has room for
+	Faction       string // faction that runs the station
+	PriceModifier int    // percentage of the base price charged, set by the player's standing with Faction
+	ErrorMessage  string // Stores feedback
+	Message       string // outcome of the last market or medbay action, set by game.go
 }
 
-func NewSpaceStationModel(ship data.Ship, credits int, missionTemplates []data.MissionTemplate, starSystems []data.StarSystem) SpaceStationModel {
+// NewSpaceStationModel docks with a station, building a tab for each of its services
+func NewSpaceStationModel(station data.Station, gameSave *data.FullGameSave) SpaceStationModel {
 	model := SpaceStationModel{
-		Ship:              ship,
-		Credits:           credits,
-		Tabs:              []string{"Hire Crew", "Missions", "Upgrade Ship", "Refuel", "Repair"},
-		TabContent:        []string{"Hire new crew members.", "Browse available missions.", "Upgrade your ship.", "Refuel before leaving. [Enter]", "Repair your ship. [Enter]"},
-		ActiveTab:         0,
-		fuelPrice:         5,
-		repairPrice:       5,
-		MissionTemplates:  missionTemplates,
-		StarSystems:       starSystems,
-		GeneratedMissions: GenerateStationMissions(3, missionTemplates, starSystems, ship.Location.StarSystemName), // Generate on load
-	}
-
-	if model.Tabs[model.ActiveTab] == "Hire Crew" {
-		model.GeneratedRecruits = generateRandomRecruits(rand.Intn(5) + 1) // Generates random amount of recruits between 1-5
+		Station:       station,
+		GameSave:      gameSave,
+		Ship:          gameSave.Ship,
+		Credits:       gameSave.Player.Credits,
+		Tabs:          []string{"Bulletin Board"},
+		TabContent:    []string{"Browse the notices and available missions."},
+		ActiveTab:     0,
+		FuelPrice:     station.Fuel(gameSave.Player),
+		PriceModifier: 100,
+		Faction:       station.Faction,
+	}
+	for _, service := range data.AllServices {
+		if station.Offers(service) {
+			model.Tabs = append(model.Tabs, serviceTabs[service].name)
+			model.TabContent = append(model.TabContent, serviceTabs[service].content)
+		}
+	}
+
+	if station.Offers(data.ServiceHiring) {
+		model.GeneratedRecruits = generateRandomRecruits(rand.Intn(5)+1, station.Recruits) // Generates random amount of recruits between 1-5
 		model.RecruitCursor = 0
 	}
 
 	return model
 }
 
-// Global variable for base prices for ship upgrades
-var baseUpgradeCosts = []int{100, 200, 300} // Engine, Weapons, Cargo
+// SetBoard puts up the missions on the station's board, keeping the cursor on the list
+func (m *SpaceStationModel) SetBoard(missions []data.Mission) {
+	m.GeneratedMissions = missions
+	m.MissionCursor = min(m.MissionCursor, max(len(missions)-1, 0))
+	if len(missions) == 0 {
+		m.showingMissionDetail = false
+		m.confirmingMissionAccept = false
+	}
+}
+
+// RefreshRecruits brings in a new pool of recruits when a new day has started on the game clock
+func (m *SpaceStationModel) RefreshRecruits(day int) {
+	if m.RecruitDay == day {
+		return
+	}
+	m.RecruitDay = day
+	if !m.Station.Offers(data.ServiceHiring) {
+		return
+	}
+	m.GeneratedRecruits = generateRandomRecruits(rand.Intn(5)+1, m.Station.Recruits)
+	m.RecruitCursor = 0
+}
 
 func (m SpaceStationModel) Init() tea.Cmd {
 	return nil
@@ -88,9 +136,10 @@ type RefuelUpdateMsg struct {
 	Credit int
 }
 
-// Repairing the ship hull health
-// TODO: other ship parts like engine
+// This signals game.go to repair part of the ship
+// Used when paying for repairs in space station
 type RepairUpdateMsg struct {
+	Target data.RepairTarget
 	Amount int
 	Credit int
 }
@@ -116,6 +165,15 @@ type AcceptMissionMsg struct {
 	Mission data.Mission
 }
 
+// StationTradeMsg signals game.go to buy or sell one unit of a good at the station's market
+type StationTradeMsg struct {
+	Good string
+	Buy  bool
+}
+
+// TreatCrewMsg signals game.go to have the injured crew treated in the station's medbay
+type TreatCrewMsg struct{}
+
 func (m SpaceStationModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
@@ -123,11 +181,13 @@ func (m SpaceStationModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "right", "l":
 			if !m.refuelMode {
 				m.ActiveTab = min(m.ActiveTab+1, len(m.Tabs)-1)
+				m.Message = ""
 			}
 			return m, nil
 		case "left", "h":
 			if !m.refuelMode {
 				m.ActiveTab = max(m.ActiveTab-1, 0)
+				m.Message = ""
 			}
 			return m, nil
 		case "enter":
@@ -141,7 +201,7 @@ func (m SpaceStationModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.refuelConfirm = true
 				} else {
 					// Perform refuel
-					totalCost := m.desiredFuel * m.fuelPrice
+					totalCost := m.price(m.desiredFuel * m.FuelPrice)
 					amountPurchased := m.desiredFuel // Value of fuel purchased
 
 					if m.SpendCredits(totalCost) {
@@ -174,49 +234,55 @@ func (m SpaceStationModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 			if m.Tabs[m.ActiveTab] == "Repair" {
+				targets := data.RepairTargets(m.Ship)
 				if !m.repairMode {
-					// Enter repair selection mode
+					// Enter part selection mode
 					m.repairMode = true
-					m.repairAmount = min(100-m.Ship.HullIntegrity, 100) // Default to full repair
-					m.repairPrice = 3                                   // Cost per unit of hull repair
+					m.repairCursor = 0
+				} else if len(targets) == 0 {
+					// Nothing to repair
+					m.repairMode = false
+				} else if !m.repairSelected {
+					// Enter amount selection mode, defaulting to a full repair
+					m.repairSelected = true
+					m.repairAmount = data.RepairNeeded(m.Ship, targets[m.repairCursor])
 				} else if !m.repairConfirm {
 					// Enter confirmation mode
 					m.repairConfirm = true
 				} else {
 					// Perform repair
-					totalCost := m.repairAmount * m.repairPrice
-					amountRepaired := m.repairAmount // Value of repair performed
-
-					if m.SpendCredits(totalCost) {
-						m.Ship.HullIntegrity = min(m.Ship.HullIntegrity+m.repairAmount, 100)
+					target := targets[m.repairCursor]
+					amount := m.repairAmount
+					totalCost := m.price(data.StationRepairCost(m.Ship, target, amount))
 
-						// Reset UI state
-						m.repairMode = false
-						m.repairConfirm = false
-						m.repairAmount = 0
+					// Reset UI state
+					m.repairMode = false
+					m.repairSelected = false
+					m.repairConfirm = false
+					m.repairAmount = 0
 
-						return m, tea.Batch(
-							func() tea.Msg {
-								return RepairUpdateMsg{
-									Amount: amountRepaired,
-									Credit: totalCost,
-								}
-							},
-							func() tea.Msg {
-								return tea.KeyMsg{Type: tea.KeyEsc}
-							},
-						)
-					} else {
+					if !m.SpendCredits(totalCost) {
 						// Not enough credits
-						m.repairConfirm = false
-						m.repairAmount = 0
-						m.repairMode = false
 						return m, nil
 					}
+					amountRepaired := data.ApplyRepair(&m.Ship, target, amount) // Value of repair performed
+
+					return m, tea.Batch(
+						func() tea.Msg {
+							return RepairUpdateMsg{
+								Target: target,
+								Amount: amountRepaired,
+								Credit: totalCost,
+							}
+						},
+						func() tea.Msg {
+							return tea.KeyMsg{Type: tea.KeyEsc}
+						},
+					)
 				}
 				return m, nil
 			}
-			if m.Tabs[m.ActiveTab] == "Upgrade Ship" {
+			if m.Tabs[m.ActiveTab] == "Shipyard" {
 				if !m.upgradeConfirm {
 					m.upgradeConfirm = true // Confirm mode
 				} else {
@@ -247,7 +313,14 @@ func (m SpaceStationModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				} else {
 					// Confirm the hire
 					recruit := m.GeneratedRecruits[m.RecruitCursor]
-					cost := getHireCost(recruit.Degree, string(recruit.Role))
+					cost := m.price(getHireCost(recruit.Degree, string(recruit.Role)))
+
+					// No room on board
+					if m.CrewCapacity > 0 && m.CrewCount >= m.CrewCapacity {
+						m.confirmHire = false
+						m.ErrorMessage = "No free berths on board!"
+						return m, nil
+					}
 
 					// Not enough credits
 					if m.Credits < cost {
@@ -257,6 +330,7 @@ func (m SpaceStationModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					}
 
 					m.Credits -= cost
+					m.CrewCount++
 
 					// Remove from recruit list
 					m.GeneratedRecruits = append(m.GeneratedRecruits[:m.RecruitCursor], m.GeneratedRecruits[m.RecruitCursor+1:]...)
@@ -274,7 +348,18 @@ func (m SpaceStationModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					}
 				}
 			}
-			if m.Tabs[m.ActiveTab] == "Missions" && len(m.GeneratedMissions) > 0 {
+			if m.Tabs[m.ActiveTab] == "Market" {
+				if rows := m.marketRows(); len(rows) > 0 {
+					row := rows[m.marketCursor]
+					return m, func() tea.Msg {
+						return StationTradeMsg{Good: row.name, Buy: row.buy}
+					}
+				}
+			}
+			if m.Tabs[m.ActiveTab] == "Medbay" {
+				return m, func() tea.Msg { return TreatCrewMsg{} }
+			}
+			if m.Tabs[m.ActiveTab] == "Bulletin Board" && len(m.GeneratedMissions) > 0 {
 				if !m.showingMissionDetail {
 					m.showingMissionDetail = true
 				} else if !m.confirmingMissionAccept {
@@ -310,9 +395,11 @@ func (m SpaceStationModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			if m.repairConfirm {
 				m.repairConfirm = false
+			} else if m.repairSelected {
+				m.repairSelected = false
+				m.repairAmount = 0
 			} else if m.repairMode {
 				m.repairMode = false
-				m.repairAmount = 0
 			}
 			if m.confirmHire {
 				m.confirmHire = false
@@ -332,12 +419,15 @@ func (m SpaceStationModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.refuelMode && !m.refuelConfirm {
 				m.desiredFuel = min(m.desiredFuel+1, m.Ship.MaxFuel-m.Ship.Fuel)
 			}
-			// Increase repair amount
-			if m.repairMode && !m.repairConfirm {
-				m.repairAmount = min(m.repairAmount+1, 100-m.Ship.HullIntegrity)
+			// Increase repair amount, or move up the list of parts
+			if m.repairSelected && !m.repairConfirm {
+				targets := data.RepairTargets(m.Ship)
+				m.repairAmount = min(m.repairAmount+1, data.RepairNeeded(m.Ship, targets[m.repairCursor]))
+			} else if m.repairMode && !m.repairConfirm {
+				m.repairCursor = max(m.repairCursor-1, 0)
 			}
 			// Higher upgrade in list
-			if m.Tabs[m.ActiveTab] == "Upgrade Ship" {
+			if m.Tabs[m.ActiveTab] == "Shipyard" {
 				m.upgradeCursor = max(m.upgradeCursor-1, 0)
 				m.ErrorMessage = ""
 			}
@@ -346,9 +436,13 @@ func (m SpaceStationModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.RecruitCursor = max(m.RecruitCursor-1, 0)
 			}
 			// Higher mission in list
-			if m.Tabs[m.ActiveTab] == "Missions" && m.MissionCursor > 0 {
+			if m.Tabs[m.ActiveTab] == "Bulletin Board" && m.MissionCursor > 0 {
 				m.MissionCursor--
 			}
+			// Higher good in the market
+			if m.Tabs[m.ActiveTab] == "Market" {
+				m.marketCursor = max(m.marketCursor-1, 0)
+			}
 			return m, nil
 
 		case "down", "j":
@@ -356,13 +450,15 @@ func (m SpaceStationModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.refuelMode && !m.refuelConfirm {
 				m.desiredFuel = max(m.desiredFuel-1, 1)
 			}
-			// Decrease repair amount
-			if m.repairMode && !m.repairConfirm {
+			// Decrease repair amount, or move down the list of parts
+			if m.repairSelected && !m.repairConfirm {
 				m.repairAmount = max(m.repairAmount-1, 1)
+			} else if m.repairMode && !m.repairConfirm {
+				m.repairCursor = min(m.repairCursor+1, max(len(data.RepairTargets(m.Ship))-1, 0))
 			}
 			// Lower upgrade in list
-			if m.Tabs[m.ActiveTab] == "Upgrade Ship" {
-				m.upgradeCursor = min(m.upgradeCursor+1, 2)
+			if m.Tabs[m.ActiveTab] == "Shipyard" {
+				m.upgradeCursor = min(m.upgradeCursor+1, len(data.AllUpgradeTypes)-1)
 				m.ErrorMessage = ""
 			}
 			// Lower crew member in list
@@ -370,9 +466,13 @@ func (m SpaceStationModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.RecruitCursor = min(m.RecruitCursor+1, len(m.GeneratedRecruits)-1)
 			}
 			// Lower mission in list
-			if m.Tabs[m.ActiveTab] == "Missions" && m.MissionCursor < len(m.GeneratedMissions)-1 {
+			if m.Tabs[m.ActiveTab] == "Bulletin Board" && m.MissionCursor < len(m.GeneratedMissions)-1 {
 				m.MissionCursor++
 			}
+			// Lower good in the market
+			if m.Tabs[m.ActiveTab] == "Market" {
+				m.marketCursor = min(m.marketCursor+1, max(len(m.marketRows())-1, 0))
+			}
 			return m, nil
 		}
 	}
@@ -402,6 +502,9 @@ var (
 )
 
 func (m SpaceStationModel) View() string {
+	if len(m.Tabs) == 0 {
+		return "There is no station to dock with here."
+	}
 	doc := strings.Builder{}
 	var renderedTabs []string
 
@@ -427,6 +530,17 @@ func (m SpaceStationModel) View() string {
 		renderedTabs = append(renderedTabs, style.Render(t))
 	}
 
+	// Which station this is, who runs it and what they charge the player
+	header := m.Station.Name
+	if m.Faction != "" {
+		header += "  •  " + data.FactionName(m.Faction)
+	}
+	header += fmt.Sprintf("  •  Prices %d%%", m.PriceModifier)
+	doc.WriteString(labelStyle.Render(header) + "\n")
+	if m.Station.Description != "" {
+		doc.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("244")).Render(m.Station.Description) + "\n")
+	}
+
 	row := lipgloss.JoinHorizontal(lipgloss.Top, renderedTabs...)
 	doc.WriteString(row)
 	doc.WriteString("\n")
@@ -435,7 +549,7 @@ func (m SpaceStationModel) View() string {
 	// Refuel section
 	if m.Tabs[m.ActiveTab] == "Refuel" && m.refuelMode {
 		if m.refuelConfirm {
-			totalCost := m.desiredFuel * m.fuelPrice
+			totalCost := m.price(m.desiredFuel * m.FuelPrice)
 			content = fmt.Sprintf(
 				"Confirm refueling %d units?\nCost: %d¢  |  You have: %d¢\n[Enter] Confirm  [b] Cancel",
 				m.desiredFuel,
@@ -451,7 +565,7 @@ func (m SpaceStationModel) View() string {
 			content = fmt.Sprintf(
 				"How much fuel do you want to buy?\n[ %d ] units (Cost: %d¢)\nYou have: %d¢\n[↑/↓] Adjust  [Enter] Confirm  [b] Cancel",
 				m.desiredFuel,
-				m.desiredFuel*m.fuelPrice,
+				m.price(m.desiredFuel*m.FuelPrice),
 				m.Credits,
 			)
 		}
@@ -461,46 +575,72 @@ func (m SpaceStationModel) View() string {
 
 	// Repair section
 	if m.Tabs[m.ActiveTab] == "Repair" {
-		if m.repairMode {
+		targets := data.RepairTargets(m.Ship)
+		if m.repairMode && len(targets) == 0 {
+			content = "Your ship is in perfect condition.\n[b] Back"
+		} else if m.repairSelected {
+			target := targets[m.repairCursor]
+			totalCost := m.price(data.StationRepairCost(m.Ship, target, m.repairAmount))
 			if m.repairConfirm {
 				content = fmt.Sprintf(
-					"Confirm repairing to %d units?\nCost: %d¢  |  You have: %d¢\n[Enter] Confirm  [b] Cancel",
-					m.repairAmount,
-					m.repairAmount*m.repairPrice,
+					"Confirm repairing %s?\nCost: %d¢  |  You have: %d¢\n[Enter] Confirm  [b] Cancel",
+					target.Label(m.Ship),
+					totalCost,
 					m.Credits,
 				)
 
 				// Add warning if player cannot afford
-				if m.repairAmount*m.repairPrice > m.Credits {
-					content += "\n\n" + warningStyle.Render(fmt.Sprintf("\n\nNot enough credits! (%d¢ needed)", m.repairAmount*m.repairPrice))
+				if totalCost > m.Credits {
+					content += "\n\n" + warningStyle.Render(fmt.Sprintf("\n\nNot enough credits! (%d¢ needed)", totalCost))
 				}
+			} else if target.Kind == data.RepairModule {
+				content = fmt.Sprintf(
+					"Restore the %s to working order?\nCost: %d¢\nYou have: %d¢\n[Enter] Confirm  [b] Cancel",
+					target.Label(m.Ship),
+					totalCost,
+					m.Credits)
 			} else {
 				content = fmt.Sprintf(
-					"How much hull integrity do you want to repair?\n[ %d ] units (Cost: %d¢)\nYou have: %d¢\n[↑/↓] Adjust  [Enter] Confirm  [b] Cancel",
+					"How much %s damage do you want to repair?\n[ %d / %d ] points (Cost: %d¢)\nYou have: %d¢\n[↑/↓] Adjust  [Enter] Confirm  [b] Cancel",
+					strings.ToLower(target.Label(m.Ship)),
 					m.repairAmount,
-					m.repairAmount*m.repairPrice,
+					data.RepairNeeded(m.Ship, target),
+					totalCost,
 					m.Credits)
 			}
+		} else if m.repairMode {
+			var repairList []string
+			for i, target := range targets {
+				needed := data.RepairNeeded(m.Ship, target)
+				line := fmt.Sprintf("%-16s full repair: %d¢", target.Label(m.Ship), m.price(data.StationRepairCost(m.Ship, target, needed)))
+				if i == m.repairCursor {
+					line = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("215")).Render("> " + line)
+				} else {
+					line = "  " + line
+				}
+				repairList = append(repairList, line)
+			}
+			content = "What needs fixing?\n\n" + strings.Join(repairList, "\n") + "\n\n[↑/↓] Select  [Enter] Choose  [b] Cancel"
 		} else {
 			content = m.TabContent[m.ActiveTab]
 		}
 	}
 
 	// Upgrade section
-	if m.Tabs[m.ActiveTab] == "Upgrade Ship" {
+	if m.Tabs[m.ActiveTab] == "Shipyard" {
 		var upgradeList []string
-		upgradeNames := []string{"Engine", "Weapon Systems", "Cargo Expansion"}
 
-		for i, name := range upgradeNames {
+		for i, upgradeType := range data.AllUpgradeTypes {
 			level := m.GetUpgradeLevel(i)
+			maxLevel := m.Ship.Upgrades.Level(upgradeType).MaxLevel
 			var line string
 
 			// Show maxed out message
-			if level >= 5 {
-				line = fmt.Sprintf("%s (Lv %d) - MAXED OUT", name, level)
+			if level >= maxLevel {
+				line = fmt.Sprintf("%s (Lv %d/%d) - MAXED OUT", upgradeType, level, maxLevel)
 			} else {
-				cost := baseUpgradeCosts[i] * (level + 1)
-				line = fmt.Sprintf("%s (Lv %d) - Cost: %d¢", name, level, cost)
+				cost := m.price(data.UpgradeCost(upgradeType, level))
+				line = fmt.Sprintf("%s (Lv %d/%d) - Cost: %d¢", upgradeType, level, maxLevel, cost)
 			}
 
 			// Highlight selected
@@ -513,10 +653,16 @@ func (m SpaceStationModel) View() string {
 
 		content = strings.Join(upgradeList, "\n")
 
+		// Before and after preview of the selected upgrade
+		selectedType := data.AllUpgradeTypes[m.upgradeCursor]
+		if m.GetUpgradeLevel(m.upgradeCursor) < m.Ship.Upgrades.Level(selectedType).MaxLevel {
+			content += "\n\n" + renderUpgradePreview(m.Ship, selectedType)
+		}
+
 		// Show confirmation message
 		if m.upgradeConfirm {
 			level := m.GetUpgradeLevel(m.upgradeCursor)
-			if level >= 5 {
+			if level >= m.Ship.Upgrades.Level(selectedType).MaxLevel {
 				content += "\n\n" + lipgloss.NewStyle().
 					Foreground(lipgloss.Color("8")).
 					Italic(true).
@@ -524,9 +670,9 @@ func (m SpaceStationModel) View() string {
 			} else {
 				content += fmt.Sprintf(
 					"\n\nConfirm upgrading %s to Lv %d for %d¢?\n[Enter] Confirm  [b] Cancel",
-					upgradeNames[m.upgradeCursor],
+					selectedType,
 					level+1,
-					baseUpgradeCosts[m.upgradeCursor]*(level+1),
+					m.price(data.UpgradeCost(selectedType, level)),
 				)
 			}
 		}
@@ -558,6 +704,9 @@ func (m SpaceStationModel) View() string {
 		content = lipgloss.NewStyle().
 			Padding(1, 2).
 			Render(strings.Join(recruitLines, "\n"))
+		if m.CrewCapacity > 0 {
+			content += "\n" + labelStyle.Render(fmt.Sprintf("Berths: %d/%d", m.CrewCount, m.CrewCapacity))
+		}
 
 		// List of detailed crew member information
 		if m.showingCrewDetail && len(m.GeneratedRecruits) > 0 {
@@ -574,7 +723,7 @@ func (m SpaceStationModel) View() string {
 				"",
 				fmt.Sprintf("%s %s", labelStyle.Render("Buffs:"), r.Buffs),
 				fmt.Sprintf("%s %s", labelStyle.Render("Debuffs:"), r.Debuffs),
-				fmt.Sprintf("%s %d", labelStyle.Render("Hire Cost:"), getHireCost(r.Degree, string(r.Role))),
+				fmt.Sprintf("%s %d", labelStyle.Render("Hire Cost:"), m.price(getHireCost(r.Degree, string(r.Role)))),
 				"",
 				func() string {
 					if m.confirmHire {
@@ -591,12 +740,12 @@ func (m SpaceStationModel) View() string {
 		}
 	}
 	// Mission section
-	if m.Tabs[m.ActiveTab] == "Missions" {
+	if m.Tabs[m.ActiveTab] == "Bulletin Board" {
 		var lines []string
 
 		// Display list of missions and incomes
 		for i, mission := range m.GeneratedMissions {
-			line := fmt.Sprintf("%-25s %6d¢", mission.Title, mission.Income)
+			line := fmt.Sprintf("%-28s %6d¢  %s  %s", mission.Title, mission.Income, dangerRating(mission.Danger), m.postingExpiry(mission))
 
 			if i == m.MissionCursor {
 				line = lipgloss.NewStyle().
@@ -610,6 +759,18 @@ func (m SpaceStationModel) View() string {
 			lines = append(lines, line)
 		}
 
+		// Notices pinned up by the station come before the missions
+		var notices []string
+		for _, bulletin := range m.Station.Bulletins {
+			notices = append(notices, "• "+bulletin)
+		}
+		if len(notices) > 0 {
+			lines = append([]string{
+				lipgloss.NewStyle().Foreground(lipgloss.Color("244")).Width(70).Render(strings.Join(notices, "\n")),
+				"",
+			}, lines...)
+		}
+
 		content = lipgloss.NewStyle().
 			Padding(1, 2).
 			Render(strings.Join(lines, "\n"))
@@ -625,6 +786,8 @@ func (m SpaceStationModel) View() string {
 				fmt.Sprintf("%s %s %s", labelStyle.Render("Location:"), mission.Location.StarSystemName, mission.Location.PlanetName),
 				fmt.Sprintf("%s %d", labelStyle.Render("Coordinates:"), mission.Location.Coordinates),
 				fmt.Sprintf("%s %d¢", labelStyle.Render("Income:"), mission.Income),
+				fmt.Sprintf("%s %s", labelStyle.Render("Danger:"), dangerRating(mission.Danger)),
+				fmt.Sprintf("%s %s", labelStyle.Render("Posting:"), m.postingExpiry(mission)),
 				"",
 				fmt.Sprintf("%s %s", labelStyle.Render("From:"), mission.Received),
 				fmt.Sprintf("%s %s", labelStyle.Render("Category:"), mission.Category),
@@ -632,6 +795,11 @@ func (m SpaceStationModel) View() string {
 				fmt.Sprintf("%s %s", labelStyle.Render("Description:"), mission.Description),
 				"",
 				fmt.Sprintf("%s %s", labelStyle.Render("Requirements:"), mission.Requirements),
+			)
+			if mission.TimeLimit > 0 {
+				detailLines = append(detailLines, fmt.Sprintf("%s %d hours once accepted", labelStyle.Render("Time limit:"), mission.TimeLimit))
+			}
+			detailLines = append(detailLines,
 				"",
 				fmt.Sprint(labelStyle.Render("Dialogue:")),
 			)
@@ -654,6 +822,50 @@ func (m SpaceStationModel) View() string {
 		}
 	}
 
+	// Market section
+	if m.Tabs[m.ActiveTab] == "Market" && m.GameSave != nil {
+		var lines []string
+		for i, row := range m.marketRows() {
+			var line string
+			if row.buy {
+				line = fmt.Sprintf("Buy  %-20s %5d¢  %2d in stock", row.name, row.price, row.count)
+			} else {
+				line = fmt.Sprintf("Sell %-20s %5d¢  %2d in hold", row.name, row.price, row.count)
+			}
+			if i == m.marketCursor {
+				line = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("215")).Render("> " + line)
+			} else {
+				line = "  " + line
+			}
+			lines = append(lines, line)
+		}
+		cargo := m.GameSave.Ship.Cargo
+		content = strings.Join(lines, "\n") +
+			"\n\n" + labelStyle.Render(fmt.Sprintf("Cargo: %d/%d  •  Credits: %d¢", cargo.UsedCapacity, cargo.Capacity, m.Credits)) +
+			"\n[↑/↓] Select  [Enter] Trade"
+	}
+
+	// Medbay section
+	if m.Tabs[m.ActiveTab] == "Medbay" && m.GameSave != nil {
+		var lines []string
+		for _, member := range m.GameSave.Crew {
+			if member.Health > 0 && member.Health < 100 {
+				lines = append(lines, fmt.Sprintf("%-12s %-24s Health %3d", member.Name, member.Role, member.Health))
+			}
+		}
+		if len(lines) == 0 {
+			content = "Nobody aboard needs treatment."
+		} else {
+			cost := data.TreatmentCost(m.GameSave, m.Station)
+			content = "Injured crew:\n\n" + strings.Join(lines, "\n") +
+				fmt.Sprintf("\n\nTreat them all for %d¢  |  You have: %d¢\n[Enter] Treat", cost, m.Credits)
+		}
+	}
+
+	if m.Message != "" {
+		content += "\n\n" + m.Message
+	}
+
 	doc.WriteString(windowStyle.Width((lipgloss.Width(row) - windowStyle.GetHorizontalFrameSize())).Render(content))
 	return docStyle.Render(doc.String())
 }
@@ -673,6 +885,57 @@ func min(a, b int) int {
 	return b
 }
 
+// dangerRating draws a mission's danger as stars, e.g. "★★☆"
+func dangerRating(danger int) string {
+	danger = min(max(danger, 1), data.MaxDanger)
+	return strings.Repeat("★", danger) + strings.Repeat("☆", data.MaxDanger-danger)
+}
+
+// postingExpiry says how long a mission stays on the board
+func (m SpaceStationModel) postingExpiry(mission data.Mission) string {
+	if m.GameSave == nil || mission.OfferExpires == 0 {
+		return ""
+	}
+	return fmt.Sprintf("expires in %dh", max(mission.OfferExpires-m.GameSave.GameMetadata.ClockHours, 0))
+}
+
+// marketRow is a line of the market, a good the station sells or one it buys
+type marketRow struct {
+	name  string
+	buy   bool
+	price int
+	count int // units left in stock to buy, or units in the hold to sell
+}
+
+// marketRows lists what the player can buy from the station's market followed by what they can sell to it
+func (m SpaceStationModel) marketRows() []marketRow {
+	if m.GameSave == nil {
+		return nil
+	}
+	var rows []marketRow
+	for _, item := range m.Station.Market {
+		rows = append(rows, marketRow{
+			name:  item.Name,
+			buy:   true,
+			price: data.StationSellPrice(m.GameSave, m.Station, item),
+			count: data.StockLeft(m.GameSave, m.Station, item.Name),
+		})
+	}
+	for _, good := range m.Station.Wants {
+		rows = append(rows, marketRow{
+			name:  good.Name,
+			price: data.StationBuyPrice(m.GameSave, m.Station, good),
+			count: data.CargoQuantity(m.GameSave.Ship.Cargo, good.Name),
+		})
+	}
+	return rows
+}
+
+// price applies the station's reputation modifier to a base price
+func (m SpaceStationModel) price(base int) int {
+	return data.AdjustPrice(base, m.PriceModifier)
+}
+
 // Subtracts from the players credits
 func (m *SpaceStationModel) SpendCredits(amount int) bool {
 	if m.Credits >= amount {
@@ -689,14 +952,15 @@ func (m *SpaceStationModel) SpendCredits(amount int) bool {
 // Applies upgrades to the ship
 func (m *SpaceStationModel) ApplyUpgrade(index int) bool {
 	currentLevel := m.GetUpgradeLevel(index)
+	upgradeType := data.UpgradeType(index)
 
-	if currentLevel >= 5 {
+	if currentLevel >= m.Ship.Upgrades.Level(upgradeType).MaxLevel {
 		m.ErrorMessage = "Already maxed out!"
 		return false
 	}
 
 	// Calc cost
-	cost := baseUpgradeCosts[index] * (currentLevel + 1)
+	cost := m.price(data.UpgradeCost(upgradeType, currentLevel))
 
 	// Check if player has enough credits
 	if !m.SpendCredits(cost) {
@@ -706,41 +970,63 @@ func (m *SpaceStationModel) ApplyUpgrade(index int) bool {
 
 	// Apply upgrade
 	m.SetUpgradeLevel(index, currentLevel+1)
+	data.ApplyUpgradeEffects(&m.Ship)
 	return true
 }
 
 // Returns the upgrade level for each module
 func (m *SpaceStationModel) GetUpgradeLevel(index int) int {
-	switch index {
-	case 0:
-		return m.Ship.Upgrades.Engine.CurrentLevel
-	case 1:
-		return m.Ship.Upgrades.WeaponSystems.CurrentLevel
-	case 2:
-		return m.Ship.Upgrades.CargoExpansion.CurrentLevel
-	default:
+	level := m.Ship.Upgrades.Level(data.UpgradeType(index))
+	if level == nil {
 		return 0
 	}
+	return level.CurrentLevel
 }
 
 // Sets the upgrade level for each module
 func (m *SpaceStationModel) SetUpgradeLevel(index int, newLevel int) {
-	switch index {
-	case 0:
-		m.Ship.Upgrades.Engine.CurrentLevel = newLevel
-	case 1:
-		m.Ship.Upgrades.WeaponSystems.CurrentLevel = newLevel
-	case 2:
-		m.Ship.Upgrades.CargoExpansion.CurrentLevel = newLevel
+	if level := m.Ship.Upgrades.Level(data.UpgradeType(index)); level != nil {
+		level.CurrentLevel = newLevel
 	}
 }
 
+// renderUpgradePreview shows each stat the upgrade changes, before and after buying it
+func renderUpgradePreview(ship data.Ship, upgradeType data.UpgradeType) string {
+	before, after := data.PreviewUpgrade(ship, upgradeType)
+
+	stats := []struct {
+		name          string
+		before, after int
+	}{
+		{"Max Fuel", before.MaxFuel, after.MaxFuel},
+		{"Max Hull", before.MaxHullIntegrity, after.MaxHullIntegrity},
+		{"Max Shields", before.MaxShieldStrength, after.MaxShieldStrength},
+		{"Cargo Capacity", before.CargoCapacity, after.CargoCapacity},
+		{"Weapon Power", before.WeaponPower, after.WeaponPower},
+		{"Collection", before.CollectionCapacity, after.CollectionCapacity},
+	}
+
+	changedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("46")).Bold(true)
+	unchangedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+	var lines []string
+	for _, stat := range stats {
+		line := fmt.Sprintf("%-15s %4d → %4d", stat.name, stat.before, stat.after)
+		if stat.after != stat.before {
+			lines = append(lines, changedStyle.Render(line))
+		} else {
+			lines = append(lines, unchangedStyle.Render(line))
+		}
+	}
+	return labelStyle.Render("Preview:") + "\n" + strings.Join(lines, "\n")
+}
+
 //***************************************
 //        Crew functions
 //***************************************
 
-// Generates random recruits
-func generateRandomRecruits(n int) []data.CrewMember {
+// Generates random recruits, drawn from the given roles or the usual four when there are none
+func generateRandomRecruits(n int, stationRoles []data.CrewRole) []data.CrewMember {
 	// Random list of names (can make bigger)
 	names := []string{
 		"Alice", "Bob", "Junko", "Nash", "Kira", "Maeve", "Cass", "Yuri", "Andrew", "Dominik", "Khanh", "Theoren",
@@ -749,7 +1035,13 @@ func generateRandomRecruits(n int) []data.CrewMember {
 		"Byte", "Echo", "Glim", "Frax", "Zip", "Lumen", "Jett", "Neon", "Plex", "Rune",
 	}
 
-	roles := []string{"Pilot", "Engineer", "Scientist"}
+	roles := []string{"Pilot", "Engineer", "Scientist", "Communications Officer"}
+	if len(stationRoles) > 0 {
+		roles = nil
+		for _, role := range stationRoles {
+			roles = append(roles, string(role))
+		}
+	}
 
 	// Generate n number of recruits
 	recruits := make([]data.CrewMember, 0, n)
@@ -797,26 +1089,11 @@ func getHireCost(degree int, role string) int {
 		roleMult = 2
 	case role == "Scientist":
 		roleMult = 4
+	case role == "Communications Officer":
+		roleMult = 3
 	default:
 		roleMult = 1
 	}
 
 	return (100 * roleMult) * degree
 }
-
-// ***************************************
-//
-//	Mission functions
-//
-// ***************************************
-func GenerateStationMissions(n int, templates []data.MissionTemplate, systems []data.StarSystem, currentLocation string) []data.Mission {
-	planets := data.FlattenPlanetsWithSystems(systems)
-
-	var missions []data.Mission
-	for i := 0; i < n; i++ {
-		m := data.GenerateMissionFromTemplate(i, templates, planets, currentLocation)
-		missions = append(missions, m)
-	}
-
-	return missions
-}
@@ -0,0 +1,126 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dominik-merdzik/project-starbyte/internal/data"
+)
+
+// StrandedModel offers the ways a ship out of fuel can get moving again
+type StrandedModel struct {
+	GameSave *data.FullGameSave
+	Cursor   int
+	Message  string // what the last recovery attempt came to, set by game.go
+}
+
+// RecoverMsg signals game.go to try one of the recovery options
+type RecoverMsg struct {
+	Option data.RecoveryOption
+}
+
+func NewStrandedModel(gameSave *data.FullGameSave) StrandedModel {
+	return StrandedModel{
+		GameSave: gameSave,
+	}
+}
+
+func (s StrandedModel) Init() tea.Cmd {
+	return nil
+}
+
+func (s StrandedModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			if s.Cursor > 0 {
+				s.Cursor--
+			}
+		case "down", "j":
+			if s.Cursor < len(data.AllRecoveryOptions)-1 {
+				s.Cursor++
+			}
+		case "enter":
+			option := data.AllRecoveryOptions[s.Cursor]
+			return s, func() tea.Msg {
+				return RecoverMsg{Option: option}
+			}
+		}
+	}
+	return s, nil
+}
+
+func (s StrandedModel) View() string {
+	panelStyle := lipgloss.NewStyle().
+		Width(110).
+		Padding(1, 2).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("160"))
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("160"))
+	hoverStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("215")).Bold(true)
+	defaultStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("217"))
+	blockedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	descriptionStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+
+	save := s.GameSave
+	var content strings.Builder
+	content.WriteString(titleStyle.Render("STRANDED • OUT OF FUEL") + "\n")
+	content.WriteString(descriptionStyle.Render(fmt.Sprintf("The tanks are dry at %s in the %s system.",
+		save.Ship.Location.PlanetName, save.Ship.Location.StarSystemName)) + "\n\n")
+
+	for i, option := range data.AllRecoveryOptions {
+		line := fmt.Sprintf("%-30s %s", s.optionLabel(option), s.optionDetail(option))
+		switch {
+		case i == s.Cursor:
+			content.WriteString(hoverStyle.Render("> "+line) + "\n")
+		case data.CanUseRecovery(save, option) != nil:
+			content.WriteString(blockedStyle.Render("  "+line) + "\n")
+		default:
+			content.WriteString(defaultStyle.Render("  "+line) + "\n")
+		}
+	}
+
+	if data.StationFuelInReach(save) {
+		content.WriteString("\n" + descriptionStyle.Render("There's fuel for sale here. Dock at the station to refuel."))
+	}
+	if s.Message != "" {
+		content.WriteString("\n" + s.Message)
+	}
+	content.WriteString("\n\n" + descriptionStyle.Render("[Enter] Choose • [Esc] Back • [B] reopens this panel"))
+	return panelStyle.Render(content.String())
+}
+
+// optionLabel names an option, the beacon turns into a wait once it is lit
+func (s StrandedModel) optionLabel(option data.RecoveryOption) string {
+	if option == data.RecoverBeacon && data.BeaconLit(s.GameSave) {
+		return "Wait for an answer"
+	}
+	return option.String()
+}
+
+// optionDetail describes what an option costs, or why it can't be used
+func (s StrandedModel) optionDetail(option data.RecoveryOption) string {
+	save := s.GameSave
+	if err := data.CanUseRecovery(save, option); err != nil {
+		return "(" + err.Error() + ")"
+	}
+	switch option {
+	case data.RecoverBeacon:
+		if data.BeaconLit(save) {
+			return fmt.Sprintf("answer due in %dh", data.BeaconHoursLeft(save))
+		}
+		return fmt.Sprintf("help arrives in %d-%dh, %d%% chance it isn't pirates", data.BeaconMinHours, data.BeaconMaxHours, data.RescueChance(save))
+	case data.RecoverTowCredits:
+		station, cost, _ := data.TowOffer(save)
+		return fmt.Sprintf("%d¢ to %s", cost, station.Name)
+	case data.RecoverTowReputation:
+		station, _, _ := data.TowOffer(save)
+		return fmt.Sprintf("-%d standing with the %s, to %s", data.TowReputationCost, data.FactionName(data.TowFaction(station)), station.Name)
+	case data.RecoverJettison:
+		return fmt.Sprintf("%d units to spare, cargo due for delivery is kept", data.SpareCargo(save))
+	}
+	return ""
+}
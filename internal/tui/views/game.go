@@ -23,14 +23,23 @@ type GameModel struct {
 	Travel      components.TravelComponent
 	Dialogue    *components.DialogueComponent
 	GameOver    components.GameOverComponent
+	Combat      *model.CombatModel
+	Diplomacy   *model.DiplomacyModel
 
 	// additional models
 	Ship         model.ShipModel
 	Crew         model.CrewModel
 	Journal      model.JournalModel
+	Log          model.LogModel // The captain's log
 	Map          model.MapModel
 	Collection   model.CollectionModel   // NEW: Collection model
-	SpaceStation model.SpaceStationModel // NEW: SpaceStation model
+	Fabrication  model.FabricationModel  // Recipes made in the ship's fabricator
+	Research     model.ResearchModel     // The ship's research tree
+	Reputation   model.ReputationModel   // Faction standings panel
+	Perks        model.PerkModel         // Perk picks on level up
+	Stranded     model.StrandedModel     // Recovery options while out of fuel
+	docked       bool                    // docked at the station at the current location
+	SpaceStation model.SpaceStationModel // the station last docked at, rebuilt when docking somewhere new
 
 	menuItems  []MenuItem
 	menuCursor int
@@ -57,6 +66,12 @@ type GameModel struct {
 	MissionTemplates []data.MissionTemplate
 	// Events
 	Event *components.EventModel
+	// enemy ship to fight once the current event is closed
+	pendingCombat string
+	// follow-up event to start once the current event is closed
+	pendingEvent int
+	// credits paid for the last mission completed, quest rewards included
+	missionReward int
 
 	playerLostGame bool
 }
@@ -66,12 +81,20 @@ type ActiveView int
 const (
 	ViewNone ActiveView = iota
 	ViewJournal
+	ViewLog
 	ViewCrew
 	ViewMap
 	ViewShip
 	ViewCollection   // NEW: Added Collection view
+	ViewFabrication  // Fabricator recipes
+	ViewResearch     // Research tree
+	ViewReputation   // Faction standings
+	ViewPerks        // Perk picks on level up
 	ViewSpaceStation // NEW: Added SpaceStation view
 	ViewEvent        // Random events
+	ViewCombat       // Ship combat encounters
+	ViewDiplomacy    // Talks with an alien species
+	ViewStranded     // Recovery options while out of fuel
 )
 
 type MenuItem int
@@ -79,10 +102,14 @@ type MenuItem int
 const (
 	MenuNone MenuItem = iota
 	MenuJournal
+	MenuLog
 	MenuShip
 	MenuCrew
 	MenuMap
+	MenuReputation
 	MenuCollection
+	MenuFabrication
+	MenuResearch
 	MenuSpaceStation
 	MenuExit
 )
@@ -91,6 +118,12 @@ type clearNotificationMsg struct{}
 
 type autoSaveMsg time.Time
 
+// shipSystemsMsg is the tick that recharges the shields and runs damage control while the ship is idle
+type shipSystemsMsg time.Time
+
+// shipSystemsInterval is how often the ship's systems run one cycle
+const shipSystemsInterval = 10 * time.Second
+
 func (g GameModel) Init() tea.Cmd {
 	return nil
 }
@@ -99,7 +132,7 @@ func (g GameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
 	// Firstly, always allow the player to quit the game no matter the state
-	if msg, ok := msg.(tea.KeyMsg); ok && msg.String() == "ctrl+c" || msg.String() == "q" {
+	if msg, ok := msg.(tea.KeyMsg); ok && msg.String() == "ctrl+c" || msg.String() == "q" && !g.typing() {
 		// Save the game before quitting
 		g.syncSaveData()
 		saveGameAsync(g.gameSave)
@@ -107,13 +140,32 @@ func (g GameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return g, tea.Quit
 	}
 
-	// The player loses the game under these conditions
-	if g.Ship.HullHealth <= 0 || g.Ship.EngineFuel <= 0 {
-		g.playerLostGame = true
+	// The player loses the game once the ship or its crew can't go on
+	// a dead or mutinous crew is reported ahead of an empty tank, so only a crew that can carry on waits to be rescued
+	cause := data.CheckLoss(g.Ship.HullHealth, g.Ship.EngineFuel, g.gameSave.Crew)
+	if cause == data.LossStranded && !g.playerLostGame && !g.gameSave.GameMetadata.GameOver {
+		// the save is only synced when the stranded state changes, as the ship runs dry or seems out of options
+		if g.gameSave.Stranded == nil {
+			g.syncSaveData()
+			g.strand()
+		} else if !data.CanRecover(g.gameSave) {
+			g.syncSaveData()
+		}
+		// a ship out of fuel is only lost once every way of getting more is used up, and never mid-fight
+		if g.activeView == ViewCombat || data.CanRecover(g.gameSave) {
+			cause = data.LossNone
+		}
+	} else if cause == data.LossNone && g.gameSave.Stranded != nil {
+		g.gameSave.Stranded = nil
+		if g.activeView == ViewStranded {
+			g.activeView = ViewNone
+		}
+	}
+	if cause != data.LossNone {
+		if !g.playerLostGame {
+			g.endRun(cause)
+		}
 		g.activeView = ViewNone
-		g.syncSaveData()
-
-		saveGameAsync(g.gameSave)
 
 		return g, nil // Skip everything else in Update()
 	}
@@ -123,6 +175,9 @@ func (g GameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmds = append(cmds, tea.Tick(2*time.Second, func(t time.Time) tea.Msg {
 			return autoSaveMsg(t)
 		}))
+		cmds = append(cmds, tea.Tick(shipSystemsInterval, func(t time.Time) tea.Msg {
+			return shipSystemsMsg(t)
+		}))
 	}
 
 	// update active view
@@ -133,6 +188,12 @@ func (g GameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			g.Journal = j
 		}
 		cmds = append(cmds, journalCmd)
+	case ViewLog:
+		newLog, logCmd := g.Log.Update(msg)
+		if l, ok := newLog.(model.LogModel); ok {
+			g.Log = l
+		}
+		cmds = append(cmds, logCmd)
 	case ViewCrew:
 		newCrew, crewCmd := g.Crew.Update(msg)
 		if c, ok := newCrew.(model.CrewModel); ok {
@@ -151,12 +212,42 @@ func (g GameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			g.Ship = s
 		}
 		cmds = append(cmds, shipCmd)
+	case ViewReputation:
+		newReputation, reputationCmd := g.Reputation.Update(msg)
+		if r, ok := newReputation.(model.ReputationModel); ok {
+			g.Reputation = r
+		}
+		cmds = append(cmds, reputationCmd)
+	case ViewPerks:
+		newPerks, perksCmd := g.Perks.Update(msg)
+		if p, ok := newPerks.(model.PerkModel); ok {
+			g.Perks = p
+		}
+		cmds = append(cmds, perksCmd)
+	case ViewStranded:
+		newStranded, strandedCmd := g.Stranded.Update(msg)
+		if s, ok := newStranded.(model.StrandedModel); ok {
+			g.Stranded = s
+		}
+		cmds = append(cmds, strandedCmd)
 	case ViewCollection: // NEW: Update Collection view
 		newCollection, CollectionCmd := g.Collection.Update(msg)
 		if col, ok := newCollection.(model.CollectionModel); ok {
 			g.Collection = col
 		}
 		cmds = append(cmds, CollectionCmd)
+	case ViewFabrication:
+		newFabrication, fabricationCmd := g.Fabrication.Update(msg)
+		if f, ok := newFabrication.(model.FabricationModel); ok {
+			g.Fabrication = f
+		}
+		cmds = append(cmds, fabricationCmd)
+	case ViewResearch:
+		newResearch, researchCmd := g.Research.Update(msg)
+		if r, ok := newResearch.(model.ResearchModel); ok {
+			g.Research = r
+		}
+		cmds = append(cmds, researchCmd)
 	case ViewSpaceStation: // NEW: Update SpaceStation view
 		newSpaceStation, SpaceStationCmd := g.SpaceStation.Update(msg)
 		if ss, ok := newSpaceStation.(model.SpaceStationModel); ok {
@@ -183,6 +274,19 @@ func (g GameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// schedule the next auto-save tick after 2 seconds *UPDATED TO 5 FOR TESTING*
 		return g, tea.Tick(5*time.Second, func(t time.Time) tea.Msg { return autoSaveMsg(t) })
+	case shipSystemsMsg:
+		// shields are busy soaking up fire during combat, so they only recharge outside of it
+		if g.activeView != ViewCombat {
+			g.regenerateShields(1)
+			g.runDamageControl()
+
+			// crew, cargo and standing change all the time, so recheck objectives and what the quest graph is waiting on
+			g.checkObjectives()
+			g.syncSaveData()
+			g.advanceQuests(data.AdvanceQuests(g.gameSave))
+
+		}
+		return g, tea.Tick(shipSystemsInterval, func(t time.Time) tea.Msg { return shipSystemsMsg(t) })
 	case clearNotificationMsg:
 		g.notification = ""
 		return g, nil
@@ -190,13 +294,15 @@ func (g GameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Mission started. Trigger mission travel sequence
 	case model.StartMissionMsg:
 		g.TrackedMission = &msg.Mission // Set the mission to track
-		destination := g.TrackedMission.Location
+		destination := g.TrackedMission.Destination()
 		currentLocation := g.Ship.Location
 
 		// Check if travel is needed
 		needsTravel := !currentLocation.IsEqual(destination)
 
-		if needsTravel && !g.isTravelling {
+		if needsTravel && g.gameSave.Stranded != nil {
+			g.notification = "The tanks are dry. Press [B] for ways to get moving again."
+		} else if needsTravel && !g.isTravelling {
 			// --- Calculate Duration ---
 			engineLevel := g.gameSave.Ship.Upgrades.Engine.CurrentLevel
 			maxEngineLevel := g.gameSave.Ship.Upgrades.Engine.MaxLevel // Make sure this value is correct in your save/defaults
@@ -214,18 +320,23 @@ func (g GameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			cmds = append(cmds, g.Travel.StartTravel(destination, travelDuration))
 
 		} else if !needsTravel {
-			// If we're already there, set the mission as in progress and init dialogue
-			g.TrackedMission.Status = data.MissionStatusInProgress
-			if len(g.TrackedMission.Dialogue) > 0 {
-				d := components.NewDialogueComponentFromMission(g.TrackedMission.Dialogue)
-				g.Dialogue = &d
-			} else {
-				g.Dialogue = nil
-			}
+			// If we're already there, start the mission (fighting its target first if it has one)
+			cmds = append(cmds, g.arriveAtTrackedMission())
 			// Optionally, clear isTravelling if it was somehow true but no travel needed
 			g.isTravelling = false
 		}
 
+	// typewriter reveal for the mission briefing and event dialogue
+	case components.DialogueTickMsg:
+		if g.Dialogue != nil {
+			cmds = append(cmds, g.Dialogue.Update(msg))
+		}
+		if g.Event != nil {
+			var cmd tea.Cmd
+			g.Event, cmd = g.Event.Update(msg)
+			cmds = append(cmds, cmd)
+		}
+
 		// (1/3) Timer for travel component
 	case components.TravelTickMsg:
 		// Only update if actively travelling AND not yet complete
@@ -242,7 +353,11 @@ func (g GameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 				// Trigger a Random Event 30% chance
 				if rand.Intn(100) < 30 {
-					cmds = append(cmds, TriggerRandomEvent())
+					// the event happens at the destination, so location preconditions are checked against it
+					g.syncSaveData()
+					at := *g.gameSave
+					at.Ship.Location = g.Travel.DestLocation
+					cmds = append(cmds, TriggerRandomEvent(&at))
 				}
 			}
 		}
@@ -256,6 +371,8 @@ func (g GameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	// Updates ship values from random event
 	case components.ApplyEffectsMsg:
+		g.awardExperience(data.XPEventResolved)
+		data.WriteLog(g.gameSave, data.LogEvent, "%s: %s", msg.Event, msg.Outcome)
 		for key, value := range msg.Effects {
 			switch key {
 			case "fuel": // Fuel between 0-MaxFuel
@@ -270,6 +387,7 @@ func (g GameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case "credits": // May be able to go into credit debt, so can be negative
 				g.Credits += value
 				g.gameSave.Player.Credits = g.Credits
+				g.gameSave.GameMetadata.Stats.Earn(value) // only a reward counts, not a cost
 			case "morale": // Morale between 0-100
 				for i := range g.Crew.CrewMembers {
 					if g.Crew.CrewMembers[i].Morale+value <= 100 && g.Crew.CrewMembers[i].Morale+value >= 0 {
@@ -288,8 +406,13 @@ func (g GameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					g.Ship.Food = 0
 				}
 				g.gameSave.Ship.Food = g.Ship.Food
-			case "hull": // Hull between 0-MaxHullHealth
-				if g.Ship.HullHealth+value <= g.Ship.MaxHullHealth && g.Ship.HullHealth+value >= 0 {
+			case "shields": // Shields between 0-MaxShieldStrength
+				g.Ship.ShieldStrength = max(min(g.Ship.ShieldStrength+value, g.Ship.MaxShieldStrength), 0)
+				g.gameSave.Ship.ShieldStrength = g.Ship.ShieldStrength
+			case "hull": // Hull between 0-MaxHullHealth, damage hits the shields first
+				if value < 0 {
+					g.absorbDamage(-value)
+				} else if g.Ship.HullHealth+value <= g.Ship.MaxHullHealth && g.Ship.HullHealth+value >= 0 {
 					g.Ship.HullHealth += value
 				} else if g.Ship.HullHealth+value > g.Ship.MaxHullHealth {
 					g.Ship.HullHealth = g.Ship.MaxHullHealth
@@ -297,22 +420,66 @@ func (g GameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					g.Ship.HullHealth = 0
 				}
 				g.gameSave.Ship.HullIntegrity = g.Ship.HullHealth
+			default:
+				// "reputation:<faction>" changes the player's standing with that faction
+				if faction, ok := strings.CutPrefix(key, data.ReputationEffectPrefix); ok {
+					data.ChangeReputation(&g.gameSave.Player.Reputation, faction, value)
+				}
+				// "item:<name>" adds or removes cargo
+				if item, ok := strings.CutPrefix(key, data.ItemEffectPrefix); ok {
+					if value < 0 {
+						data.RemoveCargoItem(&g.gameSave.Ship.Cargo, item, -value)
+					} else if lost := data.StowCargo(g.gameSave, item, value); lost > 0 {
+						g.notification = fmt.Sprintf("No room in the hold for %d %s, it was left behind.", lost, item)
+					}
+					g.Ship.Cargo = g.gameSave.Ship.Cargo
+				}
 			}
 		}
+		g.pendingCombat = msg.Combat
+		g.pendingEvent = msg.Next
 		g.syncSaveData() // Ensure updates are saved
+
+		// a script offered new quests
+		for _, id := range msg.Quests {
+			g.advanceQuests(data.OfferQuest(g.gameSave, id))
+		}
 		return g, nil
 
 	// Random event dialogue started
 	case StartEventMsg:
+		// Don't interrupt a fight or talks that started on arrival
+		if g.activeView == ViewCombat || g.activeView == ViewDiplomacy {
+			return g, nil
+		}
 		g.activeView = ViewEvent
-		g.Event = components.NewEventModel(msg.Event)
-		return g, nil
+		g.syncSaveData()
+		data.RecordEvent(g.gameSave, msg.Event.ID)
+		event := data.RenderEvent(g.gameSave, *msg.Event)
+		g.Event = components.NewEventModel(&event, g.gameSave)
+		g.Event.ShowEffects = data.PerkRank(g.gameSave.Player, data.PerkInsight) > 0
+		return g, g.Event.Init()
 
 	// Random event dialogue finished
 	case components.EventFinishedMsg:
 		g.activeView = ViewNone
 		g.Event = nil
 
+		// The event led into a fight
+		if g.pendingCombat != "" {
+			g.startCombat(g.pendingCombat)
+			g.pendingCombat = ""
+		}
+
+		// The event chains into another one
+		if g.pendingEvent != 0 {
+			next := data.FindEvent(g.pendingEvent)
+			g.pendingEvent = 0
+			if next != nil && data.EventAvailable(g.gameSave, *next) {
+				return g, func() tea.Msg { return StartEventMsg{Event: next} }
+			}
+		}
+
 		// Ensure travel is completed after an event
 		if g.isTravelling {
 			//g.isTravelling = false
@@ -322,6 +489,147 @@ func (g GameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		return g, nil
 
+	// Damage and fuel burned during a combat turn
+	case model.CombatTurnMsg:
+		g.Ship.HullHealth = msg.Ship.HullIntegrity
+		g.Ship.EngineFuel = msg.Ship.Fuel
+		g.Ship.ShieldStrength = msg.Ship.ShieldStrength
+		g.Ship.Modules = msg.Ship.Modules
+		g.syncSaveData()
+		return g, nil
+
+	// Combat over and the result dismissed
+	case model.CombatFinishedMsg:
+		g.activeView = ViewNone
+		g.Combat = nil
+
+		g.Ship.HullHealth = msg.Ship.HullIntegrity
+		g.Ship.EngineFuel = msg.Ship.Fuel
+		g.Ship.ShieldStrength = msg.Ship.ShieldStrength
+		g.Ship.Modules = msg.Ship.Modules
+		g.syncSaveData()
+
+		lostLoot := data.ApplyCombatRewards(g.gameSave, msg.Encounter)
+		g.Credits = g.gameSave.Player.Credits
+		g.Ship.Cargo = g.gameSave.Ship.Cargo
+
+		switch msg.Encounter.Outcome {
+		case data.CombatVictory:
+			g.notification = fmt.Sprintf("Defeated the %s! +%d credits", msg.Encounter.Enemy.Name, msg.Encounter.Enemy.Loot.Credits)
+			if lostLoot > 0 {
+				g.notification += fmt.Sprintf(", %d units of loot left behind for want of room in the hold", lostLoot)
+			}
+			data.WriteLog(g.gameSave, data.LogEvent, "Defeated the %s.", msg.Encounter.Enemy.Name)
+			// Defeating a mission's target lets the mission continue
+			if g.TrackedMission != nil && data.RecordVictory(g.TrackedMission, msg.Encounter.Enemy.ID) {
+				if g.TrackedMission.Status == data.MissionStatusNotStarted && g.Ship.Location.IsEqual(g.TrackedMission.Location) {
+					cmds = append(cmds, g.startTrackedMissionDialogue())
+				} else {
+					g.checkObjectives()
+				}
+			}
+		case data.CombatFled:
+			g.notification = "You escaped the fight."
+			data.WriteLog(g.gameSave, data.LogEvent, "Escaped a fight with the %s.", msg.Encounter.Enemy.Name)
+			// a mission's target is still waiting and can be taken on again by starting the mission from the journal
+			if g.missionTarget(msg.Encounter.Enemy.ID) {
+				g.notification = fmt.Sprintf("You escaped the %s. Start %s from the journal to take it on again.", msg.Encounter.Enemy.Name, g.TrackedMission.Title)
+			}
+		case data.CombatTruce:
+			g.notification = fmt.Sprintf("The %s stood down.", msg.Encounter.Enemy.Name)
+			data.WriteLog(g.gameSave, data.LogEvent, "The %s stood down.", msg.Encounter.Enemy.Name)
+			g.awardExperience(data.XPCombatTruce)
+			// a mission's target that stands down can't be beaten, so the job is off
+			if g.missionTarget(msg.Encounter.Enemy.ID) {
+				g.failTrackedMission(fmt.Sprintf("The %s stood down before it could be dealt with", msg.Encounter.Enemy.Name))
+			}
+		}
+		if msg.Encounter.Outcome == data.CombatVictory {
+			g.awardExperience(data.XPCombatVictory)
+		}
+
+		return g, utilities.PushSave(g.gameSave, g.syncSaveData)
+
+	// A round of talks with an alien species
+	case model.NegotiateMsg:
+		if g.Diplomacy == nil {
+			return g, nil
+		}
+		g.syncSaveData()
+		if err := data.Negotiate(g.gameSave, g.Diplomacy.Negotiation, msg.Approach); err != nil {
+			g.Diplomacy.Message = err.Error()
+			return g, nil
+		}
+		g.Diplomacy.Message = ""
+		g.Credits = g.gameSave.Player.Credits
+		return g, nil
+
+	// Talks over and the result dismissed
+	case model.NegotiationFinishedMsg:
+		g.activeView = ViewNone
+		g.Diplomacy = nil
+
+		n := msg.Negotiation
+		g.syncSaveData()
+		data.ApplyNegotiation(g.gameSave, n)
+		if n.FirstContact {
+			g.awardExperience(data.XPFirstContact)
+		}
+		g.advanceClock(data.NegotiationHours)
+		g.notification = fmt.Sprintf("Talks with the %s ended: %s.", n.Species.Name, n.Outcome)
+
+		// Talks a mission was waiting on let it continue, their outcome settles where the quest goes next
+		if g.TrackedMission != nil && data.RecordNegotiation(g.TrackedMission, n.Species.ID, n.Outcome) {
+			g.checkObjectives()
+		}
+		return g, utilities.PushSave(g.gameSave, g.syncSaveData)
+
+	case model.OpenTalksMsg:
+		species := data.FindSpecies(msg.Species)
+		if species == nil {
+			return g, nil
+		}
+		if g.isTravelling {
+			g.Reputation.Message = "Talks can't be held while travelling."
+			return g, nil
+		}
+		g.syncSaveData()
+		if err := data.CanOpenTalks(g.gameSave, *species); err != nil {
+			g.Reputation.Message = err.Error()
+			return g, nil
+		}
+		g.Reputation.Message = ""
+		g.startNegotiation(species.ID)
+		return g, nil
+
+	case model.TradeGoodMsg:
+		species := data.FindSpecies(msg.Species)
+		if species == nil {
+			return g, nil
+		}
+		if g.isTravelling {
+			g.Reputation.Message = "The market can't be reached while travelling."
+			return g, nil
+		}
+		g.syncSaveData()
+		trade := data.SellTradeGood
+		if msg.Buy {
+			trade = data.BuyTradeGood
+		}
+		price, err := trade(g.gameSave, *species, msg.Good)
+		if err != nil {
+			g.Reputation.Message = err.Error()
+			return g, nil
+		}
+		g.Credits = g.gameSave.Player.Credits
+		g.Ship.Cargo = g.gameSave.Ship.Cargo
+		if msg.Buy {
+			g.Reputation.Message = fmt.Sprintf("Bought %s for %d¢.", msg.Good, price)
+		} else {
+			g.Reputation.Message = fmt.Sprintf("Sold %s for %d¢.", msg.Good, price)
+		}
+		return g, utilities.PushSave(g.gameSave, g.syncSaveData)
+
 	// ---------------------------
 	// Handle key presses
 	// ---------------------------
@@ -334,8 +642,26 @@ func (g GameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return g, cmd
 		}
 
+		// Send key presses to combat.go while fighting
+		if g.activeView == ViewCombat && g.Combat != nil {
+			newCombat, cmd := g.Combat.Update(msg)
+			if c, ok := newCombat.(model.CombatModel); ok {
+				g.Combat = &c
+			}
+			return g, cmd
+		}
+
+		// Send key presses to diplomacy.go during talks
+		if g.activeView == ViewDiplomacy && g.Diplomacy != nil {
+			newDiplomacy, cmd := g.Diplomacy.Update(msg)
+			if d, ok := newDiplomacy.(model.DiplomacyModel); ok {
+				g.Diplomacy = &d
+			}
+			return g, cmd
+		}
+
 		// First, if an active view is set, process escape.
-		if g.activeView != ViewNone && msg.String() == "esc" {
+		if g.activeView != ViewNone && msg.String() == "esc" && !g.typing() {
 			g.activeView = ViewNone
 			g.selectedItem = MenuNone
 			return g, tea.Batch(cmds...)
@@ -344,22 +670,37 @@ func (g GameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return g, tea.Batch(cmds...)
 		}
 
-		// DIALOGUE -- Advance through it with Enter
-		if g.TrackedMission != nil && g.TrackedMission.Status == data.MissionStatusInProgress {
-			if msg.String() == "enter" {
-				if g.Dialogue == nil {
-					// initialize dialogue with the first line already shown
-					d := components.NewDialogueComponentFromMission(g.TrackedMission.Dialogue)
-					g.Dialogue = &d
-				} else {
-					g.Dialogue.Next()
+		// DIALOGUE -- Advance through it with Enter, once it is over the menu keys work again
+		if g.TrackedMission != nil && g.TrackedMission.Status == data.MissionStatusInProgress && !g.TrackedMission.Briefed {
+			// Briefings can stop on a choice, which may settle the quest's branch or change the game
+			if g.Dialogue != nil && g.Dialogue.AwaitingChoice() {
+				var cmd tea.Cmd
+				switch msg.String() {
+				case "up", "k":
+					g.Dialogue.MoveChoice(-1)
+				case "down", "j":
+					g.Dialogue.MoveChoice(1)
+				case "enter":
+					var choice *data.DialogueChoice
+					choice, cmd = g.Dialogue.Choose()
+					g.applyDialogueChoice(*choice)
 				}
-				// if we've advanced past all dialogue lines, mark mission as completed
-				if g.Dialogue.CurrentLine >= len(g.TrackedMission.Dialogue) {
-					g.TrackedMission.Status = data.MissionStatusCompleted
-					g.Dialogue = nil // Clear dialogue when complete
+				g.finishMissionDialogue()
+				return g, cmd
+			}
+			if msg.String() == "enter" || msg.String() == "backspace" {
+				var cmd tea.Cmd
+				switch {
+				case g.Dialogue == nil:
+					g.Dialogue = g.newMissionDialogue()
+					cmd = g.Dialogue.Start()
+				case msg.String() == "backspace":
+					cmd = g.Dialogue.Previous()
+				default:
+					cmd = g.Dialogue.Next()
 				}
-				return g, nil
+				g.finishMissionDialogue()
+				return g, cmd
 			}
 		}
 
@@ -367,8 +708,7 @@ func (g GameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		switch msg.String() {
 		case "up", "k":
 			// Skip over space station if not at one
-			planet := g.gameSave.Ship.Location.GetFullPlanet(g.gameSave.GameMap)
-			hasStation := planet.Type == "Space Station"
+			hasStation := data.StationAt(g.gameSave.GameMap, g.gameSave.Ship.Location) != nil
 
 			for {
 
@@ -382,8 +722,7 @@ func (g GameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		case "down", "j":
 			// Skip over space station if not at one
-			planet := g.gameSave.Ship.Location.GetFullPlanet(g.gameSave.GameMap)
-			hasStation := planet.Type == "Space Station"
+			hasStation := data.StationAt(g.gameSave.GameMap, g.gameSave.Ship.Location) != nil
 
 			for {
 				if g.menuCursor < len(g.menuItems)-1 {
@@ -400,24 +739,88 @@ func (g GameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			switch g.selectedItem {
 			case MenuJournal:
 				g.activeView = ViewJournal
+			case MenuLog:
+				g.activeView = ViewLog
 			case MenuCrew:
 				g.activeView = ViewCrew
 			case MenuMap:
 				g.Map.Ship.Location = g.Ship.Location // Ensure Map has the latest ship location before activating the view
+				g.Map.GameMap = g.gameSave.GameMap    // and the latest charts
 				g.activeView = ViewMap
 			case MenuShip:
 				g.activeView = ViewShip
+			case MenuReputation:
+				g.activeView = ViewReputation
 			case MenuCollection: // NEW: Activate Collection view
+				g.Collection.Message = ""
 				g.activeView = ViewCollection
+			case MenuFabrication:
+				g.syncSaveData() // recipes are checked against the save
+				g.Fabrication.Message = ""
+				g.activeView = ViewFabrication
+			case MenuResearch:
+				g.Research.Message = ""
+				g.activeView = ViewResearch
 			case MenuSpaceStation: // NEW: Activate SpaceStation view
-				// Check if current planet is a space station
-				planet := g.gameSave.Ship.Location.GetFullPlanet(g.gameSave.GameMap)
-				if planet.Type == "Space Station" {
-					g.activeView = ViewSpaceStation
+				// Only a location that holds a station can be docked at
+				station := data.StationAt(g.gameSave.GameMap, g.Ship.Location)
+				if station == nil {
+					g.notification = fmt.Sprintf("There is no station to dock with at %s", g.Ship.Location.PlanetName)
+				} else if !data.CanDock(g.gameSave.Player.Reputation, station.Faction) {
+					g.notification = fmt.Sprintf("The %s refuses you docking rights", data.FactionName(station.Faction))
 				} else {
-					//Idk what to put here
+					// Docking takes a little time, browsing the station once docked doesn't
+					if !g.docked {
+						g.advanceClock(data.DockingHours)
+						g.docked = true
+					}
+					g.syncSaveData()
+					if g.SpaceStation.Station.ID != station.ID {
+						g.SpaceStation = model.NewSpaceStationModel(*station, g.gameSave)
+					}
+					// the board takes down expired postings and puts up new ones as the clock runs
+					g.SpaceStation.SetBoard(data.MissionBoard(g.gameSave, *station, g.MissionTemplates))
+					day := data.Day(g.gameSave.GameMetadata.ClockHours)
+					g.SpaceStation.RefreshRecruits(day)
+
+					// Give the station the ship as it is now, it works on its own copy
+					g.SpaceStation.Ship = g.gameSave.Ship
+					g.SpaceStation.Ship.Modules = append([]data.Module(nil), g.gameSave.Ship.Modules...)
+					g.SpaceStation.Credits = g.Credits
+					g.SpaceStation.Message = ""
+					// prices follow the player's standing and drift from day to day
+					g.SpaceStation.PriceModifier = data.StationPriceModifier(g.gameSave, *station)
+					g.SpaceStation.FuelPrice = station.Fuel(g.gameSave.Player)
+					g.SpaceStation.CrewCount = data.BerthsTaken(g.gameSave.Crew)
+					g.SpaceStation.CrewCapacity = data.CrewCapacity(g.gameSave.Player)
+					g.activeView = ViewSpaceStation
 				}
 			}
+		case "r":
+			// Stand the crew down to rest, time passes and the injured recover
+			if g.isTravelling {
+				g.notification = "The crew can't rest while travelling."
+				return g, nil
+			}
+			g.syncSaveData()
+			report := data.Rest(g.gameSave)
+			g.notification = fmt.Sprintf("The crew rested until stardate %s.", data.Stardate(g.gameSave.GameMetadata.ClockHours))
+			g.applyClockReport(report)
+			return g, utilities.PushSave(g.gameSave, g.syncSaveData)
+		case "p":
+			// Open the perk picker
+			g.Perks = model.NewPerkModel(g.gameSave)
+			g.selectedItem = MenuNone
+			g.activeView = ViewPerks
+		case "b":
+			// Reopen the recovery options while out of fuel
+			if g.gameSave.Stranded == nil {
+				g.notification = "The ship has fuel, there's no need for help."
+				return g, nil
+			}
+			g.Stranded = model.NewStrandedModel(g.gameSave)
+			g.selectedItem = MenuNone
+			g.activeView = ViewStranded
 		case "s":
 
 			// Check if 2 seconds have passed since the last manual save
@@ -464,6 +867,11 @@ func (g GameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// This message is received when travelling (map.go)
 	// It will update the ship's location and fuel and trigger a save
 	case model.TravelUpdateMsg:
+		// A stranded ship isn't going anywhere under its own power
+		if msg.ShowTravel && g.gameSave.Stranded != nil {
+			g.notification = "The tanks are dry. Press [B] for ways to get moving again."
+			return g, nil
+		}
 		// Start travel animation if requested AND not already travelling
 		if msg.ShowTravel && !g.isTravelling {
 			destination := msg.Location        // Destination from map selection
@@ -485,7 +893,32 @@ func (g GameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Start travel component, passing calculated duration
 			cmds = append(cmds, g.Travel.StartTravel(destination, travelDuration))
 		}
+	// Scanning from the map charts what's around the ship
+	case model.ScanMsg:
+		if g.isTravelling {
+			g.notification = "The scanners can't get a clear reading while travelling."
+			return g, nil
+		}
+		g.syncSaveData()
+		report := data.Scan(g.gameSave)
+		g.advanceClock(data.ScanHours)
+		charted := len(report.Systems) + len(report.Planets)
+		g.awardExperience(data.XPScan * charted)
+
+		switch {
+		case report.Anomaly != nil:
+			g.notification = fmt.Sprintf("Scan complete: picked up %s, it will fade by stardate %s.",
+				report.Anomaly.Name, data.Stardate(report.Anomaly.ExpiresAt))
+		case charted > 0:
+			g.notification = fmt.Sprintf("Scan complete: charted %s.", strings.Join(append(report.Systems, report.Planets...), ", "))
+		default:
+			g.notification = "Scan complete: nothing new within range."
+		}
+		g.Map.GameMap = g.gameSave.GameMap
+		g.Map.GameSave = g.gameSave
+		return g, utilities.PushSave(g.gameSave, g.syncSaveData)
 	case model.CrewUpdateMsg:
+		g.advanceClock(msg.Hours)
 		return g, utilities.PushSave(g.gameSave, func() {
 			g.syncSaveData() // Sync save data after crew upgrade
 		})
@@ -497,8 +930,8 @@ func (g GameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// Calculate fuel here to avoid using old states
 		newFuel := current + amount
-		if newFuel > 200 {
-			newFuel = 200
+		if newFuel > g.Ship.MaxFuel {
+			newFuel = g.Ship.MaxFuel
 		}
 
 		g.Ship.EngineFuel = newFuel
@@ -506,46 +939,36 @@ func (g GameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		g.Credits -= totalCost
 		g.gameSave.Player.Credits = g.Credits
+		g.awardExperience(data.TradeXP(totalCost))
+		data.WriteLog(g.gameSave, data.LogPurchase, "Bought %d fuel for %d credits.", newFuel-current, totalCost)
 
 		g.syncSaveData() // Sync save data
 		return g, utilities.PushSave(g.gameSave, func() {
 			g.syncSaveData() // Sync the save data
 		})
 	case model.RepairUpdateMsg:
-		// Repair hull
-		amount := msg.Amount
-		totalCost := msg.Credit
-		current := g.Ship.HullHealth
-
-		max := g.Ship.MaxHullHealth
-		newHull := current + amount
-		if newHull > max {
-			newHull = max
-		}
-
-		g.Ship.HullHealth = newHull
-		g.gameSave.Ship.HullIntegrity = newHull
+		// Repair the chosen part of the ship
+		g.syncSaveData()
+		data.ApplyRepair(&g.gameSave.Ship, msg.Target, msg.Amount)
+		g.mirrorShipCondition()
+		data.WriteLog(g.gameSave, data.LogPurchase, "Repaired the %s for %d credits.", msg.Target.Label(g.gameSave.Ship), msg.Credit)
 
-		g.Credits -= totalCost
+		g.Credits -= msg.Credit
 		g.gameSave.Player.Credits = g.Credits
+		g.awardExperience(data.TradeXP(msg.Credit))
 
 		g.syncSaveData()
 		return g, utilities.PushSave(g.gameSave, func() {
 			g.syncSaveData()
 		})
 	case model.UpgradeUpdateMsg:
-		switch msg.UpgradeCursor {
-		case 0:
-			g.Ship.Upgrades.Engine.CurrentLevel = msg.NewLevel          // Update current ship
-			g.gameSave.Ship.Upgrades.Engine.CurrentLevel = msg.NewLevel // Then update game save
-		case 1:
-			g.Ship.Upgrades.WeaponSystems.CurrentLevel = msg.NewLevel
-			g.gameSave.Ship.Upgrades.WeaponSystems.CurrentLevel = msg.NewLevel
-		case 2:
-			g.Ship.Upgrades.CargoExpansion.CurrentLevel = msg.NewLevel
-			g.gameSave.Ship.Upgrades.CargoExpansion.CurrentLevel = msg.NewLevel
-		}
+		upgradeType := data.UpgradeType(msg.UpgradeCursor)
+		g.Ship.Upgrades.Level(upgradeType).CurrentLevel = msg.NewLevel          // Update current ship
+		g.gameSave.Ship.Upgrades.Level(upgradeType).CurrentLevel = msg.NewLevel // Then update game save
+		g.applyUpgradeEffects()
 
+		g.awardExperience(data.TradeXP(g.Credits - msg.Credits))
+		data.WriteLog(g.gameSave, data.LogPurchase, "Upgraded the %s to level %d for %d credits.", upgradeType, msg.NewLevel, g.Credits-msg.Credits)
 		g.Credits = msg.Credits
 		g.gameSave.Player.Credits = msg.Credits
 
@@ -555,6 +978,8 @@ func (g GameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		})
 	case model.HireCrewMsg:
 		g.gameSave.Crew = append(g.gameSave.Crew, msg.Crew)
+		data.WriteLog(g.gameSave, data.LogCrew, "Hired %s, a degree %d %s, for %d credits.", msg.Crew.Name, msg.Crew.Degree, msg.Crew.Role, g.Credits-msg.Credits)
+		g.awardExperience(data.TradeXP(g.Credits - msg.Credits))
 		g.Credits = msg.Credits
 		g.gameSave.Player.Credits = msg.Credits
 
@@ -562,7 +987,205 @@ func (g GameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return g, utilities.PushSave(g.gameSave, func() {
 			g.syncSaveData()
 		})
+	case model.PerkChosenMsg:
+		if err := data.ChoosePerk(&g.gameSave.Player, msg.PerkID); err != nil {
+			g.Perks.Message = err.Error()
+			return g, nil
+		}
+		if perk := data.FindPerk(msg.PerkID); perk != nil {
+			g.Perks.Message = fmt.Sprintf("%s learned!", perk.Name)
+		}
+		return g, utilities.PushSave(g.gameSave, g.syncSaveData)
+	case model.RecoverMsg:
+		if g.gameSave.Stranded == nil {
+			return g, nil
+		}
+		g.syncSaveData()
+		if err := data.CanUseRecovery(g.gameSave, msg.Option); err != nil {
+			g.Stranded.Message = "Can't do that: " + err.Error() + "."
+			return g, nil
+		}
+		switch msg.Option {
+		case data.RecoverBeacon:
+			if !data.BeaconLit(g.gameSave) {
+				hours := data.BroadcastBeacon(g.gameSave)
+				g.Stranded.Message = fmt.Sprintf("The beacon is broadcasting. Someone should answer within %dh.", hours)
+				data.WriteLog(g.gameSave, data.LogEvent, "Stranded at %s, broadcast a distress beacon.", g.Ship.Location.PlanetName)
+				break
+			}
+			// the crew sit tight until someone answers
+			g.advanceClock(data.BeaconHoursLeft(g.gameSave))
+			if data.AnswerBeacon(g.gameSave) {
+				g.Ship.EngineFuel = g.gameSave.Ship.Fuel
+				g.activeView = ViewNone
+				g.notification = fmt.Sprintf("A rescue ship answered the beacon and transferred %d fuel.", data.BeaconRescueFuel)
+				data.WriteLog(g.gameSave, data.LogEvent, "A rescue ship answered the distress beacon.")
+				break
+			}
+			data.WriteLog(g.gameSave, data.LogEvent, "Pirates answered the distress beacon.")
+			if g.startCombat(data.BeaconPirates) {
+				g.notification = "Pirates answered the beacon!"
+			}
+		case data.RecoverTowCredits, data.RecoverTowReputation:
+			station, hours, err := data.Tow(g.gameSave, msg.Option == data.RecoverTowReputation)
+			if err != nil {
+				g.Stranded.Message = "Can't do that: " + err.Error() + "."
+				return g, nil
+			}
+			g.Credits = g.gameSave.Player.Credits
+			g.Ship.EngineFuel = g.gameSave.Ship.Fuel
+			g.Ship.Location = g.gameSave.Ship.Location
+			g.docked = false
+			g.advanceClock(hours)
+			g.activeView = ViewNone
+			g.notification = fmt.Sprintf("Towed to %s. Dock to refuel.", station.Name)
+			data.WriteLog(g.gameSave, data.LogArrival, "Towed to %s in the %s system.", station.Name, station.StarSystem)
+			g.checkObjectives()
+		case data.RecoverJettison:
+			units, fuel := data.JettisonCargo(g.gameSave)
+			g.Ship.Cargo = g.gameSave.Ship.Cargo
+			g.Ship.EngineFuel = g.gameSave.Ship.Fuel
+			g.activeView = ViewNone
+			g.notification = fmt.Sprintf("Fed %d units of cargo to the reactor for %d fuel.", units, fuel)
+			data.WriteLog(g.gameSave, data.LogEvent, "Jettisoned %d units of cargo and recovered %d fuel.", units, fuel)
+		}
+		return g, utilities.PushSave(g.gameSave, g.syncSaveData)
+	case model.AbandonMissionMsg:
+		g.syncSaveData()
+		if i := g.missionIndex(msg.Mission); i >= 0 {
+			g.advanceQuests(data.AbandonMission(g.gameSave, i))
+			if g.TrackedMission != nil && g.TrackedMission.SameAs(msg.Mission) {
+				g.TrackedMission = nil
+				g.Dialogue = nil
+			}
+			g.notification = fmt.Sprintf("Abandoned %s", msg.Mission.Title)
+			if msg.Mission.Faction != "" {
+				g.notification += fmt.Sprintf(". %s standing -%d", data.FactionName(msg.Mission.Faction), data.MissionAbandonPenalty)
+			}
+		}
+		return g, utilities.PushSave(g.gameSave, g.syncSaveData)
+
+	case model.WriteNoteMsg:
+		data.WriteLog(g.gameSave, data.LogNote, "%s", msg.Text)
+		return g, utilities.PushSave(g.gameSave, g.syncSaveData)
+	case model.ExportLogMsg:
+		path, err := data.ExportLog(g.gameSave)
+		if err != nil {
+			g.notification = fmt.Sprintf("Couldn't export the log: %v", err)
+		} else {
+			g.notification = "Captain's log exported to " + path
+		}
+		return g, tea.Tick(3*time.Second, func(time.Time) tea.Msg { return clearNotificationMsg{} })
+
+	// Actions on the items in the collection
+	case model.AnalyzeItemMsg:
+		g.syncSaveData()
+		note, err := data.AnalyzeItem(g.gameSave, msg.Name)
+		if err != nil {
+			g.Collection.Message = err.Error()
+			return g, nil
+		}
+		g.advanceClock(data.AnalyzeHours)
+		g.Collection.Message = fmt.Sprintf("The %s was analyzed and written up as %s.", msg.Name, note.Name)
+		return g, utilities.PushSave(g.gameSave, g.syncSaveData)
+	case model.SellItemMsg:
+		if !g.docked {
+			g.Collection.Message = "Dock at a space station to meet its collectors."
+			return g, nil
+		}
+		g.syncSaveData()
+		price, err := data.SellItem(g.gameSave, msg.Name)
+		if err != nil {
+			g.Collection.Message = err.Error()
+			return g, nil
+		}
+		g.Credits = g.gameSave.Player.Credits
+		g.awardExperience(data.TradeXP(price))
+		g.Collection.Message = fmt.Sprintf("Sold the %s for %d credits.", msg.Name, price)
+		return g, utilities.PushSave(g.gameSave, g.syncSaveData)
+	case model.DonateItemMsg:
+		g.syncSaveData()
+		faction, standing, err := data.DonateItem(g.gameSave, msg.Name)
+		if err != nil {
+			g.Collection.Message = err.Error()
+			return g, nil
+		}
+		g.Collection.Message = fmt.Sprintf("Donated the %s. %s standing +%d", msg.Name, data.FactionName(faction), standing)
+		return g, utilities.PushSave(g.gameSave, g.syncSaveData)
+	case model.FabricateMsg:
+		if g.isTravelling {
+			g.Fabrication.Message = "The fabricator can't run while travelling."
+			return g, nil
+		}
+		g.syncSaveData()
+		fabricator, err := data.Fabricate(g.gameSave, msg.Recipe)
+		if err != nil {
+			g.Fabrication.Message = err.Error()
+			return g, nil
+		}
+		g.Ship.EngineFuel = g.gameSave.Ship.Fuel
+		g.Ship.Food = g.gameSave.Ship.Food
+		g.mirrorShipCondition()
+		if msg.Recipe.Output.Kind == data.OutputUpgrade {
+			g.Ship.Upgrades = g.gameSave.Ship.Upgrades
+			g.applyUpgradeEffects()
+		}
+		g.advanceClock(msg.Recipe.Hours)
+		g.Fabrication.Message = fmt.Sprintf("%s fabricated %s after %d hours.", fabricator.Name, msg.Recipe.Name, msg.Recipe.Hours)
+		return g, utilities.PushSave(g.gameSave, g.syncSaveData)
+
+	case model.StartResearchMsg:
+		if err := data.StartResearch(g.gameSave, msg.ID); err != nil {
+			g.Research.Message = err.Error()
+			return g, nil
+		}
+		g.Research.Message = fmt.Sprintf("Research began on %s.", data.FindResearchNode(msg.ID).Name)
+		return g, utilities.PushSave(g.gameSave, g.syncSaveData)
+
+	case model.StationTradeMsg:
+		if !g.docked {
+			g.SpaceStation.Message = "Dock at the station to use its market."
+			return g, nil
+		}
+		g.syncSaveData()
+		trade := data.SellStationGood
+		if msg.Buy {
+			trade = data.BuyStationGood
+		}
+		price, err := trade(g.gameSave, g.SpaceStation.Station, msg.Good)
+		if err != nil {
+			g.SpaceStation.Message = err.Error()
+			return g, nil
+		}
+		g.Credits = g.gameSave.Player.Credits
+		g.Ship.Cargo = g.gameSave.Ship.Cargo
+		g.SpaceStation.Credits = g.Credits
+		g.awardExperience(data.TradeXP(price))
+		if msg.Buy {
+			g.SpaceStation.Message = fmt.Sprintf("Bought %s for %d¢.", msg.Good, price)
+		} else {
+			g.SpaceStation.Message = fmt.Sprintf("Sold %s for %d¢.", msg.Good, price)
+		}
+		return g, utilities.PushSave(g.gameSave, g.syncSaveData)
+	case model.TreatCrewMsg:
+		if !g.docked {
+			g.SpaceStation.Message = "Dock at the station to use its medbay."
+			return g, nil
+		}
+		g.syncSaveData()
+		cost, err := data.TreatCrew(g.gameSave, g.SpaceStation.Station)
+		if err != nil {
+			g.SpaceStation.Message = err.Error()
+			return g, nil
+		}
+		g.Credits = g.gameSave.Player.Credits
+		g.SpaceStation.Credits = g.Credits
+		g.SpaceStation.Message = fmt.Sprintf("The crew were treated for %d¢.", cost)
+		return g, utilities.PushSave(g.gameSave, g.syncSaveData)
 	case model.AcceptMissionMsg:
+		data.TakePosting(g.gameSave, g.SpaceStation.Station, msg.Mission.Id)
+		data.AcceptMission(&msg.Mission, g.gameSave.GameMetadata.ClockHours)
+		data.WriteLog(g.gameSave, data.LogMission, "Accepted: %s.", msg.Mission.Title)
 		g.Journal.Missions = append(g.Journal.Missions, msg.Mission)
 		g.gameSave.Missions = append(g.gameSave.Missions, msg.Mission)
 
@@ -586,12 +1209,29 @@ func (g GameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			startLocation.StarSystemName, arrivalLocation.StarSystemName,
 			g.Ship.EngineHealth, g.gameSave.Ship.Fuel,
 		)
+		newFuel = data.FuelAfterResearch(g.gameSave.Ship, newFuel) // research saves some of what the trip burns
+
+		// Every trip wears the engine down, mechanics on engine maintenance slow it
+		distance := g.locationService.CalculateDistance(
+			startLocation.Coordinates, arrivalLocation.Coordinates,
+			startLocation.StarSystemName, arrivalLocation.StarSystemName,
+		)
+		g.gameSave.GameMetadata.Stats.DistanceTravelled += distance
+		engineWear := data.EngineWear(distance, g.gameSave.Crew)
+		g.Ship.EngineHealth = max(g.Ship.EngineHealth-engineWear, 0)
 
 		// --- Update State Post-Arrival ---
 		g.Ship.EngineFuel = newFuel
 		g.Ship.Location = arrivalLocation
 		g.isTravelling = false
 
+		// shields recharge for every regeneration cycle spent in transit
+		g.regenerateShields(int(g.Travel.Duration/shipSystemsInterval) + 1)
+
+		// time passes in transit, and the ship has left whatever station it was docked at
+		g.advanceClock(data.TravelHours(g.Travel.Duration))
+		g.docked = false
+
 		// --- Improvement: Reset TravelComplete flag ---
 		g.Travel.TravelComplete = false // Reset the flag now that arrival is handled
 
@@ -601,18 +1241,11 @@ func (g GameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmds = append(cmds, utilities.PushSave(g.gameSave, g.syncSaveData))
 
 		// Handle Mission Arrival / Map Arrival Notification
-		missionTravelCompleted := (g.TrackedMission != nil && g.Travel.Mission != nil && g.Travel.Mission.Title == g.TrackedMission.Title)
+		missionTravelCompleted := (g.TrackedMission != nil && g.Travel.Mission != nil && g.Travel.Mission.SameAs(*g.TrackedMission))
 
-		if missionTravelCompleted && g.Ship.Location.IsEqual(g.TrackedMission.Location) {
+		if missionTravelCompleted && g.Ship.Location.IsEqual(g.TrackedMission.Destination()) {
 			// Arrived at the specific tracked mission destination
-			// ... (mission status and dialogue logic) ...
-			g.TrackedMission.Status = data.MissionStatusInProgress
-			if len(g.TrackedMission.Dialogue) > 0 {
-				d := components.NewDialogueComponentFromMission(g.TrackedMission.Dialogue)
-				g.Dialogue = &d
-			} else {
-				g.Dialogue = nil
-			}
+			cmds = append(cmds, g.arriveAtTrackedMission())
 		} else {
 			// Arrived via map travel (or mission travel to non-final location)
 			// ... (notification logic) ...
@@ -620,6 +1253,28 @@ func (g GameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			cmds = append(cmds, tea.Tick(3*time.Second, func(time.Time) tea.Msg {
 				return clearNotificationMsg{}
 			}))
+			// Missions already under way can still make progress wherever the ship ends up
+			g.checkObjectives()
+		}
+
+		data.WriteLog(g.gameSave, data.LogArrival, "Arrived at %s in the %s system.", arrivalLocation.PlanetName, arrivalLocation.StarSystemName)
+
+		// The first visit to a planet counts as a discovery
+		if discovery, ok := data.Discover(g.gameSave, arrivalLocation); ok {
+			g.awardExperience(discovery.XP)
+			if discovery.ResearchNote {
+				g.addRandomResearchNote()
+			}
+			if discovery.Item != nil {
+				g.notification = fmt.Sprintf("Investigated %s and recovered a %s!", arrivalLocation.PlanetName, discovery.Item.Name)
+			}
+			g.Map.GameMap = g.gameSave.GameMap
+		}
+
+		// The first visit to a species' home system brings their envoys out to meet the ship
+		if species := data.SpeciesHomeTo(arrivalLocation.StarSystemName); species != nil &&
+			data.FindContact(g.gameSave, species.ID) == nil && g.activeView != ViewCombat && g.activeView != ViewDiplomacy {
+			g.startNegotiation(species.ID)
 		}
 
 		// Clear the mission associated with the travel component
@@ -628,53 +1283,43 @@ func (g GameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	// When a mission is completed
 	if g.TrackedMission != nil && g.TrackedMission.Status == data.MissionStatusCompleted {
+		creditsBefore := g.Credits
 		// Update mission status in the journal
 		for i, mission := range g.Journal.Missions {
-			if mission.Title == g.TrackedMission.Title {
+			if mission.SameAs(*g.TrackedMission) {
 				g.Journal.Missions[i].Status = data.MissionStatusCompleted
+				g.Journal.Missions[i].Branch = g.TrackedMission.Branch
+				g.Journal.Missions[i].EndedAt = g.gameSave.GameMetadata.ClockHours
 			}
 		}
-		// Reward player with credits
-		g.Credits += g.TrackedMission.Income
-		// Reward with research note (if any)
-		g.addRandomResearchNote()
 
-		// --- NEW: Assign next mission if available ---
-		nextStep := g.TrackedMission.Step + 1
-		var nextMission *data.Mission = nil
-		// Optionally, you can also check for the same mission "line" by comparing categories or a custom ID.
-		for _, tmpl := range g.MissionTemplates {
-			if tmpl.Category == g.TrackedMission.Category && tmpl.Step == nextStep {
-				nextMission = &data.Mission{
-					Title:       tmpl.Title,
-					Description: tmpl.Description,
-					Category:    tmpl.Category,
-					Step:        tmpl.Step,
-					Location:    tmpl.Location,
-					Dialogue:    tmpl.Dialogue,
-					Income:      tmpl.Income,
-					Status:      data.MissionStatusNotStarted,
-				}
-				break
+		// Story missions move the quest graph along, unlocking whatever the chosen branch leads to
+		if g.TrackedMission.QuestID != "" {
+			g.syncSaveData()
+			update := data.CompleteQuest(g.gameSave, g.TrackedMission.QuestID, g.TrackedMission.Branch)
+			g.Credits = g.gameSave.Player.Credits
+			g.Ship.Cargo = g.gameSave.Ship.Cargo
+			g.Ship.EngineFuel = g.gameSave.Ship.Fuel
+			g.advanceQuests(update)
+			if len(update.Offered) == 0 {
+				g.notifyWaitingQuests()
 			}
 		}
-		if nextMission != nil {
-			// Create a copy of the mission and initialize its status.
-			newMission := *nextMission
-			newMission.Status = data.MissionStatusNotStarted
-			// Append the new mission to the journal and game save
-			g.Journal.Missions = append(g.Journal.Missions, newMission)
-			g.gameSave.Missions = append(g.gameSave.Missions, newMission)
-			// Optionally, notify the player
-			g.notification = fmt.Sprintf("New mission available: %s", newMission.Title)
-		}
 
-		g.TrackedMission = nil // Clear the tracked mission
-	}
+		data.WriteLog(g.gameSave, data.LogMission, "%s: %s.", data.MissionStatusCompleted, g.TrackedMission.Title)
 
-	// Game over: ship destroyed
-	if g.Ship.HullHealth <= 0 {
+		// Reward player with credits, experience and standing with the faction that offered the mission
+		payout := data.AdjustPrice(g.TrackedMission.Income, data.MissionPayoutModifier(g.gameSave.Player))
+		g.Credits += payout
+		g.gameSave.GameMetadata.Stats.Earn(payout)
+		// the quest's own rewards are paid on top of the mission's income
+		g.missionReward = g.Credits - creditsBefore
+		g.awardExperience(data.MissionXP(*g.TrackedMission))
+		data.ChangeReputation(&g.gameSave.Player.Reputation, g.TrackedMission.Faction, data.MissionReputationReward)
+		// Reward with research note (if any)
+		g.addRandomResearchNote()
 
+		g.TrackedMission = nil // Clear the tracked mission
 	}
 
 	return g, tea.Batch(cmds...)
@@ -703,8 +1348,8 @@ func (g GameModel) View() string {
 		Bold(true)
 
 	var menuView strings.Builder
-	planet := g.gameSave.Ship.Location.GetFullPlanet(g.gameSave.GameMap)
-	hasStation := planet.Type == "Space Station"
+	station := data.StationAt(g.gameSave.GameMap, g.gameSave.Ship.Location)
+	hasStation := station != nil
 
 	for i, item := range g.menuItems {
 		cursor := "-"
@@ -724,14 +1369,22 @@ func (g GameModel) View() string {
 			switch item {
 			case MenuJournal:
 				itemText = "J0*rn%l"
+			case MenuLog:
+				itemText = "L0#"
 			case MenuShip:
 				itemText = "S#!p"
 			case MenuCrew:
 				itemText = "Cr3>>"
 			case MenuMap:
 				itemText = "M<p"
+			case MenuReputation:
+				itemText = "R3p#t@t!0n"
 			case MenuCollection:
 				itemText = "C0ll*ct!0n"
+			case MenuFabrication:
+				itemText = "F@br!c#t!0n"
+			case MenuResearch:
+				itemText = "R#s3@rch"
 			case MenuSpaceStation:
 				itemText = "Sp@c3 St@t!*n"
 			case MenuExit:
@@ -752,7 +1405,7 @@ func (g GameModel) View() string {
 		Padding(1, 2).
 		Border(lipgloss.RoundedBorder()).
 		BorderForeground(lipgloss.Color("63")).
-		Render(title + "\n\n" + menuView.String())
+		Render(title + "\n\n" + strings.TrimSuffix(menuView.String(), "\n")) // the menu fills the panel to its last line
 
 	// ---------------------------
 	// Center Panel: Stats & Progress Bars with Credits at the Bottom
@@ -769,6 +1422,13 @@ func (g GameModel) View() string {
 	shipHealthText := statLabelStyle.Render("┌" + strings.Repeat("─", hullPaddingLeft) + " " + hullLabelText + " " + strings.Repeat("─", hullPaddingRight) + "┐")
 	healthBar := g.ProgressBar.RenderProgressBar(g.Ship.HullHealth, g.Ship.MaxHullHealth)
 
+	// Create shields header with centered text
+	shieldLabelText := "SHIELDS"
+	shieldPaddingLeft := (centerWidth - len(shieldLabelText) - 4) / 2 // -4 for "┌── " and " ──┐"
+	shieldPaddingRight := centerWidth - len(shieldLabelText) - 4 - shieldPaddingLeft
+	shieldText := statLabelStyle.Render("┌" + strings.Repeat("─", shieldPaddingLeft) + " " + shieldLabelText + " " + strings.Repeat("─", shieldPaddingRight) + "┐")
+	shieldBar := g.ProgressBar.RenderProgressBar(g.Ship.ShieldStrength, g.Ship.MaxShieldStrength)
+
 	// Create fuel header with centered text
 	fuelLabelText := "FUEL"
 	fuelPaddingLeft := (centerWidth - len(fuelLabelText) - 4) / 2 // -4 for "┌── " and " ──┐"
@@ -779,18 +1439,26 @@ func (g GameModel) View() string {
 	// Display location
 	var locationText string
 	// If at space station
-	if planet.Type == "Space Station" {
-		locationText = fmt.Sprintf("Docked at %s, %s System", g.Ship.Location.PlanetName, g.Ship.Location.StarSystemName)
+	if hasStation {
+		locationText = fmt.Sprintf("Docked at %s, %s System", station.Name, g.Ship.Location.StarSystemName)
 	} else {
 		locationText = fmt.Sprintf("Orbiting %s, %s System", g.Ship.Location.PlanetName, g.Ship.Location.StarSystemName)
 	}
 
-	moduleStatusText := "Modules: Engine (OK), Weapons (OK), Cargo (OK)"
+	damagedModules := 0
+	for _, module := range g.Ship.Modules {
+		if module.Status == "damaged" {
+			damagedModules++
+		}
+	}
+	moduleStatusText := fmt.Sprintf("Engine: %d%%  |  Modules: %d/%d OK", g.Ship.EngineHealth, len(g.Ship.Modules)-damagedModules, len(g.Ship.Modules))
 
-	creditsText := fmt.Sprintf("Credits: %d", g.Credits)
+	creditsText := fmt.Sprintf("Credits: %d  |  Level %d (%d/%d XP)", g.Credits,
+		g.gameSave.Player.Level, g.gameSave.Player.ExperiencePoints, data.XPForLevel(g.gameSave.Player.Level+1))
+	stardateText := fmt.Sprintf("Stardate %s  |  Food: %d", data.Stardate(g.gameSave.GameMetadata.ClockHours), g.Ship.Food)
 
-	statsContent := fmt.Sprintf("%s\n%s\n\n%s\n%s\n\n\n%s\n\n%s\n\n%s",
-		shipHealthText, healthBar, fuelText, fuelBar, locationText, moduleStatusText, creditsText)
+	statsContent := fmt.Sprintf("%s\n%s\n%s\n%s\n%s\n%s\n\n%s\n%s\n%s\n%s",
+		shipHealthText, healthBar, shieldText, shieldBar, fuelText, fuelBar, locationText, moduleStatusText, creditsText, stardateText)
 
 	centerStatsPanel := lipgloss.NewStyle().
 		Width(60).
@@ -804,7 +1472,7 @@ func (g GameModel) View() string {
 	// Right Panel: Yuta and game version
 	// ---------------------------
 
-	g.Yuta = components.NewYutaComponent(g.gameSave.Ship, g.gameSave.Player.PlayerName, g.Credits, g.Version)
+	g.Yuta = components.NewYutaComponent(g.gameSave.Ship, g.gameSave.Player, g.Credits, g.Version)
 
 	// Game version displayed by Yuta OS
 	// Might need to adjust the fancy chars to fit within the width when the version number grows
@@ -835,15 +1503,31 @@ func (g GameModel) View() string {
 		bottomPanelContent = g.Crew.View()
 	case MenuJournal:
 		bottomPanelContent = g.Journal.View()
+	case MenuLog:
+		bottomPanelContent = g.Log.View()
 	case MenuMap:
 		bottomPanelContent = g.Map.View()
+	case MenuReputation:
+		bottomPanelContent = g.Reputation.View()
 	case MenuCollection: // NEW: Display Collection view.
 		bottomPanelContent = g.Collection.View()
+	case MenuFabrication:
+		bottomPanelContent = g.Fabrication.View()
+	case MenuResearch:
+		bottomPanelContent = g.Research.View()
 	case MenuSpaceStation: // NEW: Display SpaceStation view
 		bottomPanelContent = g.SpaceStation.View()
 	default:
-		// Show travel view if travelling, regardless of mission
-		if g.isTravelling {
+		// Combat takes over the bottom panel while fighting
+		if g.activeView == ViewCombat && g.Combat != nil {
+			bottomPanelContent = g.Combat.View()
+		} else if g.activeView == ViewDiplomacy && g.Diplomacy != nil {
+			bottomPanelContent = g.Diplomacy.View()
+		} else if g.activeView == ViewPerks {
+			bottomPanelContent = g.Perks.View()
+		} else if g.activeView == ViewStranded {
+			bottomPanelContent = g.Stranded.View()
+		} else if g.isTravelling { // Show travel view if travelling, regardless of mission
 			bottomPanelContent = g.Travel.View()
 		} else if g.activeView == ViewEvent && g.Event != nil { // Event in bottom panel
 			bottomPanelContent = g.Event.View()
@@ -851,6 +1535,7 @@ func (g GameModel) View() string {
 		} else if g.TrackedMission != nil {
 			// Show current task
 			currentTask := components.NewCurrentTaskComponent()
+			currentTask.GameSave = g.gameSave
 			bottomPanelContent = currentTask.Render(g.TrackedMission)
 
 			// If mission in progress, show dialogue
@@ -859,7 +1544,7 @@ func (g GameModel) View() string {
 				// Show dialogue ONLY if it's initialized
 				if g.Dialogue != nil { // <--- ADD THIS CHECK
 					bottomPanelContent = g.Dialogue.View()
-					bottomPanelContent += "\n\nPress [Enter] to continue dialogue."
+					bottomPanelContent += "\n\nPress [Enter] to continue dialogue, [Backspace] to go back."
 				} else {
 					// Optional: Show a message indicating the mission is starting or dialogue is loading
 					bottomPanelContent = fmt.Sprintf("Mission '%s' starting...\n(Press Enter to begin dialogue if available)", g.TrackedMission.Title)
@@ -870,7 +1555,7 @@ func (g GameModel) View() string {
 				// Show mission complete screen
 				// Ensure TrackedMission is not nil here too for safety, although the outer check covers it.
 				if g.TrackedMission != nil {
-					bottomPanelContent = fmt.Sprintf("Mission Complete!\n\nYou were rewarded %d credits.\n\nPress [Space] to continue.", g.TrackedMission.Income)
+					bottomPanelContent = fmt.Sprintf("Mission Complete!\n\nYou were rewarded %d credits.\n\nPress [Space] to continue.", g.missionReward)
 				}
 			}
 		} else {
@@ -931,7 +1616,8 @@ func (g GameModel) View() string {
 	return mainView
 }
 
-func NewGameModel() tea.Model {
+// NewGameModel loads the save and the game content, mods reports what loading the content mods came to
+func NewGameModel(mods data.ModReport) tea.Model {
 	fullSave, err := data.LoadFullGameSave()
 	if err != nil || fullSave == nil {
 		fmt.Println("Error loading save file or save file not found; using default values")
@@ -943,6 +1629,36 @@ func NewGameModel() tea.Model {
 		fmt.Println("Error failed to load events:", err)
 	}
 
+	// Load event and objective scripts
+	if err := data.LoadScripts(); err != nil {
+		fmt.Println("Error failed to load scripts:", err)
+	}
+
+	// Load enemy ships from enemy_ships.json
+	if err := data.LoadEnemyShips(); err != nil {
+		fmt.Println("Error failed to load enemy ships:", err)
+	}
+
+	// Load fabrication recipes from fabrication.json
+	if err := data.LoadFabricationRecipes(); err != nil {
+		fmt.Println("Error failed to load fabrication recipes:", err)
+	}
+
+	// Load alien species from species.json
+	if err := data.LoadSpecies(); err != nil {
+		fmt.Println("Error failed to load species:", err)
+	}
+
+	// Load space stations from stations.json
+	if err := data.LoadStations(); err != nil {
+		fmt.Println("Error failed to load stations:", err)
+	}
+
+	// Load placeholder word lists from wordlists.json
+	if err := data.LoadWordLists(); err != nil {
+		fmt.Println("Error failed to load word lists:", err)
+	}
+
 	// Load mission templates file
 	missionTemplates, err := data.LoadMissionTemplates()
 	if err != nil {
@@ -950,21 +1666,26 @@ func NewGameModel() tea.Model {
 	}
 
 	shipModel := model.NewShipModel(fullSave.Ship)
+	shipModel.GameSave = fullSave
 	crewModel := model.NewCrewModel(fullSave.Crew, fullSave)
 	journalModel := model.NewJournalModel()
+	journalModel.GameSave = fullSave
 	mapModel := model.NewMapModel(fullSave.GameMap, fullSave.Ship, fullSave)
 	collectionModel := model.NewCollectionModel(fullSave)
-	spaceStationModel := model.NewSpaceStationModel(fullSave.Ship, fullSave.Player.Credits, missionTemplates, fullSave.GameMap.StarSystems)
 
 	return GameModel{
 		ProgressBar:      components.NewProgressBar(),
-		menuItems:        []MenuItem{MenuJournal, MenuShip, MenuCrew, MenuMap, MenuCollection, MenuSpaceStation, MenuExit},
+		menuItems:        []MenuItem{MenuJournal, MenuLog, MenuShip, MenuCrew, MenuMap, MenuReputation, MenuCollection, MenuFabrication, MenuResearch, MenuSpaceStation, MenuExit},
 		menuCursor:       0,
 		Ship:             shipModel,
 		Crew:             crewModel,
 		Journal:          journalModel,
+		Log:              model.NewLogModel(fullSave),
 		Collection:       collectionModel,
-		SpaceStation:     spaceStationModel,
+		Fabrication:      model.NewFabricationModel(fullSave),
+		Research:         model.NewResearchModel(fullSave),
+		Reputation:       model.NewReputationModel(fullSave),
+		Perks:            model.NewPerkModel(fullSave),
 		Map:              mapModel,
 		Travel:           components.NewTravelComponent(),
 		activeView:       ViewNone,
@@ -976,11 +1697,28 @@ func NewGameModel() tea.Model {
 		lastAutoSaveTime: time.Now(),
 		locationService:  data.NewLocationService(fullSave.GameMap),
 		MissionTemplates: missionTemplates,
-		Yuta:             components.NewYutaComponent(fullSave.Ship, fullSave.Player.PlayerName, fullSave.Player.Credits, fullSave.GameMetadata.Version),
-		GameOver:         components.NewGameOverComponent(fullSave.Ship),
+		Yuta:             components.NewYutaComponent(fullSave.Ship, fullSave.Player, fullSave.Player.Credits, fullSave.GameMetadata.Version),
+		notification:     modNotification(mods),
 	}
 }
 
+// modNotification sums up the loaded mods and any problems with them for the notification bar
+func modNotification(report data.ModReport) string {
+	var parts []string
+	if len(report.Loaded) > 0 {
+		parts = append(parts, "Loaded mods: "+strings.Join(report.Loaded, ", "))
+	}
+	for _, problem := range report.Problems {
+		parts = append(parts, "Mod problem: "+problem.Error())
+	}
+	return strings.Join(parts, " • ")
+}
+
+// typing reports whether the player is typing into the active view, keys like [q] and [Esc] belong to it then
+func (g *GameModel) typing() bool {
+	return g.activeView == ViewLog && g.Log.Typing()
+}
+
 // syncSaveData updates the gameSave data with the latest state from the GameModel
 // syncSaveData updates gameSave with the latest in-memory state
 func (g *GameModel) syncSaveData() {
@@ -992,10 +1730,13 @@ func (g *GameModel) syncSaveData() {
 	g.gameSave.Ship.FTLDriveHealth = g.Ship.FTLDriveHealth
 	g.gameSave.Ship.FTLDriveCharge = g.Ship.FTLDriveCharge
 	g.gameSave.Ship.ShieldStrength = g.Ship.ShieldStrength
+	g.gameSave.Ship.MaxShieldStrength = g.Ship.MaxShieldStrength
 	g.gameSave.Ship.MaxHullIntegrity = g.Ship.MaxHullHealth
 	g.gameSave.Ship.MaxFuel = g.Ship.MaxFuel
+	g.gameSave.Ship.Upgrades = g.Ship.Upgrades
 	g.gameSave.Ship.Food = g.Ship.Food
 	g.gameSave.Ship.Location = g.Ship.Location
+	g.gameSave.Ship.Modules = g.Ship.Modules
 
 	g.gameSave.Player.Credits = g.Credits
 	g.gameSave.GameMetadata.LastSaveTime = time.Now().Format(time.RFC3339)
@@ -1005,6 +1746,298 @@ func (g *GameModel) syncSaveData() {
 	g.gameSave.GameMetadata.GameOver = g.playerLostGame // Sync game over state
 }
 
+// applyUpgradeEffects recalculates the stats that depend on upgrade levels
+// and mirrors them back onto the ship model so the next sync doesn't undo them
+func (g *GameModel) applyUpgradeEffects() {
+	g.syncSaveData()
+	data.ApplyUpgradeEffects(&g.gameSave.Ship)
+
+	g.Ship.MaxFuel = g.gameSave.Ship.MaxFuel
+	g.Ship.MaxHullHealth = g.gameSave.Ship.MaxHullIntegrity
+	g.Ship.MaxShieldStrength = g.gameSave.Ship.MaxShieldStrength
+	g.Ship.EngineFuel = g.gameSave.Ship.Fuel
+	g.Ship.HullHealth = g.gameSave.Ship.HullIntegrity
+	g.Ship.ShieldStrength = g.gameSave.Ship.ShieldStrength
+	g.Ship.Cargo.Capacity = g.gameSave.Ship.Cargo.Capacity
+	data.ApplyCollectionCapacity(g.gameSave)
+}
+
+// absorbDamage applies incoming damage to the shields first and the hull with whatever gets through
+func (g *GameModel) absorbDamage(damage int) {
+	ship := data.Ship{HullIntegrity: g.Ship.HullHealth, ShieldStrength: g.Ship.ShieldStrength}
+	data.AbsorbDamage(&ship, damage)
+
+	g.Ship.HullHealth = ship.HullIntegrity
+	g.Ship.ShieldStrength = ship.ShieldStrength
+	g.gameSave.Ship.HullIntegrity = ship.HullIntegrity
+	g.gameSave.Ship.ShieldStrength = ship.ShieldStrength
+}
+
+// regenerateShields recharges the shields for the given number of cycles
+func (g *GameModel) regenerateShields(cycles int) {
+	g.syncSaveData()
+	data.RegenerateShields(&g.gameSave.Ship, g.gameSave.Crew, cycles)
+	g.Ship.ShieldStrength = g.gameSave.Ship.ShieldStrength
+}
+
+// awardExperience gives the player experience and announces any level gained
+func (g *GameModel) awardExperience(xp int) {
+	if data.AwardExperience(&g.gameSave.Player, xp) > 0 {
+		g.notification = fmt.Sprintf("Level up! You are now level %d. Press [P] to choose a perk", g.gameSave.Player.Level)
+	}
+}
+
+// runDamageControl lets the engineers on damage control patch up the ship with materials from the cargo hold
+func (g *GameModel) runDamageControl() {
+	g.syncSaveData()
+	target, repaired := data.SelfRepair(&g.gameSave.Ship, g.gameSave.Crew)
+	if repaired == 0 {
+		return
+	}
+	g.mirrorShipCondition()
+	g.notification = fmt.Sprintf("Damage control repaired the %s", strings.ToLower(target.Label(g.gameSave.Ship)))
+}
+
+// mirrorShipCondition copies the ship's condition from gameSave back onto the ship model
+// used after a data helper has changed gameSave.Ship directly
+func (g *GameModel) mirrorShipCondition() {
+	g.Ship.HullHealth = g.gameSave.Ship.HullIntegrity
+	g.Ship.ShieldStrength = g.gameSave.Ship.ShieldStrength
+	g.Ship.EngineHealth = g.gameSave.Ship.EngineHealth
+	g.Ship.FTLDriveHealth = g.gameSave.Ship.FTLDriveHealth
+	g.Ship.Modules = g.gameSave.Ship.Modules
+	g.Ship.Cargo = g.gameSave.Ship.Cargo
+}
+
+// arriveAtTrackedMission starts the tracked mission once the ship is at its location
+// missions with an enemy target open with a fight, the dialogue begins after a victory
+// a mission already under way checks its objectives instead, fighting any enemy waiting there
+func (g *GameModel) arriveAtTrackedMission() tea.Cmd {
+	if g.TrackedMission.Status == data.MissionStatusInProgress {
+		objective := data.CurrentObjective(g.TrackedMission)
+		if objective != nil && objective.Type == data.ObjectiveCombat &&
+			g.Ship.Location.IsEqual(objective.Target(*g.TrackedMission)) && g.startCombat(objective.Enemy) {
+			return nil
+		}
+		if g.startNegotiationObjective() {
+			return nil
+		}
+		g.checkObjectives()
+		return nil
+	}
+	if g.TrackedMission.Enemy != "" && g.startCombat(g.TrackedMission.Enemy) {
+		return nil
+	}
+	return g.startTrackedMissionDialogue()
+}
+
+// checkObjectives works through the tracked mission's objectives once its dialogue is over
+// the mission completes when every objective is met and fails if a protected crew member is lost
+func (g *GameModel) checkObjectives() {
+	if g.TrackedMission == nil || g.TrackedMission.Status != data.MissionStatusInProgress || g.Dialogue != nil {
+		return
+	}
+	g.syncSaveData()
+	result := data.UpdateObjectives(g.TrackedMission, g.gameSave)
+	g.Ship.Cargo = g.gameSave.Ship.Cargo
+	if result.Err != nil {
+		g.notification = fmt.Sprintf("Script error: %v", result.Err)
+	}
+
+	switch {
+	case result.Failed:
+		// handled below once the journal is up to date
+	case data.ObjectivesComplete(*g.TrackedMission):
+		g.TrackedMission.Status = data.MissionStatusCompleted
+	case result.Progressed:
+		next := data.CurrentObjective(g.TrackedMission)
+		g.notification = fmt.Sprintf("Objective complete. Next: %s", next.Describe(*g.TrackedMission))
+		data.WriteLog(g.gameSave, data.LogMission, "%s: objective complete. Next: %s.", g.TrackedMission.Title, next.Describe(*g.TrackedMission))
+	}
+
+	// keep the journal's copy of the mission in step with its progress
+	for i, mission := range g.Journal.Missions {
+		if mission.SameAs(*g.TrackedMission) {
+			g.Journal.Missions[i] = *g.TrackedMission
+		}
+	}
+
+	if result.Failed {
+		g.failTrackedMission(result.Reason)
+	}
+}
+
+// failTrackedMission fails the tracked mission for the given reason and stops tracking it
+func (g *GameModel) failTrackedMission(reason string) {
+	failed := *g.TrackedMission
+	g.syncSaveData()
+	if i := g.missionIndex(failed); i >= 0 {
+		g.advanceQuests(data.FailMission(g.gameSave, i, reason))
+	}
+	g.notification = fmt.Sprintf("Mission failed: %s. %s.", failed.Title, reason)
+	g.TrackedMission = nil
+	g.Dialogue = nil
+}
+
+// missionTarget reports whether an enemy is the target the tracked mission has to beat before it can start
+func (g *GameModel) missionTarget(enemyID string) bool {
+	return g.TrackedMission != nil && g.TrackedMission.Status == data.MissionStatusNotStarted && g.TrackedMission.Enemy == enemyID
+}
+
+// advanceClock moves the game clock forward, everything tied to it runs in data.AdvanceClock
+func (g *GameModel) advanceClock(hours int) {
+	if hours <= 0 {
+		return
+	}
+	g.syncSaveData()
+	g.applyClockReport(data.AdvanceClock(g.gameSave, hours))
+}
+
+// applyClockReport mirrors what happened while the clock ran back onto the game model
+// and fails any mission whose deadline has now passed
+func (g *GameModel) applyClockReport(report data.ClockReport) {
+	g.Credits = g.gameSave.Player.Credits
+	g.Ship.Food = g.gameSave.Ship.Food
+
+	switch {
+	case report.Starving:
+		g.notification = "The ship is out of food, the crew is starving!"
+	case report.Unpaid:
+		g.notification = "There weren't enough credits to pay the crew, morale is falling."
+	case report.Days > 0:
+		g.notification = fmt.Sprintf("Stardate %s: the crew ate %d food and were paid %d credits.",
+			data.Stardate(g.gameSave.GameMetadata.ClockHours), report.FoodEaten, report.WagesPaid)
+	}
+
+	// finished research can change the ship's stats
+	if report.Researched != nil {
+		g.applyUpgradeEffects()
+		g.notification = fmt.Sprintf("Research complete: %s (%s)", report.Researched.Name, report.Researched.Bonus)
+	}
+
+	expired, update := data.ExpireMissions(g.gameSave)
+	if len(expired) > 0 {
+		g.advanceQuests(update)
+		g.notification = fmt.Sprintf("Mission failed, the deadline passed: %s", strings.Join(expired, ", "))
+	}
+}
+
+// missionIndex returns the index of a mission in the save, or -1 if it isn't there
+func (g *GameModel) missionIndex(m data.Mission) int {
+	for i, mission := range g.gameSave.Missions {
+		if mission.SameAs(m) {
+			return i
+		}
+	}
+	return -1
+}
+
+// newMissionDialogue builds the briefing for the tracked mission
+func (g *GameModel) newMissionDialogue() *components.DialogueComponent {
+	d := components.NewDialogueComponent(data.MissionDialogue(*g.TrackedMission), g.gameSave)
+	return &d
+}
+
+// finishMissionDialogue hands the tracked mission over to its objectives once the briefing is over
+func (g *GameModel) finishMissionDialogue() {
+	if g.Dialogue != nil && g.Dialogue.Finished {
+		g.Dialogue = nil
+		g.TrackedMission.Briefed = true
+		g.checkObjectives()
+		g.startNegotiationObjective()
+	}
+}
+
+// applyDialogueChoice applies the effects of a briefing choice and records the quest branch it settles on
+// choices made later in the conversation still apply, but nothing does once the briefing is over
+func (g *GameModel) applyDialogueChoice(choice data.DialogueChoice) {
+	if g.TrackedMission.Briefed {
+		return
+	}
+	if choice.Branch != "" {
+		g.TrackedMission.Branch = choice.Branch
+	}
+	if len(choice.Effects) == 0 {
+		return
+	}
+	g.syncSaveData()
+	data.ApplyDialogueChoice(g.gameSave, choice)
+	g.Credits = g.gameSave.Player.Credits
+	g.Ship.EngineFuel = g.gameSave.Ship.Fuel
+	g.Ship.Cargo = g.gameSave.Ship.Cargo
+}
+
+// advanceQuests brings the journal in line with the save after the quest graph has moved
+// a tracked mission that failed is dropped
+func (g *GameModel) advanceQuests(update data.QuestUpdate) {
+	g.Journal.Missions = g.gameSave.Missions
+
+	if g.TrackedMission != nil {
+		for _, mission := range g.Journal.Missions {
+			if mission.SameAs(*g.TrackedMission) && mission.Status == data.MissionStatusFailed {
+				g.TrackedMission = nil
+				g.Dialogue = nil
+				break
+			}
+		}
+	}
+
+	var titles []string
+	for _, mission := range update.Offered {
+		titles = append(titles, mission.Title)
+		data.WriteLog(g.gameSave, data.LogMission, "New mission available: %s.", mission.Title)
+	}
+	switch {
+	case len(update.Failed) > 0:
+		g.notification = fmt.Sprintf("Mission failed: %s", strings.Join(update.Failed, ", "))
+	case len(titles) > 0:
+		g.notification = fmt.Sprintf("New mission available: %s", strings.Join(titles, ", "))
+	}
+}
+
+// notifyWaitingQuests tells the player what the next quest in line is waiting on
+func (g *GameModel) notifyWaitingQuests() {
+	for _, id := range g.gameSave.Quests.Pending {
+		if missing := data.MissingPrerequisites(g.gameSave, id); len(missing) > 0 {
+			g.notification = fmt.Sprintf("Next lead: %s (needs %s)", data.FindQuest(id).Title, strings.Join(missing, ", "))
+			return
+		}
+	}
+}
+
+// startTrackedMissionDialogue marks the tracked mission as in progress and starts typing out its dialogue
+func (g *GameModel) startTrackedMissionDialogue() tea.Cmd {
+	g.TrackedMission.Status = data.MissionStatusInProgress
+	data.WriteLog(g.gameSave, data.LogMission, "%s: %s.", data.MissionStatusInProgress, g.TrackedMission.Title)
+	if tree := data.MissionDialogue(*g.TrackedMission); len(tree.Nodes) > 0 {
+		g.Dialogue = g.newMissionDialogue()
+		return g.Dialogue.Start()
+	}
+	g.Dialogue = nil
+	g.TrackedMission.Briefed = true
+	return nil
+}
+
+// startCombat opens the combat view against the enemy ship with the given id
+// returns false if no such enemy exists
+func (g *GameModel) startCombat(enemyID string) bool {
+	enemy := data.FindEnemyShip(enemyID)
+	if enemy == nil {
+		log.Printf("Unknown enemy ship: %s", enemyID)
+		return false
+	}
+
+	g.syncSaveData()
+	ship := g.gameSave.Ship
+	ship.Modules = append([]data.Module(nil), ship.Modules...) // combat damages its own copy of the modules
+
+	c := model.NewCombatModel(*enemy, ship, g.gameSave.Crew)
+	g.Combat = &c
+	g.activeView = ViewCombat
+	g.selectedItem = MenuNone
+	return true
+}
+
 // saveGameAsync saves the game in a goroutine to avoid blocking the UI
 func saveGameAsync(save *data.FullGameSave) {
 	go func(save *data.FullGameSave) {
@@ -1014,6 +2047,52 @@ func saveGameAsync(save *data.FullGameSave) {
 	}(save)
 }
 
+// strand opens the recovery options the first time the ship runs out of fuel
+func (g *GameModel) strand() {
+	if !data.Strand(g.gameSave) {
+		return
+	}
+	data.WriteLog(g.gameSave, data.LogEvent, "Ran out of fuel at %s.", g.Ship.Location.PlanetName)
+	g.notification = "Out of fuel! Press [B] for ways to get moving again."
+	g.Stranded = model.NewStrandedModel(g.gameSave)
+	// a fight or an event on screen is finished first
+	if g.activeView == ViewNone || g.activeView == ViewMap || g.activeView == ViewShip {
+		g.selectedItem = MenuNone
+		g.activeView = ViewStranded
+	}
+}
+
+// endRun ends the game, sums up the run and enters it in the hall of fame
+// a save that was already over when it was loaded isn't entered a second time
+func (g *GameModel) endRun(cause data.LossCause) {
+	recorded := g.gameSave.GameMetadata.GameOver
+	if !recorded {
+		addDurationToPlayTime(&g.gameSave.GameMetadata.TotalPlayTime, time.Since(g.lastAutoSaveTime))
+		g.lastAutoSaveTime = time.Now()
+	}
+	if g.gameSave.GameMetadata.LossCause == data.LossNone {
+		g.gameSave.GameMetadata.LossCause = cause
+	}
+	g.playerLostGame = true
+	g.syncSaveData()
+
+	epilogue := data.NewEpilogue(g.gameSave)
+	var hallOfFame []data.Epilogue
+	var place int
+	var err error
+	if recorded {
+		hallOfFame, err = data.LoadHallOfFame()
+	} else {
+		hallOfFame, place, err = data.RecordRun(epilogue)
+	}
+	if err != nil {
+		log.Printf("Error updating hall of fame: %v", err)
+	}
+	g.GameOver = components.NewGameOverComponent(epilogue, hallOfFame, place)
+
+	saveGameAsync(g.gameSave)
+}
+
 // helper: add elapsed duration to TotalPlayTime, normalizing seconds/minutes/hours
 func addDurationToPlayTime(tt *data.TotalPlayTime, d time.Duration) {
 	secondsToAdd := int(d.Seconds())
@@ -1024,6 +2103,33 @@ func addDurationToPlayTime(tt *data.TotalPlayTime, d time.Duration) {
 	tt.Minutes = tt.Minutes % 60
 }
 
+// startNegotiation opens talks with the species with the given id
+// returns false if no such species exists
+func (g *GameModel) startNegotiation(speciesID string) bool {
+	species := data.FindSpecies(speciesID)
+	if species == nil {
+		log.Printf("Unknown species: %s", speciesID)
+		return false
+	}
+
+	g.syncSaveData()
+	d := model.NewDiplomacyModel(g.gameSave, *species)
+	g.Diplomacy = &d
+	g.activeView = ViewDiplomacy
+	g.selectedItem = MenuNone
+	return true
+}
+
+// startNegotiationObjective opens the talks the tracked mission is waiting on once the ship is where they are held
+func (g *GameModel) startNegotiationObjective() bool {
+	if g.TrackedMission == nil || g.TrackedMission.Status != data.MissionStatusInProgress {
+		return false
+	}
+	objective := data.CurrentObjective(g.TrackedMission)
+	return objective != nil && objective.Type == data.ObjectiveNegotiate &&
+		g.Ship.Location.IsEqual(objective.Target(*g.TrackedMission)) && g.startNegotiation(objective.Species)
+}
+
 // Gives a random tier research note to the player
 func (g *GameModel) addRandomResearchNote() {
 	// Generate a random number between 0-100 to determine tier
@@ -1044,30 +2150,17 @@ func (g *GameModel) addRandomResearchNote() {
 		tier = 1
 	}
 
-	for i := range g.gameSave.Collection.ResearchNotes { // Iterate using index on GameSave collection
-		// Check if this is the correct tier
-		if g.gameSave.Collection.ResearchNotes[i].Tier == tier {
-			// Increment quantity directly in GameSave
-			g.gameSave.Collection.ResearchNotes[i].Quantity++
-
-			g.gameSave.Collection.UsedCapacity++
-			if g.gameSave.Collection.UsedCapacity > g.gameSave.Collection.MaxCapacity {
-				log.Printf("Warning: UsedCapacity (%d) exceeds MaxCapacity (%d)",
-					g.gameSave.Collection.UsedCapacity, g.gameSave.Collection.MaxCapacity)
-			}
-
-			// Create notification
-			g.notification = fmt.Sprintf("Received %s research note!", g.gameSave.Collection.ResearchNotes[i].Name)
-
-			break // Note found and updated
-		}
+	// The collection has to have room for it
+	if note, ok := data.AddResearchNote(&g.gameSave.Collection, tier); ok {
+		g.notification = fmt.Sprintf("Received %s research note!", note.Name)
+	} else if data.CollectionFull(g.gameSave.Collection) {
+		g.notification = "The collection is full, a research note was lost."
 	}
-
 }
 
 // Triggers random event from events.json
-func TriggerRandomEvent() tea.Cmd {
-	event := data.GetRandomEvent()
+func TriggerRandomEvent(save *data.FullGameSave) tea.Cmd {
+	event := data.GetRandomEvent(save)
 	if event != nil {
 		return func() tea.Msg {
 			return StartEventMsg{Event: event}
@@ -1087,14 +2180,22 @@ func (m MenuItem) String() string {
 	switch m {
 	case MenuJournal:
 		return "Journal"
+	case MenuLog:
+		return "Log"
 	case MenuShip:
 		return "Ship"
 	case MenuCrew:
 		return "Crew"
 	case MenuMap:
 		return "Map"
+	case MenuReputation:
+		return "Reputation"
 	case MenuCollection:
 		return "Collection"
+	case MenuFabrication:
+		return "Fabrication"
+	case MenuResearch:
+		return "Research"
 	case MenuSpaceStation:
 		return "Space Station"
 	case MenuExit:
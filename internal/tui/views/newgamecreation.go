@@ -18,27 +18,33 @@ type newGameModel struct {
 	err        error
 	showIntro  bool // flag to show the intro exposition
 	Dialogue   *components.DialogueComponent
-	lines      []string // lines of dialogue to display
+	mods       data.ModReport // handed on to the game once the save is created
 }
 
+// introDialogue is the exposition shown before a new game is set up
+var introDialogue = data.DialogueTree{Nodes: []data.DialogueNode{
+	{ID: "year", Speaker: "Narrator", Portrait: "✧", Text: "The year is 2399 and humanity has reached the stars.", Next: "ftl"},
+	{ID: "ftl", Speaker: "Narrator", Portrait: "✧", Text: "Space travel beyond the Solar System is now possible with the use of Faster-Than-Light (FTL) technology.", Next: "contact"},
+	{ID: "contact", Speaker: "Narrator", Portrait: "✧", Text: "They are not alone in the universe. Contact has been made with intelligent alien species.", Choices: []data.DialogueChoice{
+		{Text: "Tell me about them.", Next: "species"},
+		{Text: "I've heard enough.", Next: "ready"},
+	}},
+	{ID: "species", Speaker: "Narrator", Portrait: "✧", Text: "Some are friendly, some hostile, and some are indifferent.", Next: "community"},
+	{ID: "community", Speaker: "Narrator", Portrait: "✧", Text: "Humanity is now part of a galactic community, but the galaxy is a mysterious place.", Next: "ready"},
+	{ID: "ready", Speaker: "Narrator", Portrait: "✧", Text: "Your ship and crew are ready, and the stars await..."},
+}}
+
 // NewGameCreationModel initializes the new game creation form
-func NewGameCreationModel() tea.Model {
+func NewGameCreationModel(mods data.ModReport) tea.Model {
 	m := newGameModel{
 		inputs:     make([]textinput.Model, 3),
 		focusIndex: 0,
 		showIntro:  true,
-		lines: []string{
-			"The year is 2399 and humanity has reached the stars.",
-			"Space travel beyond the Solar System is now possible with the use of Faster-Than-Light (FTL) technology.",
-			"They are not alone in the universe. Contact has been made with intelligent alien species.",
-			"Some are friendly, some hostile, and some are indifferent.",
-			"Humanity is now part of a galactic community, but the galaxy is a mysterious place.",
-			"Your ship and crew are ready, and the stars await...",
-		},
+		mods:       mods,
 	}
 
-	// Initialize Dialogue component
-	d := components.NewDialogueComponentFromMission(m.lines)
+	// Initialize Dialogue component, there's no save yet to check choices against
+	d := components.NewDialogueComponent(introDialogue, nil)
 	m.Dialogue = &d
 
 	// 1. Player name
@@ -70,13 +76,15 @@ func NewGameCreationModel() tea.Model {
 }
 
 func (m newGameModel) Init() tea.Cmd {
-	return textinput.Blink
+	return tea.Batch(textinput.Blink, m.Dialogue.Start())
 }
 
 func (m newGameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
 	switch msg := msg.(type) {
+	case components.DialogueTickMsg:
+		return m, m.Dialogue.Update(msg)
 	case tea.KeyMsg:
 		// Handle quit for all key messages
 		if msg.String() == "ctrl+c" || msg.String() == "q" {
@@ -85,20 +93,28 @@ func (m newGameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// handle Enter key for the dialogue component
 		if m.showIntro {
-			if msg.String() == "enter" {
-				m.Dialogue.Next()
-				if m.Dialogue.CurrentLine >= len(m.lines) {
-					m.showIntro = false
+			var cmd tea.Cmd
+			switch msg.String() {
+			case "up", "k":
+				m.Dialogue.MoveChoice(-1)
+			case "down", "j":
+				m.Dialogue.MoveChoice(1)
+			case "enter":
+				if m.Dialogue.AwaitingChoice() {
+					_, cmd = m.Dialogue.Choose()
+				} else {
+					cmd = m.Dialogue.Next()
 				}
-			}
-			if msg.String() == "backspace" {
-				m.Dialogue.Previous()
-			}
-			if msg.String() == " " {
+			case "backspace":
+				cmd = m.Dialogue.Previous()
+			case " ":
 				m.showIntro = false // Skip
 			}
+			if m.Dialogue.Finished {
+				m.showIntro = false
+			}
 			// Must return so that it doesnt input into the stuff below
-			return m, nil
+			return m, cmd
 		}
 
 		// Converted from switch to if for slight performance gain
@@ -146,7 +162,7 @@ func (m newGameModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 
 				// after creating the save, load the game simulation
-				return NewGameModel(), nil
+				return NewGameModel(m.mods), nil
 			}
 
 			// handle focus movement (tab/shift+tab/up/down)